@@ -0,0 +1,45 @@
+package maps
+
+// Hints describes the requirements of a map so that NewAutoMap can choose an implementation.
+type Hints struct {
+	// Concurrent indicates the map will be used by multiple go routines at the same time.
+	Concurrent bool
+	// Ordered indicates the map must range in a predictable order.
+	Ordered bool
+	// ExpectedSize is the number of items the map is expected to hold. It is used to pre-size the map.
+	ExpectedSize int
+}
+
+// NewAutoMap returns a new empty map whose implementation is chosen from the given hints.
+//
+// The choices are:
+//   - Map, if neither Concurrent nor Ordered is set.
+//   - SafeMap, if only Concurrent is set.
+//   - SliceMap, if only Ordered is set.
+//   - SafeSliceMap, if both Concurrent and Ordered are set.
+//
+// This lets application code state its requirements rather than hard-coding a concrete type.
+func NewAutoMap[K comparable, V any](hints Hints) MapI[K, V] {
+	size := max(hints.ExpectedSize, 0)
+
+	switch {
+	case hints.Concurrent && hints.Ordered:
+		m := new(SafeSliceMap[K, V])
+		m.sm.items = make(map[K]V, size)
+		m.sm.order = make([]K, 0, size)
+		return m
+	case hints.Concurrent:
+		m := new(SafeMap[K, V])
+		m.items = make(map[K]V, size)
+		return m
+	case hints.Ordered:
+		m := new(SliceMap[K, V])
+		m.items = make(map[K]V, size)
+		m.order = make([]K, 0, size)
+		return m
+	default:
+		m := new(Map[K, V])
+		m.items = make(map[K]V, size)
+		return m
+	}
+}
@@ -0,0 +1,36 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAutoMap(t *testing.T) {
+	tests := []struct {
+		name  string
+		hints Hints
+		want  any
+	}{
+		{"default", Hints{}, new(Map[string, int])},
+		{"concurrent", Hints{Concurrent: true}, new(SafeMap[string, int])},
+		{"ordered", Hints{Ordered: true}, new(SliceMap[string, int])},
+		{"concurrent ordered", Hints{Concurrent: true, Ordered: true}, new(SafeSliceMap[string, int])},
+		{"sized", Hints{ExpectedSize: 100}, new(Map[string, int])},
+		{"negative size", Hints{ExpectedSize: -1, Ordered: true}, new(SliceMap[string, int])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAutoMap[string, int](tt.hints)
+			assert.IsType(t, tt.want, m)
+			assert.Equal(t, 0, m.Len())
+			m.Set("b", 2)
+			m.Set("a", 1)
+			assert.Equal(t, 2, m.Len())
+			assert.Equal(t, 1, m.Get("a"))
+			if tt.hints.Ordered {
+				assert.Equal(t, []string{"b", "a"}, m.Keys())
+			}
+		})
+	}
+}
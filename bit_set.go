@@ -0,0 +1,513 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"strings"
+)
+
+// BitSet is a set of non-negative integers that uses one bit for each possible value.
+// A set of the numbers up to a million takes 125KB, far less than a Set[int] with the same values.
+// Use it for dense sets of small integers, like IDs. For values spread over a huge range, the memory
+// used by a BitSet grows with the largest value in the set.
+//
+// Values are ranged in increasing order.
+// Adding a negative value panics.
+//
+// The zero value is ready to use. Do not make a copy of a BitSet using the equality operator (=). Use Clone instead.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates a new BitSet with the given values.
+func NewBitSet(values ...int) *BitSet {
+	m := new(BitSet)
+	m.Add(values...)
+	return m
+}
+
+// trim removes the words at the end that have no values, so that sets with the same values have the same words.
+func (m *BitSet) trim() {
+	i := len(m.words)
+	for i > 0 && m.words[i-1] == 0 {
+		i--
+	}
+	m.words = m.words[:i]
+}
+
+// Clear removes all the values in the set.
+func (m *BitSet) Clear() {
+	if m == nil {
+		return
+	}
+	m.words = nil
+}
+
+// Len returns the number of values in the set.
+func (m *BitSet) Len() (n int) {
+	if m == nil {
+		return 0
+	}
+	for _, w := range m.words {
+		n += bits.OnesCount64(w)
+	}
+	return
+}
+
+// Range calls the given function for each value in the set, in increasing order.
+// The function should return true to continue ranging, or false to stop.
+func (m *BitSet) Range(f func(k int) bool) {
+	if m == nil {
+		return
+	}
+	for i := 0; i < len(m.words); i++ {
+		for w := m.words[i]; w != 0; w &= w - 1 {
+			if !f(i*64 + bits.TrailingZeros64(w)) {
+				return
+			}
+		}
+	}
+}
+
+// NextSet returns the smallest value in the set that is greater than or equal to k,
+// and false if there is no such value.
+//
+// To iterate from a starting point, do this:
+//
+//	for k, ok := s.NextSet(start); ok; k, ok = s.NextSet(k + 1) {
+//		...
+//	}
+func (m *BitSet) NextSet(k int) (int, bool) {
+	if m == nil {
+		return 0, false
+	}
+	k = max(k, 0)
+	i := k / 64
+	if i >= len(m.words) {
+		return 0, false
+	}
+	if w := m.words[i] >> (k % 64); w != 0 {
+		return k + bits.TrailingZeros64(w), true
+	}
+	for i++; i < len(m.words); i++ {
+		if m.words[i] != 0 {
+			return i*64 + bits.TrailingZeros64(m.words[i]), true
+		}
+	}
+	return 0, false
+}
+
+// First returns the smallest value in the set, and false if the set is empty.
+func (m *BitSet) First() (int, bool) {
+	return m.NextSet(0)
+}
+
+// Last returns the largest value in the set, and false if the set is empty.
+func (m *BitSet) Last() (int, bool) {
+	if m == nil {
+		return 0, false
+	}
+	for i := len(m.words) - 1; i >= 0; i-- {
+		if w := m.words[i]; w != 0 {
+			return i*64 + 63 - bits.LeadingZeros64(w), true
+		}
+	}
+	return 0, false
+}
+
+// Has returns true if the value exists in the set.
+func (m *BitSet) Has(k int) bool {
+	if m == nil || k < 0 {
+		return false
+	}
+	i := k / 64
+	return i < len(m.words) && m.words[i]&(1<<(k%64)) != 0
+}
+
+// Delete removes the value from the set. If the value does not exist, nothing happens.
+func (m *BitSet) Delete(k int) {
+	if m == nil || k < 0 || k/64 >= len(m.words) {
+		return
+	}
+	m.words[k/64] &^= 1 << (k % 64)
+	m.trim()
+}
+
+// Values returns a new slice containing the values of the set, in increasing order.
+func (m *BitSet) Values() (values []int) {
+	if n := m.Len(); n > 0 {
+		values = make([]int, 0, n)
+	}
+	m.Range(func(k int) bool {
+		values = append(values, k)
+		return true
+	})
+	return
+}
+
+// Add adds the values to the set.
+// If a value already exists, nothing changes. Adding a negative value panics.
+func (m *BitSet) Add(k ...int) SetI[int] {
+	for _, v := range k {
+		if v < 0 {
+			panic("cannot add a negative value to a BitSet")
+		}
+		i := v / 64
+		if i >= len(m.words) {
+			m.words = append(m.words, make([]uint64, i+1-len(m.words))...)
+		}
+		m.words[i] |= 1 << (v % 64)
+	}
+	return m
+}
+
+// AddNew adds the value to the set, and returns true if it was not already in the set.
+// Adding a negative value panics.
+func (m *BitSet) AddNew(k int) bool {
+	if m.Has(k) {
+		return false
+	}
+	m.Add(k)
+	return true
+}
+
+// Merge adds the values from the given set to the set.
+// Deprecated: Call Copy instead.
+func (m *BitSet) Merge(in SetI[int]) {
+	m.Copy(in)
+}
+
+// Copy adds the values from in to the set.
+func (m *BitSet) Copy(in SetI[int]) {
+	if in == nil {
+		return
+	}
+	if b, ok := in.(*BitSet); ok {
+		m.Or(b)
+		return
+	}
+	in.Range(func(k int) bool {
+		m.Add(k)
+		return true
+	})
+}
+
+// Or adds the values of b to the set, so the set becomes the union of the two sets.
+func (m *BitSet) Or(b *BitSet) {
+	if b == nil {
+		return
+	}
+	if len(b.words) > len(m.words) {
+		m.words = append(m.words, make([]uint64, len(b.words)-len(m.words))...)
+	}
+	for i, w := range b.words {
+		m.words[i] |= w
+	}
+}
+
+// And removes the values that are not in b, so the set becomes the intersection of the two sets.
+func (m *BitSet) And(b *BitSet) {
+	if b == nil {
+		m.Clear()
+		return
+	}
+	for i := range m.words {
+		if i < len(b.words) {
+			m.words[i] &= b.words[i]
+		} else {
+			m.words[i] = 0
+		}
+	}
+	m.trim()
+}
+
+// AndNot removes the values that are in b, so the set becomes the difference of the two sets.
+func (m *BitSet) AndNot(b *BitSet) {
+	if b == nil {
+		return
+	}
+	for i := range min(len(m.words), len(b.words)) {
+		m.words[i] &^= b.words[i]
+	}
+	m.trim()
+}
+
+// Xor changes the set to have the values that are in either set, but not in both.
+func (m *BitSet) Xor(b *BitSet) {
+	if b == nil {
+		return
+	}
+	if len(b.words) > len(m.words) {
+		m.words = append(m.words, make([]uint64, len(b.words)-len(m.words))...)
+	}
+	for i, w := range b.words {
+		m.words[i] ^= w
+	}
+	m.trim()
+}
+
+// Union returns a new BitSet with the values that are in either the set or s.
+func (m *BitSet) Union(s SetReader[int]) *BitSet {
+	r := m.Clone()
+	if b, ok := s.(*BitSet); ok {
+		r.Or(b)
+	} else {
+		unionInto[int](r, nil, s)
+	}
+	return r
+}
+
+// Intersect returns a new BitSet with the values that are in both the set and s.
+func (m *BitSet) Intersect(s SetReader[int]) *BitSet {
+	if b, ok := s.(*BitSet); ok {
+		r := m.Clone()
+		r.And(b)
+		return r
+	}
+	r := new(BitSet)
+	intersectInto[int](r, m, s)
+	return r
+}
+
+// Difference returns a new BitSet with the values that are in the set, but not in s.
+func (m *BitSet) Difference(s SetReader[int]) *BitSet {
+	if b, ok := s.(*BitSet); ok {
+		r := m.Clone()
+		r.AndNot(b)
+		return r
+	}
+	r := new(BitSet)
+	differenceInto[int](r, m, s)
+	return r
+}
+
+// SymmetricDifference returns a new BitSet with the values that are in either the set or s, but not in both.
+func (m *BitSet) SymmetricDifference(s SetReader[int]) *BitSet {
+	if b, ok := s.(*BitSet); ok {
+		r := m.Clone()
+		r.Xor(b)
+		return r
+	}
+	r := new(BitSet)
+	symmetricDifferenceInto[int](r, m, s)
+	return r
+}
+
+// IsSubsetOf returns true if every value of the set is also in s.
+func (m *BitSet) IsSubsetOf(s SetReader[int]) bool {
+	if b, ok := s.(*BitSet); ok {
+		if m == nil {
+			return true
+		}
+		var words []uint64
+		if b != nil {
+			words = b.words
+		}
+		for i, w := range m.words {
+			var w2 uint64
+			if i < len(words) {
+				w2 = words[i]
+			}
+			if w&^w2 != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return IsSubset[int](m, s)
+}
+
+// IsSupersetOf returns true if every value of s is also in the set.
+func (m *BitSet) IsSupersetOf(s SetReader[int]) bool {
+	if b, ok := s.(*BitSet); ok {
+		return b.IsSubsetOf(m)
+	}
+	return IsSubset[int](s, m)
+}
+
+// IsDisjoint returns true if the set and s have no values in common.
+func (m *BitSet) IsDisjoint(s SetReader[int]) bool {
+	if b, ok := s.(*BitSet); ok {
+		if m == nil || b == nil {
+			return true
+		}
+		for i := range min(len(m.words), len(b.words)) {
+			if m.words[i]&b.words[i] != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return IsDisjoint[int](m, s)
+}
+
+// Equal returns true if the two sets are the same length and contain the same values.
+func (m *BitSet) Equal(m2 SetI[int]) bool {
+	if b, ok := m2.(*BitSet); ok {
+		if m == nil || b == nil {
+			return m.Len() == b.Len()
+		}
+		if len(m.words) != len(b.words) {
+			return false
+		}
+		for i, w := range m.words {
+			if w != b.words[i] {
+				return false
+			}
+		}
+		return true
+	}
+	if m2 == nil {
+		return m.Len() == 0
+	}
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k int) bool {
+		if !m.Has(k) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the set to a byte stream.
+func (m *BitSet) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	var words []uint64
+	if m != nil {
+		words = m.words
+	}
+	err := enc.Encode(words)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a BitSet.
+// The values are added to the set.
+func (m *BitSet) UnmarshalBinary(data []byte) (err error) {
+	var words []uint64
+
+	b := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(b)
+	if err = dec.Decode(&words); err == nil {
+		b2 := &BitSet{words: words}
+		b2.trim()
+		m.Or(b2)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the set into a JSON array of its values.
+func (m *BitSet) MarshalJSON() (out []byte, err error) {
+	values := m.Values()
+	if values == nil {
+		values = []int{}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a JSON array of numbers to a BitSet.
+// The values are added to the set.
+func (m *BitSet) UnmarshalJSON(in []byte) (err error) {
+	var values []int
+	if err = json.Unmarshal(in, &values); err != nil {
+		return
+	}
+	for _, v := range values {
+		if v < 0 {
+			return fmt.Errorf("maps: cannot add the negative value %d to a BitSet", v)
+		}
+	}
+	m.Add(values...)
+	return
+}
+
+// String returns the set as a string, in increasing order.
+func (m *BitSet) String() string {
+	var s strings.Builder
+	s.WriteString("{")
+	m.Range(func(k int) bool {
+		if s.Len() > 1 {
+			s.WriteString(",")
+		}
+		fmt.Fprint(&s, k)
+		return true
+	})
+	s.WriteString("}")
+	return s.String()
+}
+
+// All returns an iterator over all the values in the set, in increasing order.
+func (m *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		m.Range(yield)
+	}
+}
+
+// Insert adds the values from seq to the set.
+func (m *BitSet) Insert(seq iter.Seq[int]) {
+	for k := range seq {
+		m.Add(k)
+	}
+}
+
+// CollectBitSet collects values from seq into a new BitSet
+// and returns it.
+func CollectBitSet(seq iter.Seq[int]) *BitSet {
+	m := new(BitSet)
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the BitSet.
+func (m *BitSet) Clone() *BitSet {
+	m1 := new(BitSet)
+	if m != nil && len(m.words) > 0 {
+		m1.words = append([]uint64(nil), m.words...)
+	}
+	return m1
+}
+
+// DeleteFunc deletes any values for which del returns true.
+func (m *BitSet) DeleteFunc(del func(int) bool) {
+	if m == nil {
+		return
+	}
+	for i := range m.words {
+		for w := m.words[i]; w != 0; w &= w - 1 {
+			b := bits.TrailingZeros64(w)
+			if del(i*64 + b) {
+				m.words[i] &^= 1 << b
+			}
+		}
+	}
+	m.trim()
+}
+
+// Filter returns a new BitSet with the values of the set for which keep returns true.
+// The set is not changed.
+func (m *BitSet) Filter(keep func(int) bool) *BitSet {
+	m1 := new(BitSet)
+	m.Range(func(k int) bool {
+		if keep(k) {
+			m1.Add(k)
+		}
+		return true
+	})
+	return m1
+}
+
+// Hash returns a hash of the values of the set, made with seed. Sets that are Equal have the same hash.
+func (m *BitSet) Hash(seed maphash.Seed) uint64 {
+	return hashOrderedValues(seed, m.Range)
+}
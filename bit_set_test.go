@@ -0,0 +1,186 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleBitSet() {
+	s := NewBitSet(1, 64, 3, 200)
+	s.And(NewBitSet(1, 2, 3, 64))
+	fmt.Println(s, s.Len())
+	// Output: {1,3,64} 3
+}
+
+func ExampleBitSet_NextSet() {
+	s := NewBitSet(5, 70, 130)
+	for k, ok := s.NextSet(6); ok; k, ok = s.NextSet(k + 1) {
+		fmt.Println(k)
+	}
+	// Output: 70
+	// 130
+}
+
+func TestBitSet(t *testing.T) {
+	var s BitSet
+	s.Add(0, 63, 64, 1000)
+	assert.Equal(t, 4, s.Len())
+	assert.True(t, s.Has(63))
+	assert.False(t, s.Has(62))
+	assert.False(t, s.Has(-1))
+	assert.False(t, s.Has(5000))
+	assert.Equal(t, []int{0, 63, 64, 1000}, s.Values())
+	assert.Equal(t, "{0,63,64,1000}", s.String())
+
+	s.Delete(1000)
+	assert.Len(t, s.words, 2, "empty words at the end are removed")
+	s.Delete(5000)
+	s.Delete(-1)
+	assert.True(t, s.Equal(NewSet(0, 63, 64)))
+	assert.True(t, s.Equal(NewBitSet(0, 63, 64)))
+	assert.False(t, s.Equal(NewBitSet(0, 63)))
+	assert.False(t, s.Equal(NewSet(0, 63, 65)))
+
+	s2 := s.Clone()
+	s2.DeleteFunc(func(k int) bool {
+		return k > 60
+	})
+	assert.Equal(t, []int{0}, s2.Values())
+	assert.Equal(t, 3, s.Len())
+
+	s2.Copy(NewSet(7))
+	s2.Merge(NewBitSet(200))
+	assert.Equal(t, []int{0, 7, 200}, s2.Values())
+	assert.Equal(t, []int{0, 7, 200}, CollectBitSet(s2.All()).Values())
+
+	assert.Panics(t, func() {
+		s.Add(-1)
+	})
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	a := NewBitSet(1, 2, 100, 300)
+	b := NewBitSet(2, 3, 300)
+
+	or := a.Clone()
+	or.Or(b)
+	assert.Equal(t, []int{1, 2, 3, 100, 300}, or.Values())
+
+	and := a.Clone()
+	and.And(b)
+	assert.Equal(t, []int{2, 300}, and.Values())
+	and.And(NewBitSet(2))
+	assert.Equal(t, []int{2}, and.Values())
+	assert.Len(t, and.words, 1)
+
+	andNot := a.Clone()
+	andNot.AndNot(b)
+	assert.Equal(t, []int{1, 100}, andNot.Values())
+
+	xor := a.Clone()
+	xor.Xor(b)
+	assert.Equal(t, []int{1, 3, 100}, xor.Values())
+	xor.Xor(xor.Clone())
+	assert.Equal(t, 0, xor.Len())
+	assert.Empty(t, xor.words)
+
+	a.And(nil)
+	assert.Equal(t, 0, a.Len())
+}
+
+func TestBitSet_NextSet(t *testing.T) {
+	s := NewBitSet(3, 64, 129)
+	k, ok := s.NextSet(-5)
+	assert.Equal(t, 3, k)
+	assert.True(t, ok)
+	k, _ = s.NextSet(64)
+	assert.Equal(t, 64, k)
+	k, _ = s.NextSet(65)
+	assert.Equal(t, 129, k)
+	_, ok = s.NextSet(130)
+	assert.False(t, ok)
+	_, ok = s.NextSet(1000)
+	assert.False(t, ok)
+}
+
+func TestBitSet_Marshal(t *testing.T) {
+	s := NewBitSet(1, 2, 1000)
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2,1000]", string(b))
+	var s2 BitSet
+	assert.NoError(t, json.Unmarshal(b, &s2))
+	assert.True(t, s.Equal(&s2))
+	assert.Error(t, s2.UnmarshalJSON([]byte("[-1]")))
+
+	b, err = json.Marshal(new(BitSet))
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+
+	b, err = s.MarshalBinary()
+	assert.NoError(t, err)
+	var s3 BitSet
+	assert.NoError(t, s3.UnmarshalBinary(b))
+	assert.True(t, s.Equal(&s3))
+
+	s4 := NewBitSet(200)
+	assert.NoError(t, s4.UnmarshalBinary(b))
+	assert.True(t, s4.Has(200), "the values are added to the set")
+	assert.Equal(t, s.Len()+1, s4.Len())
+}
+
+func TestBitSet_Nil(t *testing.T) {
+	var s *BitSet
+	assert.Equal(t, 0, s.Len())
+	assert.False(t, s.Has(1))
+	assert.Nil(t, s.Values())
+	assert.True(t, s.Equal(NewBitSet()))
+	assert.True(t, s.Equal(nil))
+	assert.Equal(t, "{}", s.String())
+	assert.Equal(t, 0, s.Clone().Len())
+	_, ok := s.NextSet(0)
+	assert.False(t, ok)
+}
+
+func TestBitSet_Filter(t *testing.T) {
+	m := NewBitSet(1, 2, 3, 130)
+	m2 := m.Filter(func(k int) bool {
+		return k%2 == 0
+	})
+	assert.Equal(t, []int{2, 130}, m2.Values())
+	assert.Equal(t, 4, m.Len())
+}
+
+func TestBitSet_AddNew(t *testing.T) {
+	m := new(BitSet)
+	assert.True(t, m.AddNew(70))
+	assert.False(t, m.AddNew(70))
+	assert.True(t, m.AddNew(0))
+	assert.Equal(t, []int{0, 70}, m.Values())
+	assert.Panics(t, func() {
+		m.AddNew(-1)
+	})
+}
+
+func TestBitSet_FirstLast(t *testing.T) {
+	m := NewBitSet(300, 5, 64)
+	k, ok := m.First()
+	assert.Equal(t, 5, k)
+	assert.True(t, ok)
+	k, _ = m.Last()
+	assert.Equal(t, 300, k)
+
+	m.Delete(300)
+	k, _ = m.Last()
+	assert.Equal(t, 64, k)
+
+	m = new(BitSet)
+	_, ok = m.First()
+	assert.False(t, ok)
+	_, ok = m.Last()
+	assert.False(t, ok)
+}
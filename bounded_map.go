@@ -0,0 +1,320 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrFull is returned when a new key is added to a map that has reached its maximum size.
+var ErrFull = errors.New("maps: the map is full")
+
+// BoundedMap is a map with a maximum size. Unlike RingMap and CacheMap, a full BoundedMap does not remove
+// items to make room for new keys. Instead, new keys are refused until items are deleted.
+// Use it to protect a service from unbounded memory growth when the keys come from an untrusted source.
+//
+// SetStrict and SetMany return ErrFull when a new key does not fit. Set cannot return an error,
+// since it is part of the MapI interface, so it ignores new keys when the map is full.
+// Values of keys that are already in the map can always be changed.
+//
+// The zero value is NOT settable. Use NewBoundedMap to create a BoundedMap with a maximum size.
+type BoundedMap[K comparable, V any] struct {
+	items    StdMap[K, V]
+	capacity int
+}
+
+// NewBoundedMap creates a new BoundedMap that can hold up to capacity items.
+func NewBoundedMap[K comparable, V any](capacity int) *BoundedMap[K, V] {
+	if capacity <= 0 {
+		panic("the capacity of a BoundedMap must be greater than zero")
+	}
+	return &BoundedMap[K, V]{
+		capacity: capacity,
+	}
+}
+
+// Cap returns the maximum number of items the map can hold.
+func (m *BoundedMap[K, V]) Cap() int {
+	if m == nil {
+		return 0
+	}
+	return m.capacity
+}
+
+// Full returns true if the map cannot accept any new keys.
+func (m *BoundedMap[K, V]) Full() bool {
+	return m.Len() >= m.Cap()
+}
+
+// SetStrict sets the key to the given value, or returns ErrFull if the key is new and the map is full.
+func (m *BoundedMap[K, V]) SetStrict(k K, v V) error {
+	if m.Cap() == 0 {
+		panic("cannot call Set() on a BoundedMap with no capacity")
+	}
+	if _, ok := m.items[k]; !ok {
+		if len(m.items) >= m.capacity {
+			return ErrFull
+		}
+		if m.items == nil {
+			m.items = make(map[K]V)
+		}
+	}
+	m.items[k] = v
+	return nil
+}
+
+// Set sets the key to the given value. If the key is new and the map is full, the key is not added.
+// Call SetStrict to find out whether the key was added.
+func (m *BoundedMap[K, V]) Set(k K, v V) {
+	_ = m.SetStrict(k, v)
+}
+
+// SetMany sets all the given key/value pairs, or returns ErrFull and changes nothing if the new keys
+// do not all fit in the map.
+func (m *BoundedMap[K, V]) SetMany(pairs ...Pair[K, V]) error {
+	if m.Cap() == 0 {
+		panic("cannot call SetMany() on a BoundedMap with no capacity")
+	}
+	newKeys := make(map[K]struct{})
+	for _, p := range pairs {
+		if _, ok := m.items[p.Key]; !ok {
+			newKeys[p.Key] = struct{}{}
+		}
+	}
+	if len(m.items)+len(newKeys) > m.capacity {
+		return ErrFull
+	}
+	for _, p := range pairs {
+		_ = m.SetStrict(p.Key, p.Value)
+	}
+	return nil
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *BoundedMap[K, V]) Get(k K) (v V) {
+	if m == nil {
+		return
+	}
+	return m.items.Get(k)
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *BoundedMap[K, V]) Load(k K) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	return m.items.Load(k)
+}
+
+// Has returns true if the key exists.
+func (m *BoundedMap[K, V]) Has(k K) bool {
+	if m == nil {
+		return false
+	}
+	return m.items.Has(k)
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *BoundedMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	return m.items.Delete(k)
+}
+
+// Clear removes all the items in the map. The capacity is not changed.
+func (m *BoundedMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.items = nil
+}
+
+// Len returns the number of items in the map.
+func (m *BoundedMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items)
+}
+
+// Range calls the given function for each key,value pair in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+func (m *BoundedMap[K, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	m.items.Range(f)
+}
+
+// Keys returns a new slice containing the keys of the map.
+func (m *BoundedMap[K, V]) Keys() []K {
+	if m == nil {
+		return nil
+	}
+	return m.items.Keys()
+}
+
+// Values returns a new slice containing the values of the map.
+func (m *BoundedMap[K, V]) Values() []V {
+	if m == nil {
+		return nil
+	}
+	return m.items.Values()
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *BoundedMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+// New keys that do not fit are not added.
+func (m *BoundedMap[K, V]) Copy(in MapI[K, V]) {
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *BoundedMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m == nil {
+		return m2 == nil || m2.Len() == 0
+	}
+	return m.items.Equal(m2)
+}
+
+// String outputs the map as a string.
+func (m *BoundedMap[K, V]) String() string {
+	if m == nil {
+		return ""
+	}
+	return m.items.String()
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *BoundedMap[K, V]) MarshalBinary() (data []byte, err error) {
+	buf := new(bytes.Buffer)
+	encoder := gob.NewEncoder(buf)
+
+	err = encoder.Encode(m.Cap())
+	if err == nil {
+		err = encoder.Encode(map[K]V(m.items))
+	}
+	data = buf.Bytes()
+	return
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// BoundedMap. It returns ErrFull if the data has more items than its capacity, and an error if the capacity
+// is negative.
+func (m *BoundedMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var capacity int
+	var items map[K]V
+
+	buf := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buf)
+	if err = dec.Decode(&capacity); err == nil {
+		err = dec.Decode(&items)
+	}
+	if err == nil {
+		if capacity < 0 {
+			return fmt.Errorf("maps: cannot unmarshal a BoundedMap with a capacity of %d", capacity)
+		}
+		if len(items) > capacity {
+			return ErrFull
+		}
+		m.capacity = capacity
+		m.items = items
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+// The capacity is not included.
+func (m *BoundedMap[K, V]) MarshalJSON() (data []byte, err error) {
+	if m == nil {
+		return
+	}
+	return m.items.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a BoundedMap.
+// The JSON must start with an object. The current capacity of the map is kept, and ErrFull is
+// returned if the JSON has more items than will fit. Unmarshalling into a zero value BoundedMap
+// sets the capacity to the number of items, so to limit the size of untrusted JSON,
+// create the map with NewBoundedMap first.
+func (m *BoundedMap[K, V]) UnmarshalJSON(data []byte) (err error) {
+	var items map[K]V
+
+	if err = json.Unmarshal(data, &items); err == nil {
+		if m.capacity == 0 {
+			m.capacity = max(len(items), 1)
+		} else if len(items) > m.capacity {
+			return ErrFull
+		}
+		m.items = items
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map.
+func (m *BoundedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+func (m *BoundedMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+func (m *BoundedMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden. New keys that do not fit are not added.
+func (m *BoundedMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// Clone returns a copy of the BoundedMap with the same capacity. This is a shallow clone:
+// the new keys and values are set using ordinary assignment. Cloning a zero value returns a new zero value.
+func (m *BoundedMap[K, V]) Clone() *BoundedMap[K, V] {
+	if m.Cap() == 0 {
+		return new(BoundedMap[K, V]) // the zero value has no capacity
+	}
+	m1 := NewBoundedMap[K, V](m.Cap())
+	m1.items = m.items.Clone()
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *BoundedMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil {
+		return
+	}
+	m.items.DeleteFunc(del)
+}
@@ -0,0 +1,125 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedMap_Mapi(t *testing.T) {
+	runMapiTests[BoundedMap[string, int]](t,
+		func(sources ...mapT) MapI[string, int] {
+			m := NewBoundedMap[string, int](10)
+			for _, s := range sources {
+				m.Merge(s)
+			}
+			return m
+		},
+	)
+}
+
+func init() {
+	gob.Register(new(BoundedMap[string, int]))
+}
+
+func ExampleBoundedMap_SetStrict() {
+	m := NewBoundedMap[string, int](2)
+	fmt.Println(m.SetStrict("a", 1))
+	fmt.Println(m.SetStrict("b", 2))
+	fmt.Println(m.SetStrict("c", 3))
+	fmt.Println(m.SetStrict("a", 4))
+	fmt.Println(m)
+	// Output: <nil>
+	// <nil>
+	// maps: the map is full
+	// <nil>
+	// {"a":4, "b":2}
+}
+
+func TestBoundedMap(t *testing.T) {
+	assert.Panics(t, func() {
+		NewBoundedMap[string, int](0)
+	})
+	assert.Panics(t, func() {
+		var m BoundedMap[string, int]
+		m.Set("a", 1)
+	})
+
+	m := NewBoundedMap[string, int](3)
+	assert.Equal(t, 3, m.Cap())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	assert.False(t, m.Full())
+	assert.ErrorIs(t, m.SetMany(Pair[string, int]{"c", 3}, Pair[string, int]{"d", 4}), ErrFull)
+	assert.Equal(t, 2, m.Len())
+	assert.NoError(t, m.SetMany(Pair[string, int]{"a", 5}, Pair[string, int]{"c", 3}, Pair[string, int]{"c", 6}))
+	assert.Equal(t, 6, m.Get("c"))
+	assert.True(t, m.Full())
+
+	m.Set("d", 4) // ignored
+	assert.False(t, m.Has("d"))
+	assert.ErrorIs(t, m.SetStrict("d", 4), ErrFull)
+	m.Delete("a")
+	assert.NoError(t, m.SetStrict("d", 4))
+
+	m.Copy(mapT{"e": 5, "f": 6})
+	assert.Equal(t, 3, m.Len())
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	assert.Equal(t, 3, m2.Cap())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 3, m.Cap())
+}
+
+func TestBoundedMap_Marshal(t *testing.T) {
+	m := NewBoundedMap[string, int](2)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	data, err := m.MarshalBinary()
+	assert.NoError(t, err)
+
+	var m2 BoundedMap[string, int]
+	assert.NoError(t, m2.UnmarshalBinary(data))
+	assert.Equal(t, 2, m2.Cap())
+	assert.True(t, m.Equal(&m2))
+
+	m3 := NewBoundedMap[string, int](3)
+	assert.NoError(t, m3.UnmarshalJSON([]byte(`{"a":1,"b":2,"c":3}`)))
+	assert.Equal(t, 3, m3.Len())
+	assert.ErrorIs(t, m3.UnmarshalJSON([]byte(`{"a":1,"b":2,"c":3,"d":4}`)), ErrFull)
+	assert.Equal(t, 3, m3.Len())
+
+	var m4 BoundedMap[string, int]
+	assert.NoError(t, m4.UnmarshalJSON([]byte(`{"a":1,"b":2}`)))
+	assert.Equal(t, 2, m4.Cap())
+
+	var m5 BoundedMap[string, int]
+	assert.Equal(t, 0, m5.Clone().Cap())
+	data, err = m5.MarshalBinary()
+	assert.NoError(t, err)
+	assert.NoError(t, m4.UnmarshalBinary(data))
+	assert.Equal(t, 0, m4.Cap())
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, -1, map[string]int(nil))))
+	assert.ErrorIs(t, m4.UnmarshalBinary(gobEncode(t, 1, map[string]int{"a": 1, "b": 2})), ErrFull)
+}
+
+func TestBoundedMap_Nil(t *testing.T) {
+	var m *BoundedMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Cap())
+	assert.True(t, m.Full())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Keys())
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "", m.String())
+	assert.NotPanics(t, func() {
+		m.Clear()
+	})
+}
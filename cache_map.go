@@ -0,0 +1,408 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"iter"
+)
+
+// CacheMap is a map with a fixed capacity that uses an EvictionPolicy to choose which item to remove
+// when a new key is added to a full map. LRUPolicy and LFUPolicy are provided, and other policies can be
+// plugged in by implementing the EvictionPolicy interface.
+//
+// Calls to Set, Get and Load tell the policy that the key was used. Has, Range and the other methods
+// that read the whole map do not.
+//
+// The zero value is NOT settable. Use NewCacheMap to create a CacheMap with a capacity.
+// A CacheMap is not safe for concurrent use, since even reading from it updates the policy.
+type CacheMap[K comparable, V any] struct {
+	items    StdMap[K, V]
+	policy   EvictionPolicy[K]
+	capacity int
+	stats    CacheStats
+
+	onEvict func(k K, v V, reason EvictReason)
+}
+
+// CacheStats reports how well a cache-like map is working.
+type CacheStats struct {
+	// Hits is the number of calls to Get or Load that found the key.
+	Hits int
+	// Misses is the number of calls to Get or Load that did not find the key.
+	Misses int
+	// Evictions is the number of items that were removed to make room for a new key.
+	// Items removed by Delete, DeleteFunc or Clear are not counted.
+	Evictions int
+	// Size is the number of items in the map.
+	Size int
+}
+
+// NewCacheMap creates a new CacheMap that can hold up to capacity items, and that uses the given policy
+// to evict items. If policy is nil, an LRUPolicy is used. The policy must not be shared with another map.
+func NewCacheMap[K comparable, V any](capacity int, policy EvictionPolicy[K]) *CacheMap[K, V] {
+	if capacity <= 0 {
+		panic("the capacity of a CacheMap must be greater than zero")
+	}
+	if policy == nil {
+		policy = NewLRUPolicy[K]()
+	}
+	return &CacheMap[K, V]{
+		items:    make(map[K]V, capacity),
+		policy:   policy,
+		capacity: capacity,
+	}
+}
+
+// Cap returns the maximum number of items the map can hold.
+func (m *CacheMap[K, V]) Cap() int {
+	if m == nil {
+		return 0
+	}
+	return m.capacity
+}
+
+// Policy returns the eviction policy of the map.
+func (m *CacheMap[K, V]) Policy() EvictionPolicy[K] {
+	if m == nil {
+		return nil
+	}
+	return m.policy
+}
+
+// OnEvict sets a function that is called after an item is removed from the map, so that resources held by
+// the value, like files or connections, can be released. The reason tells whether the item was removed
+// to make room for a new key, or by Delete, DeleteFunc or Clear. Replacing the value of an existing key does not
+// call f. Pass nil to remove the callback. The callback is not copied by Clone.
+func (m *CacheMap[K, V]) OnEvict(f func(k K, v V, reason EvictReason)) {
+	m.onEvict = f
+}
+
+// evicted calls the OnEvict callback, if there is one.
+func (m *CacheMap[K, V]) evicted(k K, v V, reason EvictReason) {
+	if m.onEvict != nil {
+		m.onEvict(k, v, reason)
+	}
+}
+
+// Stats returns the statistics of the map since it was created or since the last call to ResetStats.
+func (m *CacheMap[K, V]) Stats() CacheStats {
+	if m == nil {
+		return CacheStats{}
+	}
+	s := m.stats
+	s.Size = len(m.items)
+	return s
+}
+
+// ResetStats sets the hit, miss and eviction counts to zero.
+func (m *CacheMap[K, V]) ResetStats() {
+	if m == nil {
+		return
+	}
+	m.stats = CacheStats{}
+}
+
+// Set sets the given key to the given value.
+// If the key is new and the map is full, the policy chooses an item to remove.
+func (m *CacheMap[K, V]) Set(k K, v V) {
+	m.Push(k, v)
+}
+
+// Push sets the given key to the given value, and returns the item that was removed to make room for it.
+// If the key is new and the map is full, the policy chooses an item to remove, and ok will be true.
+func (m *CacheMap[K, V]) Push(k K, v V) (evicted Pair[K, V], ok bool) {
+	if m.Cap() == 0 {
+		panic("cannot call Set() on a CacheMap with no capacity")
+	}
+	if _, exists := m.items[k]; exists {
+		m.items[k] = v
+		m.policy.Used(k)
+		return
+	}
+	if m.items == nil {
+		m.items = make(map[K]V, m.capacity)
+	}
+	if len(m.items) == m.capacity {
+		oldKey := m.policy.Victim()
+		evicted = Pair[K, V]{oldKey, m.items[oldKey]}
+		ok = true
+		delete(m.items, oldKey)
+		m.stats.Evictions++
+	}
+	m.items[k] = v
+	m.policy.Added(k)
+	if ok {
+		m.evicted(evicted.Key, evicted.Value, EvictedByCapacity)
+	}
+	return
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *CacheMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *CacheMap[K, V]) Load(k K) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	if v, ok = m.items[k]; ok {
+		m.policy.Used(k)
+		m.stats.Hits++
+	} else {
+		m.stats.Misses++
+	}
+	return
+}
+
+// Has returns true if the key exists. Has does not count as a use of the key.
+func (m *CacheMap[K, V]) Has(k K) bool {
+	if m == nil {
+		return false
+	}
+	return m.items.Has(k)
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *CacheMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	var ok bool
+	if v, ok = m.items[k]; ok {
+		delete(m.items, k)
+		m.policy.Removed(k)
+		m.evicted(k, v, EvictedByDelete)
+	}
+	return
+}
+
+// Clear removes all the items in the map. The capacity and policy are not changed.
+func (m *CacheMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	items := m.items
+	m.items = nil
+	if m.policy != nil {
+		m.policy.Clear()
+	}
+	if m.onEvict != nil {
+		for k, v := range items {
+			m.onEvict(k, v, EvictedByDelete)
+		}
+	}
+}
+
+// Len returns the number of items in the map.
+func (m *CacheMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items)
+}
+
+// Range calls the given function for each key,value pair in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+// The order of the iteration is not determinate, and ranging does not count as a use of the keys.
+func (m *CacheMap[K, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	m.items.Range(f)
+}
+
+// Keys returns a new slice containing the keys of the map.
+func (m *CacheMap[K, V]) Keys() []K {
+	if m == nil {
+		return nil
+	}
+	return m.items.Keys()
+}
+
+// Values returns a new slice containing the values of the map.
+func (m *CacheMap[K, V]) Values() []V {
+	if m == nil {
+		return nil
+	}
+	return m.items.Values()
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *CacheMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+// If in has more items than will fit, the policy chooses which items to remove.
+func (m *CacheMap[K, V]) Copy(in MapI[K, V]) {
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *CacheMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m == nil {
+		return m2 == nil || m2.Len() == 0
+	}
+	return m.items.Equal(m2)
+}
+
+// String outputs the map as a string.
+func (m *CacheMap[K, V]) String() string {
+	if m == nil {
+		return ""
+	}
+	return m.items.String()
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+// The capacity is included, but the state of the policy is not.
+func (m *CacheMap[K, V]) MarshalBinary() (data []byte, err error) {
+	buf := new(bytes.Buffer)
+	encoder := gob.NewEncoder(buf)
+
+	err = encoder.Encode(m.Cap())
+	if err == nil {
+		err = encoder.Encode(map[K]V(m.items))
+	}
+	data = buf.Bytes()
+	return
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// CacheMap. The current policy of the map is cleared and kept. If the map has no policy, an LRUPolicy is used.
+// An error is returned if the capacity cannot hold the items.
+func (m *CacheMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var capacity int
+	var items map[K]V
+
+	buf := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buf)
+	if err = dec.Decode(&capacity); err == nil {
+		err = dec.Decode(&items)
+	}
+	if err == nil {
+		err = checkCapacity(capacity, len(items))
+	}
+	if err == nil {
+		m.reset(capacity, items)
+	}
+	return
+}
+
+// reset replaces the contents of the map with items, keeping the current policy.
+// A capacity of zero makes the map a zero value.
+func (m *CacheMap[K, V]) reset(capacity int, items map[K]V) {
+	policy := m.policy
+	if policy != nil {
+		policy.Clear()
+	}
+	onEvict := m.onEvict
+	if capacity == 0 {
+		*m = CacheMap[K, V]{policy: policy}
+	} else {
+		*m = *NewCacheMap[K, V](capacity, policy)
+	}
+	m.onEvict = onEvict
+	for k, v := range items {
+		m.Set(k, v)
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *CacheMap[K, V]) MarshalJSON() (data []byte, err error) {
+	if m == nil {
+		return
+	}
+	return m.items.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a CacheMap.
+// The JSON must start with an object. The current policy of the map is cleared and kept.
+// If the map does not have enough capacity to hold all the items, its capacity will be increased.
+func (m *CacheMap[K, V]) UnmarshalJSON(data []byte) (err error) {
+	var items map[K]V
+
+	if err = json.Unmarshal(data, &items); err == nil {
+		m.reset(max(m.Cap(), len(items), 1), items)
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map.
+// Iterating does not count as a use of the keys.
+func (m *CacheMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+func (m *CacheMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+func (m *CacheMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden. If the map fills up, the policy chooses which items to remove.
+func (m *CacheMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// Clone returns a copy of the CacheMap that uses policy to evict items. This is a shallow clone:
+// the new keys and values are set using ordinary assignment. Since policies cannot be copied, the items are
+// added to the new policy as if they were new keys. If policy is nil, an LRUPolicy is used.
+// Cloning a zero value returns a new zero value.
+func (m *CacheMap[K, V]) Clone(policy EvictionPolicy[K]) *CacheMap[K, V] {
+	if m.Cap() == 0 {
+		return new(CacheMap[K, V]) // the zero value has no capacity
+	}
+	m1 := NewCacheMap[K, V](m.Cap(), policy)
+	m1.Copy(m)
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *CacheMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil {
+		return
+	}
+	var deleted []Pair[K, V]
+	for k, v := range m.items {
+		if del(k, v) {
+			delete(m.items, k)
+			m.policy.Removed(k)
+			if m.onEvict != nil {
+				deleted = append(deleted, Pair[K, V]{k, v})
+			}
+		}
+	}
+	for _, p := range deleted {
+		m.onEvict(p.Key, p.Value, EvictedByDelete)
+	}
+}
@@ -0,0 +1,235 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheMap_Mapi(t *testing.T) {
+	runMapiTests[CacheMap[string, int]](t,
+		func(sources ...mapT) MapI[string, int] {
+			m := NewCacheMap[string, int](10, nil)
+			for _, s := range sources {
+				m.Merge(s)
+			}
+			return m
+		},
+	)
+	runMapiTests[CacheMap[string, int]](t,
+		func(sources ...mapT) MapI[string, int] {
+			m := NewCacheMap[string, int](10, NewLFUPolicy[string]())
+			for _, s := range sources {
+				m.Merge(s)
+			}
+			return m
+		},
+	)
+}
+
+func init() {
+	gob.Register(new(CacheMap[string, int]))
+}
+
+func ExampleCacheMap() {
+	m := NewCacheMap[string, int](2, NewLRUPolicy[string]())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Get("a")
+	evicted, _ := m.Push("c", 3)
+	fmt.Println(evicted.Key)
+	fmt.Println(m)
+	// Output: b
+	// {"a":1, "c":3}
+}
+
+// fifoPolicy is an example of a user-supplied policy that evicts the oldest key.
+type fifoPolicy[K comparable] struct {
+	keys []K
+}
+
+func (p *fifoPolicy[K]) Added(k K) { p.keys = append(p.keys, k) }
+func (p *fifoPolicy[K]) Used(K)    {}
+func (p *fifoPolicy[K]) Removed(k K) {
+	for i, k2 := range p.keys {
+		if k2 == k {
+			p.keys = append(p.keys[:i], p.keys[i+1:]...)
+			return
+		}
+	}
+}
+func (p *fifoPolicy[K]) Victim() (k K) {
+	k, p.keys = p.keys[0], p.keys[1:]
+	return
+}
+func (p *fifoPolicy[K]) Clear() { p.keys = nil }
+
+func TestCacheMap(t *testing.T) {
+	assert.Panics(t, func() {
+		NewCacheMap[string, int](0, nil)
+	})
+	assert.Panics(t, func() {
+		var m CacheMap[string, int]
+		m.Set("a", 1)
+	})
+
+	t.Run("LRU", func(t *testing.T) {
+		m := NewCacheMap[string, int](3, nil)
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Set("c", 3)
+		m.Get("a")
+		m.Set("b", 4)
+		assert.True(t, m.Has("c")) // Has is not a use
+		evicted, ok := m.Push("d", 5)
+		assert.True(t, ok)
+		assert.Equal(t, Pair[string, int]{"c", 3}, evicted)
+		evicted, _ = m.Push("e", 6)
+		assert.Equal(t, "a", evicted.Key)
+
+		assert.Equal(t, 4, m.Delete("b"))
+		m.Set("f", 7)
+		evicted, _ = m.Push("g", 8)
+		assert.Equal(t, "d", evicted.Key)
+	})
+
+	t.Run("LFU", func(t *testing.T) {
+		m := NewCacheMap[string, int](3, NewLFUPolicy[string]())
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Set("c", 3)
+		m.Get("a")
+		m.Get("a")
+		m.Get("c")
+		evicted, _ := m.Push("d", 4)
+		assert.Equal(t, "b", evicted.Key)
+		evicted, _ = m.Push("e", 5)
+		assert.Equal(t, "d", evicted.Key)
+
+		m.DeleteFunc(func(k string, _ int) bool {
+			return k == "a"
+		})
+		m.Set("f", 6)
+		evicted, _ = m.Push("g", 7)
+		assert.Equal(t, "e", evicted.Key)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		m := NewCacheMap[string, int](2, &fifoPolicy[string]{})
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Get("a")
+		evicted, _ := m.Push("c", 3)
+		assert.Equal(t, "a", evicted.Key)
+	})
+
+	m := NewCacheMap[string, int](2, NewLFUPolicy[string]())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m2 := m.Clone(nil)
+	assert.True(t, m.Equal(m2))
+	assert.IsType(t, &LRUPolicy[string]{}, m2.Policy())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 2, m.Cap())
+	m.Set("c", 3)
+	m.Set("d", 4)
+	m.Set("e", 5)
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestCacheMap_BinaryMarshal(t *testing.T) {
+	m := NewCacheMap[string, int](3, nil)
+	m.Set("b", 2)
+	m.Set("a", 1)
+	data, err := m.MarshalBinary()
+	assert.NoError(t, err)
+
+	var m2 CacheMap[string, int]
+	assert.NoError(t, m2.UnmarshalBinary(data))
+	assert.Equal(t, 3, m2.Cap())
+	assert.True(t, m.Equal(&m2))
+	assert.IsType(t, &LRUPolicy[string]{}, m2.Policy())
+
+	m3 := NewCacheMap[string, int](1, NewLFUPolicy[string]())
+	assert.NoError(t, m3.UnmarshalBinary(data))
+	assert.Equal(t, 3, m3.Cap())
+	assert.IsType(t, &LFUPolicy[string]{}, m3.Policy())
+
+	var m4 CacheMap[string, int]
+	data, err = m4.MarshalBinary()
+	assert.NoError(t, err)
+	var m5 CacheMap[string, int]
+	assert.NoError(t, m5.UnmarshalBinary(data))
+	assert.Equal(t, 0, m5.Cap())
+	assert.Equal(t, 0, m4.Clone(nil).Cap())
+
+	assert.Error(t, m5.UnmarshalBinary(gobEncode(t, -1, map[string]int(nil))))
+	assert.Error(t, m5.UnmarshalBinary(gobEncode(t, 1, map[string]int{"a": 1, "b": 2})))
+	assert.Error(t, m5.UnmarshalBinary(gobEncode(t, 1<<60, map[string]int{"a": 1})))
+}
+
+func TestCacheMap_Nil(t *testing.T) {
+	var m *CacheMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Cap())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Keys())
+	assert.Nil(t, m.Policy())
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "", m.String())
+	assert.NotPanics(t, func() {
+		m.Clear()
+	})
+}
+
+func TestCacheMap_OnEvict(t *testing.T) {
+	m := NewCacheMap[string, int](2, nil)
+	var evicted []string
+	m.OnEvict(func(k string, v int, reason EvictReason) {
+		evicted = append(evicted, fmt.Sprintf("%s%d %s", k, v, reason))
+	})
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("b", 3) // replacing a value is not an eviction
+	m.Set("c", 4)
+	m.Delete("b")
+	m.Delete("z")
+	m.Set("d", 5)
+	m.DeleteFunc(func(k string, _ int) bool {
+		return k == "d"
+	})
+	m.Clear()
+	assert.Equal(t, []string{"a1 capacity", "b3 delete", "d5 delete", "c4 delete"}, evicted)
+
+	evicted = nil
+	m.OnEvict(nil)
+	m.Set("a", 1)
+	m.Delete("a")
+	assert.Nil(t, evicted)
+}
+
+func TestCacheMap_Stats(t *testing.T) {
+	m := NewCacheMap[string, int](2, nil)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Get("a")
+	m.Get("a")
+	m.Get("z")
+	m.Load("b")
+	m.Has("z") // not counted
+	m.Set("c", 3)
+	m.Delete("c") // not an eviction
+	assert.Equal(t, CacheStats{Hits: 3, Misses: 1, Evictions: 1, Size: 1}, m.Stats())
+
+	m.ResetStats()
+	assert.Equal(t, CacheStats{Size: 1}, m.Stats())
+
+	var m2 *CacheMap[string, int]
+	assert.Equal(t, CacheStats{}, m2.Stats())
+}
@@ -0,0 +1,24 @@
+package maps
+
+import "iter"
+
+// CaseInsensitiveMap is a map with string keys that ignores the case of the keys, so "Content-Type" and
+// "content-type" refer to the same item. Use it for HTTP headers, user-entered identifiers and the like.
+//
+// The map remembers the key that was used when an item was first added, and uses that key when ranging
+// and marshaling. Setting an existing item with a key in a different case changes the value, but not the key.
+//
+// The zero value is ready to use.
+type CaseInsensitiveMap[V any] = NormalizedMap[V, CaseFold]
+
+// NewCaseInsensitiveMap creates a new CaseInsensitiveMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new CaseInsensitiveMap.
+func NewCaseInsensitiveMap[V any](sources ...map[string]V) *CaseInsensitiveMap[V] {
+	return NewNormalizedMap[V](CaseFold{}, sources...)
+}
+
+// CollectCaseInsensitiveMap collects key-value pairs from seq into a new CaseInsensitiveMap
+// and returns it.
+func CollectCaseInsensitiveMap[V any](seq iter.Seq2[string, V]) *CaseInsensitiveMap[V] {
+	return CollectNormalizedMap[V](CaseFold{}, seq)
+}
@@ -0,0 +1,79 @@
+package maps
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseInsensitiveMap_Mapi(t *testing.T) {
+	runMapiTests[CaseInsensitiveMap[int]](t, makeMapi[CaseInsensitiveMap[int]])
+}
+
+func init() {
+	gob.Register(new(CaseInsensitiveMap[int]))
+}
+
+func ExampleCaseInsensitiveMap() {
+	m := new(CaseInsensitiveMap[string])
+	m.Set("Content-Type", "text/html")
+	m.Set("content-type", "application/json")
+	fmt.Println(m.Get("CONTENT-TYPE"))
+	fmt.Println(m)
+	// Output: application/json
+	// {"Content-Type":"application/json"}
+}
+
+func TestCaseInsensitiveMap(t *testing.T) {
+	var m CaseInsensitiveMap[int]
+	m.Set("Abc", 1)
+	m.Set("ABC", 2)
+	m.Set("def", 3)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 2, m.Get("abc"))
+	assert.True(t, m.Has("DEF"))
+	k, ok := m.Key("aBC")
+	assert.Equal(t, "Abc", k)
+	assert.True(t, ok)
+	_, ok = m.Key("x")
+	assert.False(t, ok)
+	assert.ElementsMatch(t, []string{"Abc", "def"}, m.Keys())
+
+	assert.True(t, m.Equal(mapT{"abc": 2, "DEF": 3}))
+	assert.False(t, m.Equal(mapT{"abc": 2, "DEF": 4}))
+
+	s, err := json.Marshal(&m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Abc":2,"def":3}`, string(s))
+
+	m2 := m.Clone()
+	m2.Set("abc", 5)
+	assert.Equal(t, 2, m.Get("abc"))
+	k, _ = m2.Key("abc")
+	assert.Equal(t, "Abc", k)
+
+	m.DeleteFunc(func(k string, _ int) bool {
+		return k == "Abc"
+	})
+	assert.False(t, m.Has("abc"))
+	assert.Equal(t, 3, m.Delete("DEF"))
+	assert.Equal(t, 0, m.Len())
+	m.Set("aBc", 6)
+	k, _ = m.Key("ABC")
+	assert.Equal(t, "aBc", k, "a deleted key is not remembered")
+}
+
+func TestCaseInsensitiveMap_Nil(t *testing.T) {
+	var m *CaseInsensitiveMap[int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Keys())
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "", m.String())
+	assert.Equal(t, 0, m.Clone().Len())
+}
@@ -0,0 +1,65 @@
+package maps
+
+// Cloner is the interface that values implement to be copied by the CloneDeep methods of the Map types.
+// Clone should return a copy of the value that does not share anything that can change with the original.
+//
+// The Map types implement Cloner with their Clone method, so maps of maps are copied by CloneDeep too.
+// Map and Set values that are held in an interface, like MapI or SetI, are also cloned.
+type Cloner[V any] interface {
+	Clone() V
+}
+
+// deepCloner is implemented by the Map types, so that CloneDeep can copy maps of maps at every level.
+type deepCloner[V any] interface {
+	CloneDeep() V
+}
+
+// anyCloner is implemented by the Map and Set types, so that CloneDeep can copy a value whose type is an
+// interface, like MapI or SetI. The Clone methods return the concrete type, so they do not match Cloner in that case.
+type anyCloner interface {
+	cloneAny() any
+}
+
+// cloneValue returns a deep copy of v if it implements CloneDeep or Cloner, or is a Map or Set type held in an
+// interface. Otherwise, it returns v itself.
+func cloneValue[V any](v V) V {
+	switch c := any(v).(type) {
+	case deepCloner[V]:
+		return c.CloneDeep()
+	case Cloner[V]:
+		return c.Clone()
+	case anyCloner:
+		if v2, ok := c.cloneAny().(V); ok {
+			return v2
+		}
+	}
+	return v
+}
+
+func (m StdMap[K, V]) cloneAny() any           { return m.CloneDeep() }
+func (m *Map[K, V]) cloneAny() any             { return m.CloneDeep() }
+func (m *SafeMap[K, V]) cloneAny() any         { return m.CloneDeep() }
+func (m *SliceMap[K, V]) cloneAny() any        { return m.CloneDeep() }
+func (m *SafeSliceMap[K, V]) cloneAny() any    { return m.CloneDeep() }
+func (m *DefaultMap[K, V]) cloneAny() any      { return m.Clone() }
+func (m *SafeDefaultMap[K, V]) cloneAny() any  { return m.Clone() }
+func (m *IdentityMap[T, V]) cloneAny() any     { return m.Clone() }
+func (m *NestedMap[K]) cloneAny() any          { return m.Clone() }
+func (m *BoundedMap[K, V]) cloneAny() any      { return m.Clone() }
+func (m *COWMap[K, V]) cloneAny() any          { return m.Clone() }
+func (m *FlatMap[K, V]) cloneAny() any         { return m.Clone() }
+func (m *LFUMap[K, V]) cloneAny() any          { return m.Clone() }
+func (m *MultiMap[K, V]) cloneAny() any        { return m.Clone() }
+func (m *SliceMultiMap[K, V]) cloneAny() any   { return m.Clone() }
+func (m *NormalizedMap[V, N]) cloneAny() any   { return m.Clone() }
+func (m *PrefixMap[V]) cloneAny() any          { return m.Clone() }
+func (m *PriorityMap[K, V]) cloneAny() any     { return m.Clone() }
+func (m *ReadMostlyMap[K, V]) cloneAny() any   { return m.Clone() }
+func (m *RingMap[K, V]) cloneAny() any         { return m.Clone() }
+func (m *SkipListMap[K, V]) cloneAny() any     { return m.Clone() }
+func (m *StripedSliceMap[K, V]) cloneAny() any { return m.Clone() }
+func (m *SyncMap[K, V]) cloneAny() any         { return m.Clone() }
+func (m *Set[K]) cloneAny() any                { return m.Clone() }
+func (s *SafeSliceSet[K]) cloneAny() any       { return s.Clone() }
+func (m *BitSet) cloneAny() any                { return m.Clone() }
+func (m *SparseSet) cloneAny() any             { return m.Clone() }
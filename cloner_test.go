@@ -0,0 +1,72 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cloneable struct {
+	tags []string
+}
+
+func (c *cloneable) Clone() *cloneable {
+	return &cloneable{tags: append([]string(nil), c.tags...)}
+}
+
+func TestCloneDeep(t *testing.T) {
+	t.Run("Cloner", func(t *testing.T) {
+		m := NewSliceMap[string, *cloneable]()
+		m.Set("a", &cloneable{[]string{"x"}})
+		m2 := m.CloneDeep()
+		m2.Get("a").tags[0] = "y"
+		assert.Equal(t, "x", m.Get("a").tags[0])
+		assert.NotSame(t, m.Get("a"), m2.Get("a"))
+	})
+	t.Run("nested maps", func(t *testing.T) {
+		inner := NewSliceMap[string, *cloneable]()
+		inner.Set("b", &cloneable{[]string{"x"}})
+		m := NewMap(map[string]*SliceMap[string, *cloneable]{"a": inner})
+		m2 := m.CloneDeep()
+		m2.Get("a").Set("c", nil)
+		m2.Get("a").Get("b").tags[0] = "y"
+		assert.False(t, inner.Has("c"))
+		assert.Equal(t, "x", inner.Get("b").tags[0])
+	})
+	t.Run("StdMap values", func(t *testing.T) {
+		m := NewSafeMap(map[string]StdMap[string, int]{"a": {"b": 1}})
+		m2 := m.CloneDeep()
+		m2.Get("a")["b"] = 2
+		assert.Equal(t, 1, m.Get("a")["b"])
+		assert.Equal(t, 1, m2.Len())
+	})
+	t.Run("MapI values", func(t *testing.T) {
+		inner := NewMap(map[string]int{"x": 1})
+		m := NewSliceMap[string, MapI[string, int]]()
+		m.Set("a", inner)
+		m2 := m.CloneDeep()
+		m2.Get("a").Set("x", 2)
+		assert.Equal(t, 1, inner.Get("x"))
+		assert.Equal(t, 2, m2.Get("a").Get("x"))
+	})
+	t.Run("SetI values", func(t *testing.T) {
+		m := NewMap(map[string]SetI[int]{"a": NewSet(1), "b": NewBitSet(1)})
+		m2 := m.CloneDeep()
+		m2.Get("a").Add(2)
+		m2.Get("b").Add(2)
+		assert.False(t, m.Get("a").Has(2))
+		assert.False(t, m.Get("b").Has(2))
+		assert.True(t, m2.Get("a").Has(2))
+	})
+	t.Run("other values are assigned", func(t *testing.T) {
+		m := NewSafeSliceMap(map[string][]int{"a": {1}})
+		m2 := m.CloneDeep()
+		assert.True(t, EqualDeep[string, []int](m, m2))
+		m2.Get("a")[0] = 2
+		assert.Equal(t, 2, m.Get("a")[0], "slices are shared")
+	})
+	t.Run("nil", func(t *testing.T) {
+		assert.Nil(t, StdMap[string, int](nil).CloneDeep())
+		assert.Equal(t, 0, (*SliceMap[string, int])(nil).CloneDeep().Len())
+	})
+}
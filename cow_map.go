@@ -0,0 +1,335 @@
+package maps
+
+import "iter"
+
+// COWMap is a copy-on-write map. Calling Snapshot returns a read-only view of the current contents of the map
+// without copying it. The first change to the map after a snapshot copies the map, so the snapshot never changes.
+//
+// Use a snapshot to range over the map, or to hand its contents to other code, while the map continues to change.
+// If no snapshot has been taken since the last copy, changes are made in place like an ordinary map.
+//
+// COWMap is not safe for concurrent use, but its snapshots are, since they never change.
+// For a map that is safe for concurrent use and that copies itself on every write, see ReadMostlyMap.
+//
+// The zero value is ready to use. Do not make a copy of a COWMap using the equality operator (=). Use Clone instead.
+type COWMap[K comparable, V any] struct {
+	items StdMap[K, V]
+	// shared is true if items is being used by a snapshot, and so must be copied before it is changed.
+	shared bool
+}
+
+// NewCOWMap creates a new COWMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new COWMap.
+func NewCOWMap[K comparable, V any](sources ...map[K]V) *COWMap[K, V] {
+	m := new(COWMap[K, V])
+	if size := sourcesLen(sources); size > 0 {
+		m.items = make(StdMap[K, V], size)
+	}
+	for _, i := range sources {
+		m.items.Copy(Cast(i))
+	}
+	return m
+}
+
+// Snapshot returns a read-only view of the current contents of the map. The view does not change when
+// the map changes. Taking a snapshot is cheap, but the next change to the map will copy the entire map.
+func (m *COWMap[K, V]) Snapshot() MapReader[K, V] {
+	if m == nil || m.items == nil {
+		return cowSnapshot[K, V]{}
+	}
+	m.shared = true
+	return cowSnapshot[K, V]{m.items}
+}
+
+// std returns the current contents of the map, which must not be changed.
+func (m *COWMap[K, V]) std() StdMap[K, V] {
+	if m == nil {
+		return nil
+	}
+	return m.items
+}
+
+// writable prepares the map to be changed, copying it first if a snapshot is using it.
+func (m *COWMap[K, V]) writable() {
+	if m.shared {
+		m.items = m.items.Clone()
+		m.shared = false
+	}
+	if m.items == nil {
+		m.items = make(StdMap[K, V])
+	}
+}
+
+// Set sets the key to the given value.
+func (m *COWMap[K, V]) Set(k K, v V) {
+	if m == nil {
+		panic("cannot set a value on a nil COWMap")
+	}
+	m.writable()
+	m.items[k] = v
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+func (m *COWMap[K, V]) Load(k K) (v V, ok bool) {
+	return m.std().Load(k)
+}
+
+// Get returns the value based on its key. If the key does not exist, the zero value will be returned.
+func (m *COWMap[K, V]) Get(k K) (v V) {
+	return m.std().Get(k)
+}
+
+// Has returns true if the given key exists in the map.
+func (m *COWMap[K, V]) Has(k K) bool {
+	return m.std().Has(k)
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *COWMap[K, V]) Delete(k K) (v V) {
+	v, _ = m.LoadAndDelete(k)
+	return
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+func (m *COWMap[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	if m == nil {
+		panic("cannot set a value on a nil COWMap")
+	}
+	m.writable()
+	previous, loaded = m.items[k]
+	m.items[k] = v
+	return
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m *COWMap[K, V]) LoadAndDelete(k K) (v V, loaded bool) {
+	if v, loaded = m.Load(k); !loaded {
+		return // prevent an unnecessary copy
+	}
+	m.writable()
+	delete(m.items, k)
+	return
+}
+
+// Clear removes all the items in the map. Snapshots are not affected.
+func (m *COWMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.items = nil
+	m.shared = false
+}
+
+// Len returns the number of items in the map.
+func (m *COWMap[K, V]) Len() int {
+	return len(m.std())
+}
+
+// Range will call the given function with every key and value in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+//
+// Like an ordinary go map, f may delete items from the map, but to change the map in other ways
+// while ranging, range over a Snapshot instead.
+func (m *COWMap[K, V]) Range(f func(k K, v V) bool) {
+	m.std().Range(f)
+}
+
+// Keys returns a slice of the keys. It will return a nil slice if the map is empty.
+func (m *COWMap[K, V]) Keys() []K {
+	return m.std().Keys()
+}
+
+// Values returns a slice of the values. It will return a nil slice if the map is empty.
+func (m *COWMap[K, V]) Values() []V {
+	return m.std().Values()
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Use Copy instead.
+func (m *COWMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the keys and values of in into this map, overwriting any duplicates.
+func (m *COWMap[K, V]) Copy(in MapI[K, V]) {
+	if in == nil || in.Len() == 0 {
+		return
+	}
+	if m == nil {
+		panic("cannot copy into a nil COWMap")
+	}
+	m.writable()
+	m.items.Copy(in)
+}
+
+// Equal returns true if all the keys and values are equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *COWMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m == nil {
+		return m2 == nil || m2.Len() == 0
+	}
+	return m.items.Equal(m2)
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *COWMap[K, V]) MarshalBinary() ([]byte, error) {
+	return m.std().MarshalBinary()
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// COWMap.
+func (m *COWMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var items StdMap[K, V]
+	if err = items.UnmarshalBinary(data); err == nil {
+		m.items = items
+		m.shared = false
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *COWMap[K, V]) MarshalJSON() (out []byte, err error) {
+	return m.std().MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a COWMap.
+// The JSON must start with an object.
+func (m *COWMap[K, V]) UnmarshalJSON(in []byte) (err error) {
+	var items StdMap[K, V]
+	if err = items.UnmarshalJSON(in); err == nil {
+		m.items = items
+		m.shared = false
+	}
+	return
+}
+
+// String outputs the map as a string.
+func (m *COWMap[K, V]) String() string {
+	return m.std().String()
+}
+
+// All returns an iterator over all the items in the map.
+func (m *COWMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+func (m *COWMap[K, V]) KeysIter() iter.Seq[K] {
+	return m.std().KeysIter()
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+func (m *COWMap[K, V]) ValuesIter() iter.Seq[V] {
+	return m.std().ValuesIter()
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *COWMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// CollectCOWMap collects key-value pairs from seq into a new COWMap
+// and returns it.
+func CollectCOWMap[K comparable, V any](seq iter.Seq2[K, V]) *COWMap[K, V] {
+	m := new(COWMap[K, V])
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the COWMap. The copy shares its contents with the original until one of them changes.
+func (m *COWMap[K, V]) Clone() *COWMap[K, V] {
+	m2 := new(COWMap[K, V])
+	if m == nil || m.items == nil {
+		return m2
+	}
+	m.shared = true
+	m2.items = m.items
+	m2.shared = true
+	return m2
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *COWMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil || m.items == nil {
+		return
+	}
+	if m.shared {
+		// copy only the items that are kept, rather than copying the map and then deleting from it
+		items := make(StdMap[K, V], len(m.items))
+		for k, v := range m.items {
+			if !del(k, v) {
+				items[k] = v
+			}
+		}
+		m.items = items
+		m.shared = false
+		return
+	}
+	m.items.DeleteFunc(del)
+}
+
+// cowSnapshot is the read-only view of a COWMap returned by Snapshot.
+type cowSnapshot[K comparable, V any] struct {
+	items StdMap[K, V]
+}
+
+func (s cowSnapshot[K, V]) Get(k K) V {
+	return s.items.Get(k)
+}
+
+func (s cowSnapshot[K, V]) Load(k K) (V, bool) {
+	return s.items.Load(k)
+}
+
+func (s cowSnapshot[K, V]) Has(k K) bool {
+	return s.items.Has(k)
+}
+
+func (s cowSnapshot[K, V]) Len() int {
+	return s.items.Len()
+}
+
+func (s cowSnapshot[K, V]) Range(f func(k K, v V) bool) {
+	s.items.Range(f)
+}
+
+func (s cowSnapshot[K, V]) Keys() []K {
+	return s.items.Keys()
+}
+
+func (s cowSnapshot[K, V]) Values() []V {
+	return s.items.Values()
+}
+
+func (s cowSnapshot[K, V]) Equal(m2 MapI[K, V]) bool {
+	return s.items.Equal(m2)
+}
+
+func (s cowSnapshot[K, V]) All() iter.Seq2[K, V] {
+	return s.items.All()
+}
+
+func (s cowSnapshot[K, V]) KeysIter() iter.Seq[K] {
+	return s.items.KeysIter()
+}
+
+func (s cowSnapshot[K, V]) ValuesIter() iter.Seq[V] {
+	return s.items.ValuesIter()
+}
+
+func (s cowSnapshot[K, V]) String() string {
+	return s.items.String()
+}
+
+func (s cowSnapshot[K, V]) MarshalJSON() (out []byte, err error) {
+	return s.items.MarshalJSON()
+}
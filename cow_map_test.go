@@ -0,0 +1,94 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCOWMap_Mapi(t *testing.T) {
+	runMapiTests[COWMap[string, int]](t, makeMapi[COWMap[string, int]])
+}
+
+func init() {
+	gob.Register(new(COWMap[string, int]))
+}
+
+func ExampleCOWMap_Snapshot() {
+	m := NewCOWMap(map[string]int{"a": 1, "b": 2})
+	// change the map while ranging over a snapshot of it
+	for k, v := range m.Snapshot().All() {
+		m.Set(k+k, v*10)
+	}
+	fmt.Println(m.Len(), m.Get("aa"), m.Get("bb"))
+	// Output: 4 10 20
+}
+
+func TestCOWMap_Snapshot(t *testing.T) {
+	m := NewCOWMap(map[string]int{"a": 1, "b": 2})
+	s1 := m.Snapshot()
+	s2 := m.Snapshot()
+
+	m.Set("c", 3)
+	m.Delete("a")
+	assert.Equal(t, `{"b":2, "c":3}`, m.String())
+	assert.Equal(t, `{"a":1, "b":2}`, s1.String())
+	assert.True(t, s1.Equal(StdMap[string, int]{"a": 1, "b": 2}))
+	assert.Equal(t, s1.String(), s2.String())
+
+	// no copy is made when there is no snapshot
+	m.Set("d", 4)
+	s3 := m.Snapshot()
+	assert.Equal(t, 3, s3.Len())
+	m.DeleteFunc(func(k string, v int) bool {
+		return v > 2
+	})
+	assert.Equal(t, []string{"b"}, m.Keys())
+	assert.Equal(t, 3, s3.Len())
+	assert.True(t, s3.Has("d"))
+	m.Clear()
+	assert.Equal(t, 3, s3.Len())
+	assert.Equal(t, 0, m.Snapshot().Len())
+
+	// snapshot reads
+	assert.Equal(t, 1, s1.Get("a"))
+	v, ok := s1.Load("b")
+	assert.Equal(t, 2, v)
+	assert.True(t, ok)
+	assert.ElementsMatch(t, []string{"a", "b"}, s1.Keys())
+	assert.ElementsMatch(t, []int{1, 2}, s1.Values())
+	n := 0
+	s1.Range(func(k string, v int) bool {
+		n++
+		return true
+	})
+	for range s1.KeysIter() {
+		n++
+	}
+	for range s1.ValuesIter() {
+		n++
+	}
+	assert.Equal(t, 6, n)
+}
+
+func TestCOWMap_Clone(t *testing.T) {
+	m := NewCOWMap(map[string]int{"a": 1})
+	m2 := m.Clone()
+	m2.Set("b", 2)
+	m.Set("c", 3)
+	assert.Equal(t, `{"a":1, "c":3}`, m.String())
+	assert.Equal(t, `{"a":1, "b":2}`, m2.String())
+
+	m3 := CollectCOWMap(m2.All())
+	assert.True(t, m3.Equal(m2))
+
+	var m4 *COWMap[string, int]
+	assert.Equal(t, 0, m4.Clone().Len())
+	assert.Equal(t, 0, m4.Snapshot().Len())
+	assert.Equal(t, 0, m4.Len())
+	assert.Panics(t, func() {
+		m4.Set("a", 1)
+	})
+}
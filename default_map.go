@@ -0,0 +1,101 @@
+package maps
+
+// DefaultMap is a Map that creates a value for a missing key when the key is read with Get,
+// like Python's defaultdict. The value is created by a factory function, stored in the map,
+// and returned.
+//
+// Only Get creates values. Load and Has report whether the key exists without changing the map.
+//
+// The zero value has no factory and works like a Map. Use NewDefaultMap to create a DefaultMap with a factory.
+type DefaultMap[K comparable, V any] struct {
+	Map[K, V]
+	factory func(k K) V
+}
+
+// NewDefaultMap creates a new DefaultMap that calls factory to create the value of a missing key.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new DefaultMap.
+func NewDefaultMap[K comparable, V any](factory func(k K) V, sources ...map[K]V) *DefaultMap[K, V] {
+	m := &DefaultMap[K, V]{factory: factory}
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// Get returns the value for the given key. If the key does not exist, the factory is called to create
+// a value, which is stored in the map and returned. If there is no factory, the zero value is returned
+// and the map is not changed.
+func (m *DefaultMap[K, V]) Get(k K) (v V) {
+	var ok bool
+	if v, ok = m.Load(k); ok || m.factory == nil {
+		return
+	}
+	v = m.factory(k)
+	m.Set(k, v)
+	return
+}
+
+// Clone returns a copy of the DefaultMap with the same factory, key function and value comparison function. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *DefaultMap[K, V]) Clone() *DefaultMap[K, V] {
+	m1 := &DefaultMap[K, V]{factory: m.factory}
+	m1.items = m.items.Clone()
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	return m1
+}
+
+// SafeDefaultMap is a SafeMap that creates a value for a missing key when the key is read with Get.
+// The value is created by a factory function, stored in the map, and returned. Checking for the key,
+// creating the value and storing it happen atomically, so the factory is called at most once per missing key
+// even when several go routines call Get at the same time.
+//
+// Only Get creates values. Load and Has report whether the key exists without changing the map.
+//
+// The zero value has no factory and works like a SafeMap. Use NewSafeDefaultMap to create a SafeDefaultMap with a factory.
+// Do not make a copy of a SafeDefaultMap using the equality operator (=). Use Clone instead.
+type SafeDefaultMap[K comparable, V any] struct {
+	SafeMap[K, V]
+	factory func(k K) V
+}
+
+// NewSafeDefaultMap creates a new SafeDefaultMap that calls factory to create the value of a missing key.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new SafeDefaultMap.
+func NewSafeDefaultMap[K comparable, V any](factory func(k K) V, sources ...map[K]V) *SafeDefaultMap[K, V] {
+	m := &SafeDefaultMap[K, V]{factory: factory}
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// Get returns the value for the given key. If the key does not exist, the factory is called to create
+// a value, which is stored in the map and returned. If there is no factory, the zero value is returned
+// and the map is not changed.
+//
+// The map is locked while the factory is called, so the factory must not call methods of the map.
+func (m *SafeDefaultMap[K, V]) Get(k K) (v V) {
+	var ok bool
+	if v, ok = m.Load(k); ok || m.factory == nil {
+		return
+	}
+	v, _ = m.Update(k, func(old V, exists bool) (V, bool) {
+		if exists {
+			return old, true // another go routine added it first
+		}
+		return m.factory(k), true
+	})
+	return
+}
+
+// Clone returns a copy of the SafeDefaultMap with the same factory, key function and value comparison function. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *SafeDefaultMap[K, V]) Clone() *SafeDefaultMap[K, V] {
+	m1 := &SafeDefaultMap[K, V]{factory: m.factory}
+	m.mu.RLock()
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	m.mu.RUnlock()
+	m1.Copy(&m.SafeMap)
+	return m1
+}
@@ -0,0 +1,114 @@
+package maps
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMap_Mapi(t *testing.T) {
+	runMapiTests[DefaultMap[string, int]](t,
+		func(sources ...mapT) MapI[string, int] {
+			m := new(DefaultMap[string, int])
+			for _, s := range sources {
+				m.Merge(s)
+			}
+			return m
+		},
+	)
+}
+
+func TestSafeDefaultMap_Mapi(t *testing.T) {
+	runMapiTests[SafeDefaultMap[string, int]](t,
+		func(sources ...mapT) MapI[string, int] {
+			m := new(SafeDefaultMap[string, int])
+			for _, s := range sources {
+				m.Merge(s)
+			}
+			return m
+		},
+	)
+}
+
+func init() {
+	gob.Register(new(DefaultMap[string, int]))
+	gob.Register(new(SafeDefaultMap[string, int]))
+}
+
+func ExampleDefaultMap() {
+	m := NewDefaultMap(func(k string) []string {
+		return []string{}
+	})
+	m.Set("fruit", append(m.Get("fruit"), "apple"))
+	m.Set("fruit", append(m.Get("fruit"), "pear"))
+	fmt.Println(m.Get("fruit"), len(m.Get("meat")), m.Len())
+	// Output: [apple pear] 0 2
+}
+
+func TestDefaultMap(t *testing.T) {
+	var calls int
+	m := NewDefaultMap(func(k string) int {
+		calls++
+		return len(k)
+	}, map[string]int{"a": 10})
+
+	assert.Equal(t, 10, m.Get("a"))
+	assert.Equal(t, 0, calls)
+	assert.False(t, m.Has("abc"))
+	_, ok := m.Load("abc")
+	assert.False(t, ok)
+	assert.Equal(t, 3, m.Get("abc"))
+	assert.Equal(t, 3, m.Get("abc"))
+	assert.Equal(t, 1, calls)
+	assert.True(t, m.Has("abc"))
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	assert.Equal(t, 2, m2.Get("bb"))
+	assert.False(t, m.Has("bb"))
+
+	var m3 DefaultMap[string, int]
+	assert.Equal(t, 0, m3.Get("a"))
+	assert.False(t, m3.Has("a"))
+
+	s, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":10,"abc":3}`, string(s))
+}
+
+func TestSafeDefaultMap(t *testing.T) {
+	var calls atomic.Int32
+	m := NewSafeDefaultMap(func(k string) int {
+		calls.Add(1)
+		return len(k)
+	}, map[string]int{"a": 10})
+
+	assert.Equal(t, 10, m.Get("a"))
+	assert.False(t, m.Has("abc"))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 3, m.Get("abc"))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 2, m.Len())
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	assert.Equal(t, 2, m2.Get("bb"))
+	assert.False(t, m.Has("bb"))
+
+	var m3 SafeDefaultMap[string, int]
+	assert.Equal(t, 0, m3.Get("a"))
+	assert.False(t, m3.Has("a"))
+}
@@ -1,5 +1,7 @@
 package maps
 
+import "reflect"
+
 // Equaler is the interface that implements an Equal function and that provides a way for the
 // various MapI like objects to determine if they are equal.
 //
@@ -26,6 +28,14 @@ type Equaler interface {
 	Equal(a any) bool
 }
 
+// equalValuesFunc returns true if a and b are equal, using eq if it is not nil, or equalValues if it is.
+func equalValuesFunc[V any](eq func(a, b V) bool, a, b V) bool {
+	if eq != nil {
+		return eq(a, b)
+	}
+	return equalValues(a, b)
+}
+
 func equalValues(a, b any) bool {
 	if e, ok := a.(Equaler); ok {
 		return e.Equal(b)
@@ -33,3 +43,18 @@ func equalValues(a, b any) bool {
 
 	return a == b
 }
+
+// EqualDeep returns true if m1 and m2 have the same keys, and the values of those keys are equal.
+//
+// Values that implement Equaler are compared with their Equal function, like Equal does.
+// Other values are compared with reflect.DeepEqual, so unlike Equal, EqualDeep does not panic when
+// the values are not comparable, like slices or maps from types you do not own.
+// If one of the maps is a "safe" map, its more efficient to pass that map as m2.
+func EqualDeep[K comparable, V any](m1, m2 MapReader[K, V]) bool {
+	return EqualFunc(m1, m2, func(a, b V) bool {
+		if e, ok := any(a).(Equaler); ok {
+			return e.Equal(b)
+		}
+		return reflect.DeepEqual(a, b)
+	})
+}
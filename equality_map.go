@@ -0,0 +1,388 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// errNoDerive is returned when unmarshaling into an EqualityMap that was not created with NewEqualityMap.
+var errNoDerive = errors.New("maps: cannot unmarshal into an EqualityMap with no derive function")
+
+// EqualityMap is a map whose keys are compared with functions given to NewEqualityMap,
+// rather than with ==, so keys do not need to be comparable.
+//
+// The derive function turns a key into a comparable form, like a hash or a normalized string,
+// that is used to find the key quickly. Keys with the same derived form are then compared with the
+// equality function, if there is one. Keys that are equal must have the same derived form.
+// For example, paths that are equal after resolving symlinks could use the cleaned path as the derived form,
+// and compare the resolved paths in the equality function.
+//
+// Since its keys may not be comparable, an EqualityMap does not implement MapI, but it has the same methods
+// wherever the method does not need comparable keys.
+// The order of the items when ranging is not determinate.
+//
+// The zero value is NOT settable. Use NewEqualityMap to create an EqualityMap.
+type EqualityMap[K any, D comparable, V any] struct {
+	items  map[D][]equalityItem[K, V]
+	count  int
+	derive func(K) D
+	eq     func(K, K) bool
+}
+
+// equalityItem is an item in an EqualityMap.
+type equalityItem[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// NewEqualityMap creates a new EqualityMap that uses derive and eq to find its keys.
+// If eq is nil, keys are equal when their derived forms are equal.
+func NewEqualityMap[K any, D comparable, V any](derive func(K) D, eq func(K, K) bool) *EqualityMap[K, D, V] {
+	if derive == nil {
+		panic("an EqualityMap needs a derive function")
+	}
+	return &EqualityMap[K, D, V]{derive: derive, eq: eq}
+}
+
+// find returns the derived form of k, and the position of k in its bucket, or -1 if k is not in the map.
+func (m *EqualityMap[K, D, V]) find(k K) (d D, i int) {
+	d = m.derive(k)
+	bucket := m.items[d]
+	if m.eq == nil {
+		if len(bucket) == 0 {
+			return d, -1
+		}
+		return d, 0
+	}
+	for i, item := range bucket {
+		if m.eq(item.Key, k) {
+			return d, i
+		}
+	}
+	return d, -1
+}
+
+// Set sets the key to the given value.
+func (m *EqualityMap[K, D, V]) Set(k K, v V) {
+	if m.derive == nil {
+		panic("cannot call Set() on an EqualityMap with no derive function")
+	}
+	d, i := m.find(k)
+	if i >= 0 {
+		m.items[d][i].Value = v
+		return
+	}
+	if m.items == nil {
+		m.items = make(map[D][]equalityItem[K, V])
+	}
+	m.items[d] = append(m.items[d], equalityItem[K, V]{k, v})
+	m.count++
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *EqualityMap[K, D, V]) Load(k K) (v V, ok bool) {
+	if m.Len() == 0 {
+		return
+	}
+	if d, i := m.find(k); i >= 0 {
+		return m.items[d][i].Value, true
+	}
+	return
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *EqualityMap[K, D, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the key exists.
+func (m *EqualityMap[K, D, V]) Has(k K) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *EqualityMap[K, D, V]) Delete(k K) (v V) {
+	if m.Len() == 0 {
+		return
+	}
+	d, i := m.find(k)
+	if i < 0 {
+		return
+	}
+	v = m.items[d][i].Value
+	m.deleteAt(d, i)
+	return
+}
+
+// deleteAt removes the item at position i of bucket d.
+func (m *EqualityMap[K, D, V]) deleteAt(d D, i int) {
+	bucket := m.items[d]
+	if len(bucket) == 1 {
+		delete(m.items, d)
+	} else {
+		last := len(bucket) - 1
+		bucket[i] = bucket[last]
+		bucket[last] = equalityItem[K, V]{}
+		m.items[d] = bucket[:last]
+	}
+	m.count--
+}
+
+// Clear removes all the items in the map.
+func (m *EqualityMap[K, D, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.items = nil
+	m.count = 0
+}
+
+// Len returns the number of items in the map.
+func (m *EqualityMap[K, D, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+// Range calls the given function for each key,value pair in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+func (m *EqualityMap[K, D, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	for _, bucket := range m.items {
+		for _, item := range bucket {
+			if !f(item.Key, item.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map.
+func (m *EqualityMap[K, D, V]) Keys() (keys []K) {
+	if m.Len() == 0 {
+		return
+	}
+	keys = make([]K, 0, m.count)
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return
+}
+
+// Values returns a new slice containing the values of the map.
+func (m *EqualityMap[K, D, V]) Values() (values []V) {
+	if m.Len() == 0 {
+		return
+	}
+	values = make([]V, 0, m.count)
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+func (m *EqualityMap[K, D, V]) Copy(in *EqualityMap[K, D, V]) {
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal. The keys are compared with the equality function of m.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *EqualityMap[K, D, V]) Equal(m2 *EqualityMap[K, D, V]) bool {
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k K, v V) bool {
+		if v2, ok := m.Load(k); !ok || !equalValues(v, v2) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// String outputs the map as a string.
+func (m *EqualityMap[K, D, V]) String() string {
+	var s string
+
+	if m == nil {
+		return s
+	}
+
+	s = "{"
+	m.Range(func(k K, v V) bool {
+		s += fmt.Sprintf(`%#v:%#v,`, k, v)
+		return true
+	})
+	s = strings.TrimRight(s, ",")
+	s += "}"
+	return s
+}
+
+// pairs returns the items of the map.
+func (m *EqualityMap[K, D, V]) pairs() (items []equalityItem[K, V]) {
+	if m.Len() == 0 {
+		return
+	}
+	items = make([]equalityItem[K, V], 0, m.count)
+	for _, bucket := range m.items {
+		items = append(items, bucket...)
+	}
+	return
+}
+
+// setPairs replaces the items of the map with items.
+func (m *EqualityMap[K, D, V]) setPairs(items []equalityItem[K, V]) {
+	m.Clear()
+	for _, item := range items {
+		m.Set(item.Key, item.Value)
+	}
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+// The derive and equality functions are not serialized.
+func (m *EqualityMap[K, D, V]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(m.pairs())
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// EqualityMap. The map must have been created with NewEqualityMap, so that it has a derive function.
+// Otherwise, an error is returned.
+func (m *EqualityMap[K, D, V]) UnmarshalBinary(data []byte) (err error) {
+	var items []equalityItem[K, V]
+
+	if m.derive == nil {
+		return errNoDerive
+	}
+
+	b := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(b)
+	if err = dec.Decode(&items); err == nil {
+		m.setPairs(items)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into JSON.
+// Since the keys may not be strings, the map is written as an array of objects,
+// each with a "Key" and a "Value".
+func (m *EqualityMap[K, D, V]) MarshalJSON() (out []byte, err error) {
+	items := m.pairs()
+	if items == nil {
+		items = []equalityItem[K, V]{}
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert JSON written by MarshalJSON to a EqualityMap.
+// The map must have been created with NewEqualityMap, so that it has a derive function.
+// Otherwise, an error is returned.
+func (m *EqualityMap[K, D, V]) UnmarshalJSON(in []byte) (err error) {
+	var items []equalityItem[K, V]
+	if m.derive == nil {
+		return errNoDerive
+	}
+	if err = json.Unmarshal(in, &items); err == nil {
+		m.setPairs(items)
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map.
+func (m *EqualityMap[K, D, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+func (m *EqualityMap[K, D, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+func (m *EqualityMap[K, D, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *EqualityMap[K, D, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// CollectEqualityMap collects key-value pairs from seq into a new EqualityMap that uses derive and eq,
+// and returns it.
+func CollectEqualityMap[K any, D comparable, V any](derive func(K) D, eq func(K, K) bool, seq iter.Seq2[K, V]) *EqualityMap[K, D, V] {
+	m := NewEqualityMap[K, D, V](derive, eq)
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the EqualityMap, with the same derive and equality functions. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *EqualityMap[K, D, V]) Clone() *EqualityMap[K, D, V] {
+	m1 := new(EqualityMap[K, D, V])
+	if m == nil {
+		return m1
+	}
+	m1.derive = m.derive
+	m1.eq = m.eq
+	m1.count = m.count
+	if m.items != nil {
+		m1.items = make(map[D][]equalityItem[K, V], len(m.items))
+		for d, bucket := range m.items {
+			m1.items[d] = append([]equalityItem[K, V](nil), bucket...)
+		}
+	}
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *EqualityMap[K, D, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil {
+		return
+	}
+	for d, bucket := range m.items {
+		for i := len(bucket) - 1; i >= 0; i-- {
+			if del(bucket[i].Key, bucket[i].Value) {
+				m.deleteAt(d, i)
+				bucket = m.items[d]
+			}
+		}
+	}
+}
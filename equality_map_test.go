@@ -0,0 +1,55 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// versionless removes a version suffix, like "@2", from an ID.
+func versionless(id string) string {
+	base, _, _ := strings.Cut(id, "@")
+	return base
+}
+
+func ExampleEqualityMap() {
+	m := NewEqualityMap[string, string, int](versionless, nil)
+	m.Set("widget@1", 1)
+	m.Set("widget@2", 2)
+	fmt.Println(m.Len(), m.Get("widget"), m.Keys())
+	// Output: 1 2 [widget@1]
+}
+
+type labeledPoint struct {
+	x, y  int
+	label []string
+}
+
+func TestEqualityMap(t *testing.T) {
+	// Points are equal if they have the same coordinates, whatever their labels.
+	// The derived form only uses x, so the equality function must also compare y.
+	m := NewEqualityMap[labeledPoint, int, string](
+		func(p labeledPoint) int { return p.x },
+		func(a, b labeledPoint) bool { return a.x == b.x && a.y == b.y },
+	)
+	m.Set(labeledPoint{x: 1, y: 1, label: []string{"a"}}, "a")
+	m.Set(labeledPoint{x: 1, y: 1}, "b")
+	m.Set(labeledPoint{x: 1, y: 2}, "c")
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, "b", m.Get(labeledPoint{x: 1, y: 1, label: []string{"z"}}))
+	assert.False(t, m.Has(labeledPoint{x: 2, y: 1}))
+	assert.Equal(t, []string{"a"}, m.Keys()[0].label)
+	assert.Equal(t, "c", m.Delete(labeledPoint{x: 1, y: 2}))
+
+	m2 := CollectEqualityMap(m.derive, m.eq, m.All())
+	assert.True(t, m.Equal(m2))
+	assert.Equal(t, 1, m2.Len())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.Panics(t, func() {
+		NewEqualityMap[string, string, int](nil, nil)
+	})
+}
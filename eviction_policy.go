@@ -0,0 +1,151 @@
+package maps
+
+import (
+	"container/heap"
+	"container/list"
+)
+
+// EvictionPolicy decides which key a CacheMap removes when it is full and a new key is added.
+//
+// The CacheMap tells the policy about every key that is added, used or removed, and calls Victim
+// to choose the key to evict. A policy is owned by a single CacheMap and does not need to be safe for
+// concurrent use. Implement this interface to plug in other policies, like ARC or 2Q.
+type EvictionPolicy[K comparable] interface {
+	// Added is called when a new key is added to the map.
+	Added(k K)
+	// Used is called when an existing key is read by Get or Load, or its value is changed by Set.
+	Used(k K)
+	// Removed is called when a key is removed from the map by something other than an eviction,
+	// like Delete or DeleteFunc.
+	Removed(k K)
+	// Victim removes a key from the policy and returns it. The CacheMap will then remove the key from the map.
+	// Victim is only called when the map is not empty.
+	Victim() K
+	// Clear forgets all the keys.
+	Clear()
+}
+
+// EvictReason tells an OnEvict callback why an item was removed from a bounded map.
+type EvictReason int
+
+const (
+	// EvictedByCapacity means the item was removed to make room for a new key.
+	EvictedByCapacity EvictReason = iota + 1
+	// EvictedByDelete means the item was removed by Delete, DeleteFunc or Clear.
+	EvictedByDelete
+)
+
+// String returns a description of the reason.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictedByCapacity:
+		return "capacity"
+	case EvictedByDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// LRUPolicy is an EvictionPolicy that evicts the least recently used key.
+type LRUPolicy[K comparable] struct {
+	list  list.List // the most recently used key is at the front
+	items map[K]*list.Element
+}
+
+// NewLRUPolicy returns a new LRUPolicy.
+func NewLRUPolicy[K comparable]() *LRUPolicy[K] {
+	return &LRUPolicy[K]{items: make(map[K]*list.Element)}
+}
+
+// Added records a new key as the most recently used.
+func (p *LRUPolicy[K]) Added(k K) {
+	if p.items == nil {
+		p.items = make(map[K]*list.Element)
+	}
+	p.items[k] = p.list.PushFront(k)
+}
+
+// Used records the key as the most recently used.
+func (p *LRUPolicy[K]) Used(k K) {
+	if e, ok := p.items[k]; ok {
+		p.list.MoveToFront(e)
+	}
+}
+
+// Removed forgets the key.
+func (p *LRUPolicy[K]) Removed(k K) {
+	if e, ok := p.items[k]; ok {
+		p.list.Remove(e)
+		delete(p.items, k)
+	}
+}
+
+// Victim removes and returns the least recently used key.
+func (p *LRUPolicy[K]) Victim() K {
+	k := p.list.Remove(p.list.Back()).(K)
+	delete(p.items, k)
+	return k
+}
+
+// Clear forgets all the keys.
+func (p *LRUPolicy[K]) Clear() {
+	p.list.Init()
+	p.items = nil
+}
+
+// LFUPolicy is an EvictionPolicy that evicts the least frequently used key.
+// If more than one key has the lowest use count, the one that was used least recently is evicted.
+type LFUPolicy[K comparable] struct {
+	heap  lfuHeap[K, struct{}]
+	items map[K]*lfuEntry[K, struct{}]
+	tick  uint64
+}
+
+// NewLFUPolicy returns a new LFUPolicy.
+func NewLFUPolicy[K comparable]() *LFUPolicy[K] {
+	return &LFUPolicy[K]{items: make(map[K]*lfuEntry[K, struct{}])}
+}
+
+// Added records the first use of a new key.
+func (p *LFUPolicy[K]) Added(k K) {
+	if p.items == nil {
+		p.items = make(map[K]*lfuEntry[K, struct{}])
+	}
+	e := &lfuEntry[K, struct{}]{key: k}
+	p.items[k] = e
+	heap.Push(&p.heap, e)
+	p.Used(k)
+}
+
+// Used records a use of the key.
+func (p *LFUPolicy[K]) Used(k K) {
+	if e, ok := p.items[k]; ok {
+		p.tick++
+		e.freq++
+		e.tick = p.tick
+		heap.Fix(&p.heap, e.index)
+	}
+}
+
+// Removed forgets the key.
+func (p *LFUPolicy[K]) Removed(k K) {
+	if e, ok := p.items[k]; ok {
+		heap.Remove(&p.heap, e.index)
+		delete(p.items, k)
+	}
+}
+
+// Victim removes and returns the least frequently used key.
+func (p *LFUPolicy[K]) Victim() K {
+	e := heap.Pop(&p.heap).(*lfuEntry[K, struct{}])
+	delete(p.items, e.key)
+	return e.key
+}
+
+// Clear forgets all the keys.
+func (p *LFUPolicy[K]) Clear() {
+	p.heap = nil
+	p.items = nil
+	p.tick = 0
+}
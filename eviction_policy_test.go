@@ -0,0 +1,41 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUPolicy(t *testing.T) {
+	var p LRUPolicy[string] // zero value is usable
+	p.Added("a")
+	p.Added("b")
+	p.Added("c")
+	p.Used("a")
+	p.Removed("b")
+	p.Removed("z")
+	assert.Equal(t, "c", p.Victim())
+	assert.Equal(t, "a", p.Victim())
+	p.Added("d")
+	p.Clear()
+	p.Added("e")
+	assert.Equal(t, "e", p.Victim())
+}
+
+func TestLFUPolicy(t *testing.T) {
+	var p LFUPolicy[string] // zero value is usable
+	p.Added("a")
+	p.Added("b")
+	p.Added("c")
+	p.Used("a")
+	p.Used("b")
+	p.Used("b")
+	p.Removed("c")
+	p.Removed("z")
+	assert.Equal(t, "a", p.Victim())
+	assert.Equal(t, "b", p.Victim())
+	p.Added("d")
+	p.Clear()
+	p.Added("e")
+	assert.Equal(t, "e", p.Victim())
+}
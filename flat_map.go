@@ -0,0 +1,454 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"strings"
+)
+
+// FlatMap is a hash map that uses open addressing, like the Swiss tables of Abseil.
+// The keys and values are stored in one flat slice, without buckets or pointers, so it uses less memory
+// than a go map and is faster to scan when the keys and values are small and the map is dense.
+//
+// The slots are arranged in groups of 8, with a control byte for each slot holding 7 bits of the hash of its key.
+// A lookup compares the 8 control bytes of a group at once with bit operations on a uint64,
+// and only compares keys whose control byte matches.
+//
+// The order of the items when ranging is not determinate.
+// The zero value is ready to use. Do not make a copy of a FlatMap using the equality operator (=). Use Clone instead.
+type FlatMap[K comparable, V any] struct {
+	ctrl  []uint8
+	slots []flatSlot[K, V]
+	count int // the number of items
+	used  int // the number of slots that are full or deleted
+	seed  maphash.Seed
+}
+
+type flatSlot[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+const (
+	flatGroupSize       = 8
+	flatEmpty     uint8 = 0x80
+	flatDeleted   uint8 = 0xFE
+	flatLSBs            = 0x0101010101010101
+	flatMSBs            = 0x8080808080808080
+)
+
+// NewFlatMap creates a new FlatMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new FlatMap.
+// The new map is pre-sized to hold all the items in sources.
+func NewFlatMap[K comparable, V any](sources ...map[K]V) *FlatMap[K, V] {
+	m := new(FlatMap[K, V])
+	if size := sourcesLen(sources); size > 0 {
+		m.resize(flatGroupsFor(size))
+	}
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// flatGroupsFor returns the number of groups needed to hold n items without growing.
+// The number of groups is always a power of 2.
+func flatGroupsFor(n int) int {
+	groups := 1
+	for flatMaxUsed(groups*flatGroupSize) < n {
+		groups *= 2
+	}
+	return groups
+}
+
+// flatMaxUsed returns the number of slots that can be used before a map with size slots must grow.
+// Keeping some slots empty makes sure that lookups end.
+func flatMaxUsed(size int) int {
+	return size * 7 / 8
+}
+
+// matchByte returns a mask with the high bit set in each byte of word that might be equal to b.
+// There can be false positives, but only in a byte next to a byte that is a true match.
+func matchByte(word uint64, b uint8) uint64 {
+	x := word ^ (flatLSBs * uint64(b))
+	return (x - flatLSBs) &^ x & flatMSBs
+}
+
+// matchEmpty returns a mask with the high bit set in each byte of word that is flatEmpty.
+func matchEmpty(word uint64) uint64 {
+	return word &^ (word << 6) & flatMSBs
+}
+
+// group returns the control bytes of group g as a word.
+func (m *FlatMap[K, V]) group(g int) uint64 {
+	return binary.LittleEndian.Uint64(m.ctrl[g*flatGroupSize:])
+}
+
+// find returns the slot of k, and whether k was found.
+func (m *FlatMap[K, V]) find(k K) (slot int, ok bool) {
+	if m.count == 0 {
+		return -1, false
+	}
+	h := maphash.Comparable(m.seed, k)
+	mask := len(m.ctrl)/flatGroupSize - 1
+	g := int(h>>7) & mask
+	h2 := uint8(h & 0x7f)
+	for probe := 1; ; probe++ {
+		word := m.group(g)
+		for match := matchByte(word, h2); match != 0; match &= match - 1 {
+			slot = g*flatGroupSize + bits.TrailingZeros64(match)/8
+			if m.ctrl[slot] == h2 && m.slots[slot].key == k {
+				return slot, true
+			}
+		}
+		if matchEmpty(word) != 0 {
+			return -1, false
+		}
+		g = (g + probe) & mask
+	}
+}
+
+// insert adds k to the map, which must not have the key, and must have room for it.
+func (m *FlatMap[K, V]) insert(k K, v V) {
+	h := maphash.Comparable(m.seed, k)
+	mask := len(m.ctrl)/flatGroupSize - 1
+	g := int(h>>7) & mask
+	for probe := 1; ; probe++ {
+		// Empty and deleted slots are the only ones with the high bit set
+		if match := m.group(g) & flatMSBs; match != 0 {
+			slot := g*flatGroupSize + bits.TrailingZeros64(match)/8
+			if m.ctrl[slot] == flatEmpty {
+				m.used++
+			}
+			m.ctrl[slot] = uint8(h & 0x7f)
+			m.slots[slot] = flatSlot[K, V]{k, v}
+			m.count++
+			return
+		}
+		g = (g + probe) & mask
+	}
+}
+
+// resize moves the items to a new table with the given number of groups, removing the deleted slots.
+func (m *FlatMap[K, V]) resize(groups int) {
+	oldCtrl, oldSlots := m.ctrl, m.slots
+	if oldCtrl == nil {
+		m.seed = maphash.MakeSeed()
+	}
+	m.ctrl = bytes.Repeat([]byte{flatEmpty}, groups*flatGroupSize)
+	m.slots = make([]flatSlot[K, V], groups*flatGroupSize)
+	m.count = 0
+	m.used = 0
+	for i, c := range oldCtrl {
+		if c&0x80 == 0 {
+			m.insert(oldSlots[i].key, oldSlots[i].value)
+		}
+	}
+}
+
+// Set sets the key to the given value.
+func (m *FlatMap[K, V]) Set(k K, v V) {
+	if slot, ok := m.find(k); ok {
+		m.slots[slot].value = v
+		return
+	}
+	if m.used >= flatMaxUsed(len(m.ctrl)) {
+		// Grow if the map is more than half full. Otherwise, just clear out the deleted slots.
+		m.resize(flatGroupsFor(m.count*2 + 1))
+	}
+	m.insert(k, v)
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *FlatMap[K, V]) Load(k K) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	var slot int
+	if slot, ok = m.find(k); ok {
+		v = m.slots[slot].value
+	}
+	return
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *FlatMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the key exists.
+func (m *FlatMap[K, V]) Has(k K) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *FlatMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	if slot, ok := m.find(k); ok {
+		v = m.slots[slot].value
+		m.deleteSlot(slot)
+	}
+	return
+}
+
+// deleteSlot removes the item in the given slot.
+func (m *FlatMap[K, V]) deleteSlot(slot int) {
+	// A lookup stops at a group with an empty slot, so if the group already has one, the slot can be
+	// made empty too. Otherwise, it must be marked as deleted so that lookups continue past it.
+	if matchEmpty(m.group(slot/flatGroupSize)) != 0 {
+		m.ctrl[slot] = flatEmpty
+		m.used--
+	} else {
+		m.ctrl[slot] = flatDeleted
+	}
+	m.slots[slot] = flatSlot[K, V]{}
+	m.count--
+}
+
+// Clear removes all the items in the map.
+func (m *FlatMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.ctrl = nil
+	m.slots = nil
+	m.count = 0
+	m.used = 0
+}
+
+// Len returns the number of items in the map.
+func (m *FlatMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+// Range calls the given function for each key,value pair in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+// It is safe to delete items while ranging, but items that are added may or may not be visited.
+func (m *FlatMap[K, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	ctrl, slots := m.ctrl, m.slots
+	for i := range ctrl {
+		if ctrl[i]&0x80 == 0 {
+			if !f(slots[i].key, slots[i].value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map.
+func (m *FlatMap[K, V]) Keys() (keys []K) {
+	if m.Len() == 0 {
+		return
+	}
+	keys = make([]K, 0, m.count)
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return
+}
+
+// Values returns a new slice containing the values of the map.
+func (m *FlatMap[K, V]) Values() (values []V) {
+	if m.Len() == 0 {
+		return
+	}
+	values = make([]V, 0, m.count)
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *FlatMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+func (m *FlatMap[K, V]) Copy(in MapI[K, V]) {
+	if in == nil {
+		return
+	}
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *FlatMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m2 == nil {
+		return m.Len() == 0
+	}
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k K, v V) bool {
+		if v2, ok := m.Load(k); !ok || !equalValues(v, v2) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// std returns the items of the map as a standard map.
+func (m *FlatMap[K, V]) std() StdMap[K, V] {
+	if m == nil {
+		return nil
+	}
+	s := make(StdMap[K, V], m.count)
+	m.Range(func(k K, v V) bool {
+		s[k] = v
+		return true
+	})
+	return s
+}
+
+// String outputs the map as a string.
+func (m *FlatMap[K, V]) String() string {
+	if m == nil {
+		return ""
+	}
+	s := fmt.Sprintf("%#v", map[K]V(m.std()))
+	loc := strings.IndexRune(s, '{')
+	return s[loc:]
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *FlatMap[K, V]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(map[K]V(m.std()))
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// FlatMap.
+func (m *FlatMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var items StdMap[K, V]
+	if err = items.UnmarshalBinary(data); err == nil {
+		m.setItems(items)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *FlatMap[K, V]) MarshalJSON() (out []byte, err error) {
+	return json.Marshal(map[K]V(m.std()))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a FlatMap.
+// The JSON must start with an object.
+func (m *FlatMap[K, V]) UnmarshalJSON(in []byte) (err error) {
+	var items StdMap[K, V]
+	if err = items.UnmarshalJSON(in); err == nil {
+		m.setItems(items)
+	}
+	return
+}
+
+// setItems replaces the items of the map with items.
+func (m *FlatMap[K, V]) setItems(items StdMap[K, V]) {
+	m.Clear()
+	if len(items) > 0 {
+		m.resize(flatGroupsFor(len(items)))
+	}
+	for k, v := range items {
+		m.insert(k, v)
+	}
+}
+
+// All returns an iterator over all the items in the map.
+func (m *FlatMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+func (m *FlatMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+func (m *FlatMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *FlatMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// CollectFlatMap collects key-value pairs from seq into a new FlatMap
+// and returns it.
+func CollectFlatMap[K comparable, V any](seq iter.Seq2[K, V]) *FlatMap[K, V] {
+	m := new(FlatMap[K, V])
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the FlatMap. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *FlatMap[K, V]) Clone() *FlatMap[K, V] {
+	m1 := new(FlatMap[K, V])
+	if m == nil || m.ctrl == nil {
+		return m1
+	}
+	m1.ctrl = append([]uint8(nil), m.ctrl...)
+	m1.slots = append([]flatSlot[K, V](nil), m.slots...)
+	m1.count = m.count
+	m1.used = m.used
+	m1.seed = m.seed
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *FlatMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil {
+		return
+	}
+	for i, c := range m.ctrl {
+		if c&0x80 == 0 && del(m.slots[i].key, m.slots[i].value) {
+			m.deleteSlot(i)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatMap_Mapi(t *testing.T) {
+	runMapiTests[FlatMap[string, int]](t, makeMapi[FlatMap[string, int]])
+}
+
+func init() {
+	gob.Register(new(FlatMap[string, int]))
+}
+
+func ExampleFlatMap_String() {
+	m := NewFlatMap(map[string]int{"b": 2, "a": 1})
+	fmt.Print(m)
+	// Output: {"a":1, "b":2}
+}
+
+func TestFlatMap_Random(t *testing.T) {
+	var m FlatMap[int, int]
+	std := StdMap[int, int]{}
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range 20000 {
+		k := r.IntN(2000)
+		switch r.IntN(3) {
+		case 0, 1:
+			m.Set(k, i)
+			std[k] = i
+		case 2:
+			assert.Equal(t, std.Delete(k), m.Delete(k))
+		}
+		if i%1000 == 0 {
+			assert.True(t, m.Equal(std))
+		}
+	}
+	assert.True(t, m.Equal(std))
+	assert.True(t, m.Clone().Equal(std))
+
+	m.DeleteFunc(func(k int, _ int) bool {
+		return k%2 == 0
+	})
+	std.DeleteFunc(func(k int, _ int) bool {
+		return k%2 == 0
+	})
+	assert.True(t, m.Equal(std))
+	assert.Equal(t, len(std), len(m.Keys()))
+}
+
+func TestFlatMap_ZeroKey(t *testing.T) {
+	var m FlatMap[int, string]
+	m.Set(1, "a")
+	assert.False(t, m.Has(0), "an empty slot does not match the zero key")
+	m.Set(0, "b")
+	assert.Equal(t, "b", m.Get(0))
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestFlatMap_Tombstones(t *testing.T) {
+	// Repeatedly adding and deleting keys must not fill the table with deleted slots.
+	m := NewFlatMap(map[int]int{-1: -1})
+	for i := range 100000 {
+		m.Set(i, i)
+		m.Delete(i)
+	}
+	assert.Equal(t, 1, m.Len())
+	assert.LessOrEqual(t, len(m.ctrl), 64)
+	assert.Equal(t, -1, m.Get(-1))
+}
+
+func TestFlatMap_Nil(t *testing.T) {
+	var m *FlatMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.Nil(t, m.Keys())
+	assert.Equal(t, "", m.String())
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, 0, m.Clone().Len())
+}
+
+var benchSizes = []int{100, 10000, 1000000}
+
+func BenchmarkFlatMap_Set(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for b.Loop() {
+				m := new(FlatMap[int, int])
+				for i := range n {
+					m.Set(i, i)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkStdMap_Set(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for b.Loop() {
+				m := StdMap[int, int]{}
+				for i := range n {
+					m.Set(i, i)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFlatMap_Get(b *testing.B) {
+	for _, n := range benchSizes {
+		m := new(FlatMap[int, int])
+		for i := range n {
+			m.Set(i, i)
+		}
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			i := 0
+			for b.Loop() {
+				m.Get(i % (2 * n)) // half the keys are missing
+				i++
+			}
+		})
+	}
+}
+
+func BenchmarkStdMap_Get(b *testing.B) {
+	for _, n := range benchSizes {
+		m := StdMap[int, int]{}
+		for i := range n {
+			m.Set(i, i)
+		}
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			i := 0
+			for b.Loop() {
+				m.Get(i % (2 * n))
+				i++
+			}
+		})
+	}
+}
+
+func BenchmarkFlatMap_Range(b *testing.B) {
+	m := new(FlatMap[int, int])
+	for i := range 10000 {
+		m.Set(i, i)
+	}
+	for b.Loop() {
+		m.Range(func(k, v int) bool { return true })
+	}
+}
+
+func BenchmarkStdMap_Range(b *testing.B) {
+	m := StdMap[int, int]{}
+	for i := range 10000 {
+		m.Set(i, i)
+	}
+	for b.Loop() {
+		m.Range(func(k, v int) bool { return true })
+	}
+}
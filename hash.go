@@ -0,0 +1,57 @@
+package maps
+
+import "hash/maphash"
+
+// hashItem returns the hash of a key and its value.
+// The value is hashed as an interface, so it panics if the value is not comparable.
+func hashItem[K comparable, V any](seed maphash.Seed, k K, v V) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	maphash.WriteComparable(&h, k)
+	maphash.WriteComparable(&h, any(v))
+	return h.Sum64()
+}
+
+// hashItems returns a hash of the items that range passes to its function, without regard to their order.
+func hashItems[K comparable, V any](seed maphash.Seed, ranger func(func(K, V) bool)) uint64 {
+	var sum, count uint64
+	ranger(func(k K, v V) bool {
+		sum += hashItem(seed, k, v)
+		count++
+		return true
+	})
+	return maphash.Comparable(seed, [2]uint64{sum, count})
+}
+
+// hashOrderedItems returns a hash of the items that range passes to its function, in order.
+func hashOrderedItems[K comparable, V any](seed maphash.Seed, ranger func(func(K, V) bool)) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	ranger(func(k K, v V) bool {
+		maphash.WriteComparable(&h, hashItem(seed, k, v))
+		return true
+	})
+	return h.Sum64()
+}
+
+// hashValues returns a hash of the values that range passes to its function, without regard to their order.
+func hashValues[K comparable](seed maphash.Seed, ranger func(func(K) bool)) uint64 {
+	var sum, count uint64
+	ranger(func(k K) bool {
+		sum += maphash.Comparable(seed, k)
+		count++
+		return true
+	})
+	return maphash.Comparable(seed, [2]uint64{sum, count})
+}
+
+// hashOrderedValues returns a hash of the values that range passes to its function, in order.
+func hashOrderedValues[K comparable](seed maphash.Seed, ranger func(func(K) bool)) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	ranger(func(k K) bool {
+		maphash.WriteComparable(&h, maphash.Comparable(seed, k))
+		return true
+	})
+	return h.Sum64()
+}
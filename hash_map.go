@@ -0,0 +1,28 @@
+package maps
+
+import "iter"
+
+// HashMap is a map whose keys do not need to be comparable, like slices or structs that contain slices.
+// The map uses a hash function and an equality function on the keys that are given to NewHashMap.
+// Keys that are equal must have the same hash.
+//
+// HashMap is an EqualityMap that uses the hash of a key as its derived form.
+//
+// The zero value is NOT settable. Use NewHashMap to create a HashMap.
+type HashMap[K any, V any] = EqualityMap[K, uint64, V]
+
+// NewHashMap creates a new HashMap that uses hash and eq to find its keys.
+func NewHashMap[K any, V any](hash func(K) uint64, eq func(K, K) bool) *HashMap[K, V] {
+	if hash == nil || eq == nil {
+		panic("a HashMap needs a hash function and an equality function")
+	}
+	return NewEqualityMap[K, uint64, V](hash, eq)
+}
+
+// CollectHashMap collects key-value pairs from seq into a new HashMap that uses hash and eq,
+// and returns it.
+func CollectHashMap[K any, V any](hash func(K) uint64, eq func(K, K) bool, seq iter.Seq2[K, V]) *HashMap[K, V] {
+	m := NewHashMap[K, V](hash, eq)
+	m.Insert(seq)
+	return m
+}
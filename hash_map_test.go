@@ -0,0 +1,130 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var hashSeed = maphash.MakeSeed()
+
+func hashInts(k []int) uint64 {
+	var h maphash.Hash
+	h.SetSeed(hashSeed)
+	for _, i := range k {
+		maphash.WriteComparable(&h, i)
+	}
+	return h.Sum64()
+}
+
+func newIntsMap() *HashMap[[]int, string] {
+	return NewHashMap[[]int, string](hashInts, slices.Equal[[]int])
+}
+
+func ExampleHashMap() {
+	m := NewHashMap[string, int](
+		func(k string) uint64 { return maphash.String(hashSeed, strings.ToLower(k)) },
+		strings.EqualFold,
+	)
+	m.Set("Content-Type", 1)
+	fmt.Println(m.Get("content-type"))
+	// Output: 1
+}
+
+func TestHashMap(t *testing.T) {
+	m := newIntsMap()
+	m.Set([]int{1, 2}, "a")
+	m.Set([]int{3}, "b")
+	m.Set([]int{1, 2}, "c")
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, "c", m.Get([]int{1, 2}))
+	assert.True(t, m.Has([]int{3}))
+	assert.False(t, m.Has([]int{2, 1}))
+	assert.ElementsMatch(t, []string{"b", "c"}, m.Values())
+	assert.Len(t, m.Keys(), 2)
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	m2.Set([]int{4}, "d")
+	assert.False(t, m.Equal(m2))
+	assert.Equal(t, 2, m.Len())
+
+	assert.Equal(t, "b", m.Delete([]int{3}))
+	assert.Equal(t, "", m.Delete([]int{3}))
+	assert.Equal(t, 1, m.Len())
+
+	m2.DeleteFunc(func(k []int, _ string) bool {
+		return len(k) == 1
+	})
+	assert.Equal(t, `{[]int{1, 2}:"c"}`, m2.String())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	m.Insert(m2.All())
+	assert.True(t, m.Equal(m2))
+}
+
+func TestHashMap_Collisions(t *testing.T) {
+	m := NewHashMap[int, int](func(int) uint64 { return 0 }, func(a, b int) bool { return a == b })
+	for i := range 10 {
+		m.Set(i, i)
+	}
+	assert.Equal(t, 10, m.Len())
+	assert.Equal(t, 5, m.Get(5))
+	m.DeleteFunc(func(k int, _ int) bool {
+		return k%2 == 0
+	})
+	assert.Equal(t, 5, m.Len())
+	keys := m.Keys()
+	slices.Sort(keys)
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, keys)
+}
+
+func TestHashMap_Marshal(t *testing.T) {
+	m := newIntsMap()
+	m.Set([]int{1, 2}, "a")
+	m.Set([]int{3}, "b")
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+	m2 := newIntsMap()
+	assert.NoError(t, json.Unmarshal(b, m2))
+	assert.True(t, m.Equal(m2))
+
+	b, err = json.Marshal(newIntsMap())
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+
+	b, err = m.MarshalBinary()
+	assert.NoError(t, err)
+	m3 := newIntsMap()
+	assert.NoError(t, m3.UnmarshalBinary(b))
+	assert.True(t, m.Equal(m3))
+
+	var m4 HashMap[[]int, string]
+	assert.Error(t, m4.UnmarshalBinary(b))
+	assert.Error(t, json.Unmarshal([]byte(`[{"Key":[1],"Value":"a"}]`), &m4))
+}
+
+func TestHashMap_Nil(t *testing.T) {
+	var m *HashMap[[]int, string]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, "", m.Get([]int{1}))
+	assert.Equal(t, "", m.Delete([]int{1}))
+	assert.Nil(t, m.Keys())
+	assert.Equal(t, "", m.String())
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, 0, m.Clone().Len())
+
+	assert.Panics(t, func() {
+		new(HashMap[[]int, string]).Set([]int{1}, "a")
+	})
+	assert.Panics(t, func() {
+		NewHashMap[[]int, string](nil, nil)
+	})
+}
@@ -0,0 +1,76 @@
+package maps
+
+import (
+	"github.com/stretchr/testify/assert"
+	"hash/maphash"
+	"testing"
+)
+
+func TestMap_Hash(t *testing.T) {
+	type hasher interface {
+		MapI[string, int]
+		Hash(seed maphash.Seed) uint64
+	}
+	seed := maphash.MakeSeed()
+	tests := []struct {
+		name string
+		f    func() hasher
+	}{
+		{"StdMap", func() hasher { return NewStdMap[string, int]() }},
+		{"Map", func() hasher { return NewMap[string, int]() }},
+		{"SafeMap", func() hasher { return NewSafeMap[string, int]() }},
+		{"SliceMap", func() hasher { return NewSliceMap[string, int]() }},
+		{"SafeSliceMap", func() hasher { return NewSafeSliceMap[string, int]() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m1 := tt.f()
+			m2 := tt.f()
+			assert.Equal(t, m1.Hash(seed), m2.Hash(seed))
+			m1.Set("a", 1)
+			assert.NotEqual(t, m1.Hash(seed), m2.Hash(seed))
+			m2.Set("a", 1)
+			assert.Equal(t, m1.Hash(seed), m2.Hash(seed))
+			m2.Set("a", 2)
+			assert.NotEqual(t, m1.Hash(seed), m2.Hash(seed))
+		})
+	}
+}
+
+func TestMap_HashOrder(t *testing.T) {
+	seed := maphash.MakeSeed()
+	m1 := NewStdMap(map[string]int{"a": 1, "b": 2})
+	m2 := NewMap(map[string]int{"b": 2, "a": 1})
+	assert.Equal(t, m1.Hash(seed), m2.Hash(seed))
+
+	s1 := NewSliceMap[string, int]()
+	s1.Set("a", 1)
+	s1.Set("b", 2)
+	s2 := NewSafeSliceMap[string, int]()
+	s2.Set("b", 2)
+	s2.Set("a", 1)
+	assert.True(t, s1.Equal(s2))
+	assert.NotEqual(t, s1.Hash(seed), s2.Hash(seed))
+	s2.SetAt(0, "a", 1)
+	assert.Equal(t, s1.Hash(seed), s2.Hash(seed))
+
+	assert.Panics(t, func() {
+		NewStdMap(map[string][]int{"a": {1}}).Hash(seed)
+	})
+}
+
+func TestSet_Hash(t *testing.T) {
+	seed := maphash.MakeSeed()
+	assert.Equal(t, NewSet("a", "b").Hash(seed), NewSet("b", "a").Hash(seed))
+	assert.NotEqual(t, NewSet("a", "b").Hash(seed), NewSet("a", "c").Hash(seed))
+	assert.NotEqual(t, NewSet("ab", "c").Hash(seed), NewSet("a", "bc").Hash(seed))
+
+	assert.Equal(t, NewSafeSliceSet("a", "b").Hash(seed), NewSafeSliceSet("a", "b").Hash(seed))
+	assert.NotEqual(t, NewSafeSliceSet("a", "b").Hash(seed), NewSafeSliceSet("b", "a").Hash(seed))
+
+	assert.Equal(t, NewBitSet(1, 64, 3).Hash(seed), NewBitSet(64, 3, 1).Hash(seed))
+	assert.NotEqual(t, NewBitSet(1, 2).Hash(seed), NewBitSet(1).Hash(seed))
+	assert.Equal(t, NewSparseSet(-5, 1000).Hash(seed), NewSparseSet(1000, -5).Hash(seed))
+	assert.NotEqual(t, NewSparseSet(-5).Hash(seed), NewSparseSet(5).Hash(seed))
+	assert.Equal(t, NewBitSet().Hash(seed), NewBitSet().Hash(seed))
+}
@@ -0,0 +1,84 @@
+package maps
+
+import (
+	"errors"
+	"iter"
+)
+
+// errIdentitySerialize is returned by the serialization methods of IdentityMap.
+var errIdentitySerialize = errors.New("maps: an IdentityMap cannot be serialized")
+
+// IdentityMap is a Map keyed by pointers, so two keys are the same only if they point to the same object.
+// The objects themselves are never compared, which makes it safe to use objects whose == is meaningless
+// or that are not comparable at all, like structs that hold slices or functions.
+// Use it to track the nodes that have been visited while walking a graph, or as a registry of objects.
+//
+// Pointers are only meaningful in the running program, so an IdentityMap cannot be serialized,
+// and its marshaling methods return an error.
+//
+// The zero value is ready to use.
+type IdentityMap[T any, V any] struct {
+	Map[*T, V]
+}
+
+// NewIdentityMap creates a new IdentityMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new IdentityMap.
+func NewIdentityMap[T any, V any](sources ...map[*T]V) *IdentityMap[T, V] {
+	m := new(IdentityMap[T, V])
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// Add sets the key to the given value only if the key is not in the map, and returns true if it was added.
+// This is convenient for visited sets:
+//
+//	if visited.Add(node, struct{}{}) {
+//		// first visit to node
+//	}
+func (m *IdentityMap[T, V]) Add(p *T, v V) bool {
+	if m.Has(p) {
+		return false
+	}
+	m.Set(p, v)
+	return true
+}
+
+// MarshalBinary returns an error, since an IdentityMap cannot be serialized.
+func (m *IdentityMap[T, V]) MarshalBinary() ([]byte, error) {
+	return nil, errIdentitySerialize
+}
+
+// UnmarshalBinary returns an error, since an IdentityMap cannot be serialized.
+func (m *IdentityMap[T, V]) UnmarshalBinary([]byte) error {
+	return errIdentitySerialize
+}
+
+// MarshalJSON returns an error, since an IdentityMap cannot be serialized.
+func (m *IdentityMap[T, V]) MarshalJSON() ([]byte, error) {
+	return nil, errIdentitySerialize
+}
+
+// UnmarshalJSON returns an error, since an IdentityMap cannot be serialized.
+func (m *IdentityMap[T, V]) UnmarshalJSON([]byte) error {
+	return errIdentitySerialize
+}
+
+// CollectIdentityMap collects key-value pairs from seq into a new IdentityMap
+// and returns it.
+func CollectIdentityMap[T any, V any](seq iter.Seq2[*T, V]) *IdentityMap[T, V] {
+	m := new(IdentityMap[T, V])
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the IdentityMap with the same value comparison function. This is a shallow clone:
+// the new map has the same pointers as keys, and the values are set using ordinary assignment.
+func (m *IdentityMap[T, V]) Clone() *IdentityMap[T, V] {
+	m1 := new(IdentityMap[T, V])
+	m1.items = m.items.Clone()
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	return m1
+}
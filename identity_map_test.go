@@ -0,0 +1,60 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type graphNode struct {
+	name     string
+	edges    []*graphNode
+	callback func()
+}
+
+func ExampleIdentityMap() {
+	a := &graphNode{name: "a"}
+	b := &graphNode{name: "b"}
+	a.edges = []*graphNode{b, a}
+	b.edges = []*graphNode{a}
+
+	var visited IdentityMap[graphNode, struct{}]
+	var walk func(n *graphNode)
+	walk = func(n *graphNode) {
+		if visited.Add(n, struct{}{}) {
+			fmt.Println(n.name)
+			for _, e := range n.edges {
+				walk(e)
+			}
+		}
+	}
+	walk(a)
+	// Output: a
+	// b
+}
+
+func TestIdentityMap(t *testing.T) {
+	a := &graphNode{name: "a", callback: func() {}}
+	a2 := &graphNode{name: "a", callback: func() {}}
+
+	m := NewIdentityMap(map[*graphNode]int{a: 1})
+	assert.True(t, m.Has(a))
+	assert.False(t, m.Has(a2), "objects with the same contents are different keys")
+	assert.True(t, m.Add(a2, 2))
+	assert.False(t, m.Add(a2, 3))
+	assert.Equal(t, 2, m.Get(a2))
+
+	m2 := m.Clone()
+	assert.True(t, m2.Equal(m))
+	m3 := CollectIdentityMap(m.All())
+	assert.True(t, m3.Equal(m))
+
+	_, err := json.Marshal(m)
+	assert.Error(t, err)
+	assert.Error(t, m.UnmarshalJSON([]byte(`{}`)))
+	_, err = m.MarshalBinary()
+	assert.Error(t, err)
+	assert.Error(t, m.UnmarshalBinary(nil))
+}
@@ -0,0 +1,327 @@
+package maps
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrOverlap is returned when a range is added to an IntervalMap that does not allow overlapping ranges,
+// and the range overlaps a range that is already in the map.
+var ErrOverlap = errors.New("maps: the range overlaps a range in the map")
+
+// OverlapPolicy tells an IntervalMap what to do when a new range overlaps ranges that are already in the map.
+type OverlapPolicy int
+
+const (
+	// OverlapReplace gives the overlapping part of the old ranges the new value, splitting the old ranges
+	// as needed. This is the default.
+	OverlapReplace OverlapPolicy = iota
+	// OverlapKeep keeps the old ranges, and only gives the new value to the parts of the new range that
+	// were not in the map.
+	OverlapKeep
+	// OverlapError does not change the map, and SetRange returns ErrOverlap.
+	OverlapError
+)
+
+// intervalItem is a range and its value in an IntervalMap.
+type intervalItem[K cmp.Ordered, V any] struct {
+	Lo    K
+	Hi    K
+	Value V
+}
+
+func (i intervalItem[K, V]) interval() Interval[K] {
+	return Interval[K]{i.Lo, i.Hi}
+}
+
+// IntervalMap maps ranges of keys to values, like price bands or blocks of IP addresses.
+// Each range is a half-open Interval, and Get returns the value of the range that holds a key.
+// The ranges in the map never overlap. What happens when a new range overlaps the ranges in the map
+// is decided by the OverlapPolicy of the map.
+//
+// Ranges are kept in order, and are not joined, even when they touch and have the same value.
+//
+// The zero value is ready to use, with the OverlapReplace policy.
+// Do not make a copy of an IntervalMap using the equality operator (=). Use Clone instead.
+type IntervalMap[K cmp.Ordered, V any] struct {
+	items  []intervalItem[K, V]
+	policy OverlapPolicy
+}
+
+// NewIntervalMap creates a new IntervalMap with the given policy for overlapping ranges.
+func NewIntervalMap[K cmp.Ordered, V any](policy OverlapPolicy) *IntervalMap[K, V] {
+	return &IntervalMap[K, V]{policy: policy}
+}
+
+// Policy returns the OverlapPolicy of the map.
+func (m *IntervalMap[K, V]) Policy() OverlapPolicy {
+	if m == nil {
+		return OverlapReplace
+	}
+	return m.policy
+}
+
+// overlapping returns the positions of the ranges that overlap [lo, hi), which are the ranges from i up to j.
+func (m *IntervalMap[K, V]) overlapping(lo, hi K) (i, j int) {
+	i = sort.Search(len(m.items), func(n int) bool { return m.items[n].Hi > lo })
+	j = sort.Search(len(m.items), func(n int) bool { return m.items[n].Lo >= hi })
+	return
+}
+
+// SetRange sets the keys from lo up to, but not including, hi to the value v. If lo >= hi, nothing is set.
+// If the range overlaps ranges in the map, the OverlapPolicy of the map decides what happens,
+// and SetRange returns ErrOverlap if the policy is OverlapError.
+func (m *IntervalMap[K, V]) SetRange(lo, hi K, v V) error {
+	if !(lo < hi) {
+		return nil
+	}
+	i, j := m.overlapping(lo, hi)
+	if i == j {
+		m.items = slices.Insert(m.items, i, intervalItem[K, V]{lo, hi, v})
+		return nil
+	}
+	switch m.policy {
+	case OverlapError:
+		return ErrOverlap
+	case OverlapKeep:
+		var gaps []intervalItem[K, V]
+		for _, item := range m.items[i:j] {
+			if lo < item.Lo {
+				gaps = append(gaps, intervalItem[K, V]{lo, item.Lo, v})
+			}
+			lo = item.Hi
+		}
+		if lo < hi {
+			gaps = append(gaps, intervalItem[K, V]{lo, hi, v})
+		}
+		m.items = slices.Replace(m.items, i, j, mergeIntervals(m.items[i:j], gaps)...)
+	default:
+		m.items = slices.Replace(m.items, i, j, m.cut(i, j, lo, hi, &intervalItem[K, V]{lo, hi, v})...)
+	}
+	return nil
+}
+
+// mergeIntervals returns the ranges of a and b in order. The ranges must not overlap.
+func mergeIntervals[K cmp.Ordered, V any](a, b []intervalItem[K, V]) []intervalItem[K, V] {
+	items := append(slices.Clone(a), b...)
+	slices.SortFunc(items, func(x, y intervalItem[K, V]) int {
+		return cmp.Compare(x.Lo, y.Lo)
+	})
+	return items
+}
+
+// cut returns what is left of the ranges from i up to j after removing [lo, hi), with item in its place if it is not nil.
+func (m *IntervalMap[K, V]) cut(i, j int, lo, hi K, item *intervalItem[K, V]) (rest []intervalItem[K, V]) {
+	if first := m.items[i]; first.Lo < lo {
+		rest = append(rest, intervalItem[K, V]{first.Lo, lo, first.Value})
+	}
+	if item != nil {
+		rest = append(rest, *item)
+	}
+	if last := m.items[j-1]; last.Hi > hi {
+		rest = append(rest, intervalItem[K, V]{hi, last.Hi, last.Value})
+	}
+	return
+}
+
+// DeleteRange removes the keys from lo up to, but not including, hi, splitting ranges as needed.
+func (m *IntervalMap[K, V]) DeleteRange(lo, hi K) {
+	if m == nil || !(lo < hi) {
+		return
+	}
+	if i, j := m.overlapping(lo, hi); i < j {
+		m.items = slices.Replace(m.items, i, j, m.cut(i, j, lo, hi, nil)...)
+	}
+}
+
+// Find returns the range that holds k and its value, and false if no range holds k.
+func (m *IntervalMap[K, V]) Find(k K) (r Interval[K], v V, ok bool) {
+	if m == nil {
+		return
+	}
+	i := sort.Search(len(m.items), func(n int) bool { return m.items[n].Hi > k })
+	if i < len(m.items) && m.items[i].Lo <= k {
+		return m.items[i].interval(), m.items[i].Value, true
+	}
+	return
+}
+
+// Load returns the value of the range that holds k, and a boolean indicating whether there is such a range.
+func (m *IntervalMap[K, V]) Load(k K) (v V, ok bool) {
+	_, v, ok = m.Find(k)
+	return
+}
+
+// Get returns the value of the range that holds k. If no range holds k, the zero value will be returned.
+func (m *IntervalMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if a range holds k.
+func (m *IntervalMap[K, V]) Has(k K) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// Clear removes all the ranges in the map. The policy does not change.
+func (m *IntervalMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.items = nil
+}
+
+// Len returns the number of ranges in the map.
+func (m *IntervalMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items)
+}
+
+// Range calls the given function for each range and its value, in order.
+// If f returns false, it stops the iteration.
+func (m *IntervalMap[K, V]) Range(f func(r Interval[K], v V) bool) {
+	if m == nil {
+		return
+	}
+	for _, item := range slices.Clone(m.items) {
+		if !f(item.interval(), item.Value) {
+			return
+		}
+	}
+}
+
+// Intervals returns a new slice containing the ranges of the map, in order.
+func (m *IntervalMap[K, V]) Intervals() (intervals []Interval[K]) {
+	m.Range(func(r Interval[K], _ V) bool {
+		intervals = append(intervals, r)
+		return true
+	})
+	return
+}
+
+// Values returns a new slice containing the values of the ranges, in order.
+func (m *IntervalMap[K, V]) Values() (values []V) {
+	m.Range(func(_ Interval[K], v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// All returns an iterator over the ranges and their values, in order.
+func (m *IntervalMap[K, V]) All() iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		m.Range(yield)
+	}
+}
+
+// Equal returns true if the maps have the same ranges with the same values.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *IntervalMap[K, V]) Equal(m2 *IntervalMap[K, V]) bool {
+	if m.Len() != m2.Len() {
+		return false
+	}
+	for i, item := range m.itemsOrNil() {
+		item2 := m2.items[i]
+		if item.Lo != item2.Lo || item.Hi != item2.Hi || !equalValues(item.Value, item2.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// itemsOrNil returns the items of the map, or nil if the map is nil.
+func (m *IntervalMap[K, V]) itemsOrNil() []intervalItem[K, V] {
+	if m == nil {
+		return nil
+	}
+	return m.items
+}
+
+// String returns the map as a string, like {[1,5):"a",[7,9):"b"}.
+func (m *IntervalMap[K, V]) String() string {
+	var s []string
+	m.Range(func(r Interval[K], v V) bool {
+		s = append(s, fmt.Sprintf("%s:%#v", r, v))
+		return true
+	})
+	return "{" + strings.Join(s, ",") + "}"
+}
+
+// setItems replaces the ranges of the map with items, using the OverlapReplace policy.
+func (m *IntervalMap[K, V]) setItems(items []intervalItem[K, V]) {
+	policy := m.policy
+	m.policy = OverlapReplace
+	m.items = nil
+	for _, item := range items {
+		_ = m.SetRange(item.Lo, item.Hi, item.Value)
+	}
+	m.policy = policy
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+// The policy is not serialized.
+func (m *IntervalMap[K, V]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(m.itemsOrNil())
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to an
+// IntervalMap.
+func (m *IntervalMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var items []intervalItem[K, V]
+
+	b := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(b)
+	if err = dec.Decode(&items); err == nil {
+		m.setItems(items)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON array of ranges,
+// where each range is an object like {"Lo":1,"Hi":5,"Value":"a"}. The policy is not serialized.
+func (m *IntervalMap[K, V]) MarshalJSON() (out []byte, err error) {
+	items := m.itemsOrNil()
+	if items == nil {
+		items = []intervalItem[K, V]{}
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a JSON array of ranges to an IntervalMap.
+// If ranges overlap, the later ones replace the earlier ones.
+func (m *IntervalMap[K, V]) UnmarshalJSON(in []byte) (err error) {
+	var items []intervalItem[K, V]
+	if err = json.Unmarshal(in, &items); err == nil {
+		m.setItems(items)
+	}
+	return
+}
+
+// Clone returns a copy of the IntervalMap, with the same policy. This is a shallow clone:
+// the new values are set using ordinary assignment.
+func (m *IntervalMap[K, V]) Clone() *IntervalMap[K, V] {
+	m1 := new(IntervalMap[K, V])
+	if m != nil {
+		m1.policy = m.policy
+		m1.items = slices.Clone(m.items)
+	}
+	return m1
+}
@@ -0,0 +1,111 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleIntervalMap() {
+	prices := new(IntervalMap[int, string])
+	_ = prices.SetRange(0, 10, "small")
+	_ = prices.SetRange(10, 100, "medium")
+	_ = prices.SetRange(100, 1000, "large")
+	fmt.Println(prices.Get(5), prices.Get(10), prices.Get(999), prices.Get(1000) == "")
+	// Output: small medium large true
+}
+
+func TestIntervalMap_SetRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   OverlapPolicy
+		lo, hi   int
+		expected string
+		err      error
+	}{
+		{"gap", OverlapReplace, 5, 7, `{[1,5):"a",[5,7):"x",[7,10):"b"}`, nil},
+		{"empty", OverlapReplace, 7, 7, `{[1,5):"a",[7,10):"b"}`, nil},
+		{"replace middle", OverlapReplace, 2, 3, `{[1,2):"a",[2,3):"x",[3,5):"a",[7,10):"b"}`, nil},
+		{"replace across", OverlapReplace, 3, 8, `{[1,3):"a",[3,8):"x",[8,10):"b"}`, nil},
+		{"replace all", OverlapReplace, 0, 20, `{[0,20):"x"}`, nil},
+		{"keep across", OverlapKeep, 0, 12, `{[0,1):"x",[1,5):"a",[5,7):"x",[7,10):"b",[10,12):"x"}`, nil},
+		{"keep inside", OverlapKeep, 2, 3, `{[1,5):"a",[7,10):"b"}`, nil},
+		{"keep end", OverlapKeep, 3, 6, `{[1,5):"a",[5,6):"x",[7,10):"b"}`, nil},
+		{"error", OverlapError, 4, 6, `{[1,5):"a",[7,10):"b"}`, ErrOverlap},
+		{"error touching", OverlapError, 5, 7, `{[1,5):"a",[5,7):"x",[7,10):"b"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewIntervalMap[int, string](tt.policy)
+			assert.NoError(t, m.SetRange(1, 5, "a"))
+			assert.NoError(t, m.SetRange(7, 10, "b"))
+			assert.Equal(t, tt.err, m.SetRange(tt.lo, tt.hi, "x"))
+			assert.Equal(t, tt.expected, m.String())
+		})
+	}
+}
+
+func TestIntervalMap(t *testing.T) {
+	var m IntervalMap[float64, int]
+	assert.Equal(t, OverlapReplace, m.Policy())
+	_ = m.SetRange(1, 2, 1)
+	_ = m.SetRange(3, 4, 2)
+
+	r, v, ok := m.Find(3.5)
+	assert.Equal(t, Interval[float64]{3, 4}, r)
+	assert.Equal(t, 2, v)
+	assert.True(t, ok)
+	_, _, ok = m.Find(2)
+	assert.False(t, ok)
+	assert.True(t, m.Has(1))
+	assert.False(t, m.Has(0.5))
+
+	assert.Equal(t, []Interval[float64]{{1, 2}, {3, 4}}, m.Intervals())
+	assert.Equal(t, []int{1, 2}, m.Values())
+	n := 0
+	for range m.All() {
+		n++
+	}
+	assert.Equal(t, 2, n)
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	m2.DeleteRange(1.5, 3.5)
+	assert.Equal(t, "{[1,1.5):1,[3.5,4):2}", m2.String())
+	assert.False(t, m.Equal(m2))
+	m2.Clear()
+	assert.Equal(t, 0, m2.Len())
+}
+
+func TestIntervalMap_Marshal(t *testing.T) {
+	m := new(IntervalMap[int, string])
+	_ = m.SetRange(1, 5, "a")
+	_ = m.SetRange(7, 10, "b")
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"Lo":1,"Hi":5,"Value":"a"},{"Lo":7,"Hi":10,"Value":"b"}]`, string(b))
+	m2 := NewIntervalMap[int, string](OverlapError)
+	assert.NoError(t, json.Unmarshal(b, m2))
+	assert.True(t, m.Equal(m2))
+	assert.Equal(t, OverlapError, m2.Policy())
+
+	b, err = m.MarshalBinary()
+	assert.NoError(t, err)
+	var m3 IntervalMap[int, string]
+	assert.NoError(t, m3.UnmarshalBinary(b))
+	assert.True(t, m.Equal(&m3))
+}
+
+func TestIntervalMap_Nil(t *testing.T) {
+	var m *IntervalMap[int, string]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, "", m.Get(1))
+	assert.Nil(t, m.Intervals())
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "{}", m.String())
+	assert.Equal(t, 0, m.Clone().Len())
+	m.DeleteRange(1, 2)
+}
@@ -0,0 +1,84 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Key2 is a key made of two values, for maps with two-dimensional keys.
+//
+// A Key2 can be used as the key of any of the maps. It implements encoding.TextMarshaler,
+// so maps keyed by a Key2 can be converted to JSON, where each key is written as a JSON array
+// in a string, like "[1,\"a\"]".
+type Key2[T1, T2 comparable] struct {
+	K1 T1
+	K2 T2
+}
+
+// MakeKey2 returns a Key2 made of k1 and k2.
+func MakeKey2[T1, T2 comparable](k1 T1, k2 T2) Key2[T1, T2] {
+	return Key2[T1, T2]{k1, k2}
+}
+
+// String returns the key as a string.
+func (k Key2[T1, T2]) String() string {
+	return fmt.Sprintf("(%v, %v)", k.K1, k.K2)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface to convert the key to a JSON array.
+func (k Key2[T1, T2]) MarshalText() ([]byte, error) {
+	return json.Marshal([]any{k.K1, k.K2})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface to convert a JSON array to the key.
+func (k *Key2[T1, T2]) UnmarshalText(text []byte) error {
+	return unmarshalKey(text, &k.K1, &k.K2)
+}
+
+// Key3 is a key made of three values, for maps with three-dimensional keys.
+//
+// A Key3 can be used as the key of any of the maps. It implements encoding.TextMarshaler,
+// so maps keyed by a Key3 can be converted to JSON, where each key is written as a JSON array
+// in a string, like "[1,\"a\",true]".
+type Key3[T1, T2, T3 comparable] struct {
+	K1 T1
+	K2 T2
+	K3 T3
+}
+
+// MakeKey3 returns a Key3 made of k1, k2 and k3.
+func MakeKey3[T1, T2, T3 comparable](k1 T1, k2 T2, k3 T3) Key3[T1, T2, T3] {
+	return Key3[T1, T2, T3]{k1, k2, k3}
+}
+
+// String returns the key as a string.
+func (k Key3[T1, T2, T3]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", k.K1, k.K2, k.K3)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface to convert the key to a JSON array.
+func (k Key3[T1, T2, T3]) MarshalText() ([]byte, error) {
+	return json.Marshal([]any{k.K1, k.K2, k.K3})
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface to convert a JSON array to the key.
+func (k *Key3[T1, T2, T3]) UnmarshalText(text []byte) error {
+	return unmarshalKey(text, &k.K1, &k.K2, &k.K3)
+}
+
+// unmarshalKey unmarshals the JSON array in text into parts. The array must have one item for each part.
+func unmarshalKey(text []byte, parts ...any) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(text, &items); err != nil {
+		return err
+	}
+	if len(items) != len(parts) {
+		return fmt.Errorf("maps: a key needs %d parts, but %d were given", len(parts), len(items))
+	}
+	for i, item := range items {
+		if err := json.Unmarshal(item, parts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package maps
+
+// The functions in this file apply the key function set by SetKeyFunc on Map and SafeMap.
+// A nil key function does not change the keys.
+
+// applyKeyFunc returns the key that k is stored under.
+func applyKeyFunc[K comparable](f func(K) K, k K) K {
+	if f == nil {
+		return k
+	}
+	return f(k)
+}
+
+// applyKeyFuncToKeys returns the keys that keys are stored under. keys is not changed.
+func applyKeyFuncToKeys[K comparable](f func(K) K, keys []K) []K {
+	if f == nil {
+		return keys
+	}
+	keys2 := make([]K, len(keys))
+	for i, k := range keys {
+		keys2[i] = f(k)
+	}
+	return keys2
+}
+
+// applyKeyFuncToPairs returns pairs with the keys they are stored under. pairs is not changed.
+func applyKeyFuncToPairs[K comparable, V any](f func(K) K, pairs []Pair[K, V]) []Pair[K, V] {
+	if f == nil {
+		return pairs
+	}
+	pairs2 := make([]Pair[K, V], len(pairs))
+	for i, p := range pairs {
+		pairs2[i] = Pair[K, V]{f(p.Key), p.Value}
+	}
+	return pairs2
+}
+
+// applyKeyFuncToItems returns items with their keys changed by f.
+// If more than one key changes to the same key, which value is kept is not determinate.
+func applyKeyFuncToItems[K comparable, V any](f func(K) K, items StdMap[K, V]) StdMap[K, V] {
+	if f == nil || items == nil {
+		return items
+	}
+	items2 := make(StdMap[K, V], len(items))
+	for k, v := range items {
+		items2[f(k)] = v
+	}
+	return items2
+}
+
+// copyWithKeyFunc copies the items of in to items, changing the keys with f.
+func copyWithKeyFunc[K comparable, V any](f func(K) K, items StdMap[K, V], in MapI[K, V]) {
+	if f == nil {
+		items.Copy(in)
+		return
+	}
+	in.Range(func(k K, v V) bool {
+		items[f(k)] = v
+		return true
+	})
+}
+
+// copyFuncWithKeyFunc copies the items of in into items, after changing their keys with f.
+// When a key is already in items, the value stored is the one resolve returns.
+func copyFuncWithKeyFunc[K comparable, V any](f func(K) K, items StdMap[K, V], in MapI[K, V], resolve func(k K, existing, incoming V) V) {
+	in.Range(func(k K, v V) bool {
+		k = applyKeyFunc(f, k)
+		if existing, ok := items[k]; ok && resolve != nil {
+			v = resolve(k, existing, v)
+		}
+		items[k] = v
+		return true
+	})
+}
+
+// equalWithKeyFunc returns true if items has the same keys and values as m2,
+// after the keys of m2 are changed with f. Values are compared with eq, if it is not nil.
+func equalWithKeyFunc[K comparable, V any](f func(K) K, eq func(a, b V) bool, items StdMap[K, V], m2 MapI[K, V]) bool {
+	if f == nil && eq == nil {
+		return items.Equal(m2)
+	}
+	if items.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k K, v V) bool {
+		if v2, ok := items[applyKeyFunc(f, k)]; !ok || !equalValuesFunc(eq, v2, v) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
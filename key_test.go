@@ -0,0 +1,66 @@
+package maps
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gob.Register(new(Map[Key2[string, int], int]))
+}
+
+func ExampleKey2() {
+	m := new(Map[Key2[string, int], string])
+	m.Set(MakeKey2("a", 1), "x")
+	b, _ := json.Marshal(m)
+	fmt.Println(m.Get(MakeKey2("a", 1)), string(b))
+	// Output: x {"[\"a\",1]":"x"}
+}
+
+func TestKey2(t *testing.T) {
+	m := NewMap(map[Key2[string, int]]int{
+		MakeKey2("a", 1): 1,
+		MakeKey2("a", 2): 2,
+	})
+	assert.Equal(t, "(a, 1)", MakeKey2("a", 1).String())
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+	m2 := new(Map[Key2[string, int], int])
+	assert.NoError(t, json.Unmarshal(b, m2))
+	assert.True(t, m.Equal(m2))
+
+	b, err = m.MarshalBinary()
+	assert.NoError(t, err)
+	m3 := new(Map[Key2[string, int], int])
+	assert.NoError(t, m3.UnmarshalBinary(b))
+	assert.True(t, m.Equal(m3))
+
+	var k Key2[string, int]
+	assert.Error(t, k.UnmarshalText([]byte(`["a"]`)))
+	assert.Error(t, k.UnmarshalText([]byte(`["a","b"]`)))
+	assert.Error(t, k.UnmarshalText([]byte(`{}`)))
+}
+
+func TestKey3(t *testing.T) {
+	m := new(SliceMap[Key3[int, string, bool], int])
+	m.Set(MakeKey3(1, "a", true), 1)
+	m.Set(MakeKey3(1, "a", false), 2)
+	assert.Equal(t, "(1, a, true)", MakeKey3(1, "a", true).String())
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+	m2 := new(SliceMap[Key3[int, string, bool], int])
+	assert.NoError(t, json.Unmarshal(b, m2))
+	assert.True(t, m.Equal(m2))
+
+	b, err = m.MarshalBinary()
+	assert.NoError(t, err)
+	m3 := new(SliceMap[Key3[int, string, bool], int])
+	assert.NoError(t, m3.UnmarshalBinary(b))
+	assert.Equal(t, m.Keys(), m3.Keys())
+}
@@ -0,0 +1,519 @@
+package maps
+
+import (
+	"bytes"
+	"cmp"
+	"container/heap"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// LFUMap is a map with a fixed capacity that tracks how often each key is used.
+// When a new key is added to a full LFUMap, the least frequently used item is removed to make room for it.
+// If more than one item has the lowest use count, the one that was used least recently is removed.
+//
+// Calls to Set, Get and Load count as a use of the key. Has, Range and the other methods
+// that read the whole map do not.
+//
+// The zero value is NOT settable. Use NewLFUMap to create an LFUMap with a capacity.
+// An LFUMap is not safe for concurrent use, since even reading from it changes the use counts.
+//
+// LFUMap is useful as a cache for workloads where some keys are used much more often than others.
+type LFUMap[K comparable, V any] struct {
+	items    map[K]*lfuEntry[K, V]
+	heap     lfuHeap[K, V]
+	capacity int
+	tick     uint64
+	stats    CacheStats
+
+	onEvict func(k K, v V, reason EvictReason)
+}
+
+// lfuEntry is an item in an LFUMap.
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	freq  int    // the number of times the key was used
+	tick  uint64 // when the key was last used
+	index int    // the location of the entry in the heap
+}
+
+// lfuHeap is a min-heap of entries, with the least frequently used entry at the top.
+type lfuHeap[K comparable, V any] []*lfuEntry[K, V]
+
+func (h lfuHeap[K, V]) Len() int {
+	return len(h)
+}
+
+func (h lfuHeap[K, V]) Less(i, j int) bool {
+	if h[i].freq != h[j].freq {
+		return h[i].freq < h[j].freq
+	}
+	return h[i].tick < h[j].tick
+}
+
+func (h lfuHeap[K, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap[K, V]) Push(x any) {
+	e := x.(*lfuEntry[K, V])
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *lfuHeap[K, V]) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
+
+// NewLFUMap creates a new LFUMap that can hold up to capacity items.
+func NewLFUMap[K comparable, V any](capacity int) *LFUMap[K, V] {
+	if capacity <= 0 {
+		panic("the capacity of an LFUMap must be greater than zero")
+	}
+	return &LFUMap[K, V]{
+		items:    make(map[K]*lfuEntry[K, V], capacity),
+		heap:     make(lfuHeap[K, V], 0, capacity),
+		capacity: capacity,
+	}
+}
+
+// Cap returns the maximum number of items the map can hold.
+func (m *LFUMap[K, V]) Cap() int {
+	if m == nil {
+		return 0
+	}
+	return m.capacity
+}
+
+// use records a use of the entry.
+func (m *LFUMap[K, V]) use(e *lfuEntry[K, V]) {
+	m.tick++
+	e.freq++
+	e.tick = m.tick
+	heap.Fix(&m.heap, e.index)
+}
+
+// OnEvict sets a function that is called after an item is removed from the map, so that resources held by
+// the value, like files or connections, can be released. The reason tells whether the item was removed
+// to make room for a new key, or by Delete, DeleteFunc or Clear. Replacing the value of an existing key does not
+// call f. Pass nil to remove the callback. The callback is not copied by Clone.
+func (m *LFUMap[K, V]) OnEvict(f func(k K, v V, reason EvictReason)) {
+	m.onEvict = f
+}
+
+// evicted calls the OnEvict callback, if there is one.
+func (m *LFUMap[K, V]) evicted(k K, v V, reason EvictReason) {
+	if m.onEvict != nil {
+		m.onEvict(k, v, reason)
+	}
+}
+
+// Stats returns the statistics of the map since it was created or since the last call to ResetStats.
+func (m *LFUMap[K, V]) Stats() CacheStats {
+	if m == nil {
+		return CacheStats{}
+	}
+	s := m.stats
+	s.Size = len(m.heap)
+	return s
+}
+
+// ResetStats sets the hit, miss and eviction counts to zero.
+func (m *LFUMap[K, V]) ResetStats() {
+	if m == nil {
+		return
+	}
+	m.stats = CacheStats{}
+}
+
+// Set sets the given key to the given value.
+// If the key is new and the map is full, the least frequently used item will be removed.
+func (m *LFUMap[K, V]) Set(k K, v V) {
+	m.Push(k, v)
+}
+
+// Push sets the given key to the given value, and returns the item that was removed to make room for it.
+// If the key is new and the map is full, the least frequently used item will be removed, and ok will be true.
+func (m *LFUMap[K, V]) Push(k K, v V) (evicted Pair[K, V], ok bool) {
+	if m.Cap() == 0 {
+		panic("cannot call Set() on an LFUMap with no capacity")
+	}
+	if e, exists := m.items[k]; exists {
+		e.value = v
+		m.use(e)
+		return
+	}
+	if m.items == nil {
+		m.items = make(map[K]*lfuEntry[K, V], m.capacity)
+	}
+	if len(m.heap) == m.capacity {
+		e := heap.Pop(&m.heap).(*lfuEntry[K, V])
+		delete(m.items, e.key)
+		evicted = Pair[K, V]{e.key, e.value}
+		ok = true
+		m.stats.Evictions++
+	}
+	e := &lfuEntry[K, V]{key: k, value: v}
+	m.items[k] = e
+	heap.Push(&m.heap, e)
+	m.use(e)
+	if ok {
+		m.evicted(evicted.Key, evicted.Value, EvictedByCapacity)
+	}
+	return
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *LFUMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *LFUMap[K, V]) Load(k K) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	var e *lfuEntry[K, V]
+	if e, ok = m.items[k]; ok {
+		v = e.value
+		m.use(e)
+		m.stats.Hits++
+	} else {
+		m.stats.Misses++
+	}
+	return
+}
+
+// Has returns true if the key exists. Has does not count as a use of the key.
+func (m *LFUMap[K, V]) Has(k K) (exists bool) {
+	if m == nil {
+		return
+	}
+	_, exists = m.items[k]
+	return
+}
+
+// Frequency returns the number of times the key has been used, or zero if the key is not in the map.
+func (m *LFUMap[K, V]) Frequency(k K) int {
+	if m == nil {
+		return 0
+	}
+	if e, ok := m.items[k]; ok {
+		return e.freq
+	}
+	return 0
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *LFUMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	e, ok := m.items[k]
+	if !ok {
+		return
+	}
+	delete(m.items, k)
+	heap.Remove(&m.heap, e.index)
+	m.evicted(k, e.value, EvictedByDelete)
+	return e.value
+}
+
+// Clear removes all the items in the map. The capacity is not changed.
+func (m *LFUMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	items := m.items
+	m.items = nil
+	clear(m.heap)
+	m.heap = m.heap[:0]
+	m.tick = 0
+	if m.onEvict != nil {
+		for k, e := range items {
+			m.onEvict(k, e.value, EvictedByDelete)
+		}
+	}
+}
+
+// Len returns the number of items in the map.
+func (m *LFUMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.heap)
+}
+
+// Range calls the given function for each key,value pair in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+// The order of the iteration is not determinate, and ranging does not count as a use of the keys.
+func (m *LFUMap[K, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	for k, e := range m.items {
+		if !f(k, e.value) {
+			break
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map.
+func (m *LFUMap[K, V]) Keys() (keys []K) {
+	if m.Len() == 0 {
+		return
+	}
+	keys = make([]K, 0, m.Len())
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return
+}
+
+// Values returns a new slice containing the values of the map.
+func (m *LFUMap[K, V]) Values() (values []V) {
+	if m.Len() == 0 {
+		return
+	}
+	values = make([]V, 0, m.Len())
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *LFUMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+// If in has more items than will fit, the least frequently used items will be removed.
+func (m *LFUMap[K, V]) Copy(in MapI[K, V]) {
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal. The use counts are not compared.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *LFUMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m == nil {
+		return m2 == nil || m2.Len() == 0
+	}
+	return m.snapshot().Equal(m2)
+}
+
+// snapshot returns the contents of the map as a standard map.
+func (m *LFUMap[K, V]) snapshot() StdMap[K, V] {
+	if m == nil {
+		return nil
+	}
+	s := make(StdMap[K, V], len(m.items))
+	for k, e := range m.items {
+		s[k] = e.value
+	}
+	return s
+}
+
+// String outputs the map as a string.
+func (m *LFUMap[K, V]) String() string {
+	if m == nil {
+		return ""
+	}
+	s := fmt.Sprintf("%#v", map[K]V(m.snapshot()))
+	loc := strings.IndexRune(s, '{')
+	return s[loc:]
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+// The capacity and the use counts are included.
+func (m *LFUMap[K, V]) MarshalBinary() (data []byte, err error) {
+	buf := new(bytes.Buffer)
+	encoder := gob.NewEncoder(buf)
+
+	// Encode from the least recently used so that the order of uses is preserved.
+	var entries lfuHeap[K, V]
+	if m != nil {
+		entries = append(entries, m.heap...)
+	}
+	slices.SortFunc(entries, func(a, b *lfuEntry[K, V]) int {
+		return cmp.Compare(a.tick, b.tick)
+	})
+	keys := make([]K, len(entries))
+	values := make([]V, len(entries))
+	freqs := make([]int, len(entries))
+	for i, e := range entries {
+		keys[i] = e.key
+		values[i] = e.value
+		freqs[i] = e.freq
+	}
+
+	err = encoder.Encode(m.Cap())
+	if err == nil {
+		err = encoder.Encode(keys)
+	}
+	if err == nil {
+		err = encoder.Encode(values)
+	}
+	if err == nil {
+		err = encoder.Encode(freqs)
+	}
+	data = buf.Bytes()
+	return
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to an
+// LFUMap.
+func (m *LFUMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var capacity int
+	var keys []K
+	var values []V
+	var freqs []int
+
+	buf := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buf)
+	if err = dec.Decode(&capacity); err == nil {
+		if err = dec.Decode(&keys); err == nil {
+			if err = dec.Decode(&values); err == nil {
+				err = dec.Decode(&freqs)
+			}
+		}
+	}
+	if err != nil {
+		return
+	}
+	if len(keys) != len(values) || len(keys) != len(freqs) {
+		return fmt.Errorf("maps: cannot unmarshal %d keys with %d values and %d use counts", len(keys), len(values), len(freqs))
+	}
+	if err = checkCapacity(capacity, len(keys)); err != nil {
+		return
+	}
+	onEvict := m.onEvict
+	if capacity == 0 {
+		*m = LFUMap[K, V]{} // the zero value was marshaled
+	} else {
+		*m = *NewLFUMap[K, V](capacity)
+	}
+	m.onEvict = onEvict
+	for i, k := range keys {
+		m.Set(k, values[i])
+		m.items[k].freq = freqs[i]
+	}
+	heap.Init(&m.heap)
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+// The use counts are not included.
+func (m *LFUMap[K, V]) MarshalJSON() (data []byte, err error) {
+	if m == nil {
+		return
+	}
+	return json.Marshal(map[K]V(m.snapshot()))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to an LFUMap.
+// The JSON must start with an object. All the items will start with a use count of one.
+// If the map does not have enough capacity to hold all the items, its capacity will be increased.
+func (m *LFUMap[K, V]) UnmarshalJSON(data []byte) (err error) {
+	var items map[K]V
+
+	if err = json.Unmarshal(data, &items); err == nil {
+		onEvict := m.onEvict
+		*m = *NewLFUMap[K, V](max(m.Cap(), len(items), 1))
+		m.onEvict = onEvict
+		for k, v := range items {
+			m.Set(k, v)
+		}
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map.
+// Iterating does not count as a use of the keys.
+func (m *LFUMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+func (m *LFUMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+func (m *LFUMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden. If the map fills up, the least frequently used items will be removed.
+func (m *LFUMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// Clone returns a copy of the LFUMap, including the use counts. This is a shallow clone:
+// the new keys and values are set using ordinary assignment. Cloning a zero value returns a new zero value.
+func (m *LFUMap[K, V]) Clone() *LFUMap[K, V] {
+	if m.Cap() == 0 {
+		return new(LFUMap[K, V]) // the zero value has no capacity
+	}
+	m1 := NewLFUMap[K, V](m.Cap())
+	m1.tick = m.tick
+	for _, e := range m.heap {
+		e1 := *e
+		m1.items[e.key] = &e1
+		m1.heap = append(m1.heap, &e1)
+	}
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *LFUMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m.Len() == 0 {
+		return
+	}
+	var deleted []*lfuEntry[K, V]
+	for k, e := range m.items {
+		if del(k, e.value) {
+			delete(m.items, k)
+			heap.Remove(&m.heap, e.index)
+			deleted = append(deleted, e)
+		}
+	}
+	for _, e := range deleted {
+		m.evicted(e.key, e.value, EvictedByDelete)
+	}
+}
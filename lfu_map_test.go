@@ -0,0 +1,198 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFUMap_Mapi(t *testing.T) {
+	runMapiTests[LFUMap[string, int]](t,
+		func(sources ...mapT) MapI[string, int] {
+			m := NewLFUMap[string, int](10)
+			for _, s := range sources {
+				m.Merge(s)
+			}
+			return m
+		},
+	)
+}
+
+func init() {
+	gob.Register(new(LFUMap[string, int]))
+}
+
+func ExampleLFUMap() {
+	m := NewLFUMap[string, int](3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Get("a")
+	m.Get("c")
+	evicted, ok := m.Push("d", 4)
+	fmt.Println(evicted.Key, evicted.Value, ok)
+	fmt.Println(m)
+	// Output: b 2 true
+	// {"a":1, "c":3, "d":4}
+}
+
+func TestLFUMap(t *testing.T) {
+	assert.Panics(t, func() {
+		NewLFUMap[string, int](0)
+	})
+	assert.Panics(t, func() {
+		var m LFUMap[string, int]
+		m.Set("a", 1)
+	})
+
+	m := NewLFUMap[string, int](3)
+	assert.Equal(t, 3, m.Cap())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Get("a")
+	m.Get("a")
+	m.Get("b")
+	assert.Equal(t, 3, m.Frequency("a"))
+	assert.Equal(t, 2, m.Frequency("b"))
+	assert.Equal(t, 1, m.Frequency("c"))
+	assert.Equal(t, 0, m.Frequency("z"))
+
+	// Has does not count as a use
+	assert.True(t, m.Has("c"))
+	assert.Equal(t, 1, m.Frequency("c"))
+
+	evicted, ok := m.Push("d", 4)
+	assert.True(t, ok)
+	assert.Equal(t, Pair[string, int]{"c", 3}, evicted)
+
+	// ties go to the least recently used
+	evicted, ok = m.Push("e", 5)
+	assert.True(t, ok)
+	assert.Equal(t, Pair[string, int]{"d", 4}, evicted)
+
+	// setting an existing key does not evict
+	_, ok = m.Push("e", 6)
+	assert.False(t, ok)
+	assert.Equal(t, 2, m.Frequency("e"))
+
+	assert.Equal(t, 6, m.Delete("e"))
+	assert.Equal(t, 0, m.Delete("e"))
+	assert.Equal(t, 2, m.Len())
+	m.Set("f", 7)
+	_, ok = m.Push("g", 8)
+	assert.True(t, ok)
+	assert.False(t, m.Has("f"))
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	assert.Equal(t, m.Frequency("a"), m2.Frequency("a"))
+	m2.Get("a")
+	assert.Equal(t, m.Frequency("a")+1, m2.Frequency("a"))
+
+	m.DeleteFunc(func(k string, v int) bool {
+		return k == "a"
+	})
+	assert.False(t, m.Has("a"))
+	assert.Equal(t, 2, m.Len())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 3, m.Cap())
+	m.Set("a", 1)
+	assert.Equal(t, 1, m.Frequency("a"))
+}
+
+func TestLFUMap_BinaryMarshal(t *testing.T) {
+	m := NewLFUMap[string, int](2)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Get("a")
+	data, err := m.MarshalBinary()
+	assert.NoError(t, err)
+
+	var m2 LFUMap[string, int]
+	assert.NoError(t, m2.UnmarshalBinary(data))
+	assert.Equal(t, 2, m2.Cap())
+	assert.Equal(t, 2, m2.Frequency("a"))
+	assert.Equal(t, 1, m2.Frequency("b"))
+	evicted, _ := m2.Push("c", 3)
+	assert.Equal(t, "b", evicted.Key)
+
+	var m3 LFUMap[string, int]
+	data, err = m3.MarshalBinary()
+	assert.NoError(t, err)
+	var m4 LFUMap[string, int]
+	assert.NoError(t, m4.UnmarshalBinary(data))
+	assert.Equal(t, 0, m4.Cap())
+	assert.Equal(t, 0, m4.Len())
+	assert.Equal(t, 0, m4.Clone().Cap())
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, 1<<60, []string{"a"}, []int{1}, []int{1})))
+
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, 0, []string{"a"}, []int{1}, []int{1})))
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, 1, []string{"a", "b"}, []int{1, 2}, []int{1, 1})))
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, 3, []string{"a", "b"}, []int{1, 2}, []int{1})))
+}
+
+func TestLFUMap_Nil(t *testing.T) {
+	var m *LFUMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Cap())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Keys())
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "", m.String())
+	assert.NotPanics(t, func() {
+		m.Clear()
+	})
+}
+
+func TestLFUMap_OnEvict(t *testing.T) {
+	m := NewLFUMap[string, int](2)
+	var evicted []string
+	m.OnEvict(func(k string, v int, reason EvictReason) {
+		evicted = append(evicted, fmt.Sprintf("%s%d %s", k, v, reason))
+	})
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("b", 3) // replacing a value is not an eviction
+	m.Set("c", 4)
+	m.Delete("b")
+	m.Delete("z")
+	m.Set("d", 5)
+	m.DeleteFunc(func(k string, _ int) bool {
+		return k == "d"
+	})
+	m.Clear()
+	assert.Equal(t, []string{"a1 capacity", "b3 delete", "d5 delete", "c4 delete"}, evicted)
+
+	evicted = nil
+	m.OnEvict(nil)
+	m.Set("a", 1)
+	m.Delete("a")
+	assert.Nil(t, evicted)
+}
+
+func TestLFUMap_Stats(t *testing.T) {
+	m := NewLFUMap[string, int](2)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Get("a")
+	m.Get("a")
+	m.Get("z")
+	m.Load("b")
+	m.Has("z") // not counted
+	m.Set("c", 3)
+	m.Delete("c") // not an eviction
+	assert.Equal(t, CacheStats{Hits: 3, Misses: 1, Evictions: 1, Size: 1}, m.Stats())
+
+	m.ResetStats()
+	assert.Equal(t, CacheStats{Size: 1}, m.Stats())
+
+	var m2 *LFUMap[string, int]
+	assert.Equal(t, CacheStats{}, m2.Stats())
+}
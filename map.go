@@ -1,6 +1,7 @@
 package maps
 
 import (
+	"hash/maphash"
 	"iter"
 )
 
@@ -16,18 +17,42 @@ import (
 // This will allow you to swap in a different kind of Map just by changing the type.
 type Map[K comparable, V any] struct {
 	items StdMap[K, V]
+	keyF  func(K) K
+	eqF   func(a, b V) bool
 }
 
 // NewMap creates a new map that maps values of type K to values of type V.
 // Pass in zero or more standard maps and the contents of those maps will be copied to the new Map.
 func NewMap[K comparable, V any](sources ...map[K]V) *Map[K, V] {
 	m := new(Map[K, V])
+	if size := sourcesLen(sources); size > 0 {
+		m.items = make(StdMap[K, V], size)
+	}
 	for _, i := range sources {
 		m.Copy(Cast(i))
 	}
 	return m
 }
 
+// SetKeyFunc sets a function that changes every key given to the map before it is used,
+// so that keys can be put in a canonical form, like trimming spaces or changing them to lower case.
+// The function is used by every method that receives a key, and keys already in the map are changed too.
+// If more than one key changes to the same key, which value is kept is not determinate.
+//
+// To stop changing keys, set the function to nil. The function is not serialized.
+func (m *Map[K, V]) SetKeyFunc(f func(K) K) {
+	m.keyF = f
+	m.items = applyKeyFuncToItems(f, m.items)
+}
+
+// SetEqualFunc sets a function that Equal uses to compare values, instead of comparing them with == or
+// their Equaler interface. Use it when the values are types you do not own that are not comparable,
+// or when values should be compared loosely. To go back to the default comparison, set the function to nil.
+// The function is not serialized.
+func (m *Map[K, V]) SetEqualFunc(eq func(a, b V) bool) {
+	m.eqF = eq
+}
+
 // Clear resets the map to an empty map
 func (m *Map[K, V]) Clear() {
 	m.items = nil
@@ -49,22 +74,48 @@ func (m *Map[K, V]) Range(f func(k K, v V) bool) {
 // Load returns the value based on its key, and a boolean indicating whether it exists in the map.
 // This is the same interface as sync.Map.Load()
 func (m *Map[K, V]) Load(k K) (V, bool) {
-	return m.items.Load(k)
+	return m.items.Load(applyKeyFunc(m.keyF, k))
 }
 
 // Get returns the value for the given key. If the key does not exist, the zero value will be returned.
 func (m *Map[K, V]) Get(k K) V {
-	return m.items.Get(k)
+	return m.items.Get(applyKeyFunc(m.keyF, k))
 }
 
 // Has returns true if the key exists.
 func (m *Map[K, V]) Has(k K) bool {
-	return m.items.Has(k)
+	return m.items.Has(applyKeyFunc(m.keyF, k))
 }
 
-// Delete removes the key from the map. If the key does not exist, nothing happens.
-func (m Map[K, V]) Delete(k K) V {
-	return m.items.Delete(k)
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *Map[K, V]) Delete(k K) V {
+	return m.items.Delete(applyKeyFunc(m.keyF, k))
+}
+
+// GetOrSet returns the value of the key if it exists. Otherwise, it sets the key to v and returns v.
+func (m *Map[K, V]) GetOrSet(k K, v V) V {
+	if v2, ok := m.Load(k); ok {
+		return v2
+	}
+	m.Set(k, v)
+	return v
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+func (m *Map[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	k = applyKeyFunc(m.keyF, k)
+	if m.items == nil {
+		m.items = map[K]V{k: v}
+		return
+	}
+	return m.items.Swap(k, v)
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m *Map[K, V]) LoadAndDelete(k K) (V, bool) {
+	return m.items.LoadAndDelete(applyKeyFunc(m.keyF, k))
 }
 
 // Keys returns a new slice containing the keys of the map.
@@ -79,6 +130,7 @@ func (m *Map[K, V]) Values() []V {
 
 // Set sets the key to the given value.
 func (m *Map[K, V]) Set(k K, v V) {
+	k = applyKeyFunc(m.keyF, k)
 	if m.items == nil {
 		m.items = map[K]V{k: v}
 	} else {
@@ -86,6 +138,35 @@ func (m *Map[K, V]) Set(k K, v V) {
 	}
 }
 
+// SetMany sets all the given key and value pairs.
+func (m *Map[K, V]) SetMany(pairs ...Pair[K, V]) {
+	if m.items == nil {
+		m.items = make(map[K]V, len(pairs))
+	}
+	m.items.SetMany(applyKeyFuncToPairs(m.keyF, pairs)...)
+}
+
+// GetMany returns the values for the given keys, in the same order as the keys.
+// The zero value is returned for keys that do not exist.
+func (m *Map[K, V]) GetMany(keys ...K) []V {
+	return m.items.GetMany(applyKeyFuncToKeys(m.keyF, keys)...)
+}
+
+// GetMultiple returns a new Map with the items of the given keys. Keys that do not exist are skipped.
+// The new map has the same key function as the map.
+func (m *Map[K, V]) GetMultiple(keys ...K) *Map[K, V] {
+	m2 := new(Map[K, V])
+	m2.items = m.items.GetMultiple(applyKeyFuncToKeys(m.keyF, keys)...)
+	m2.keyF = m.keyF
+	m2.eqF = m.eqF
+	return m2
+}
+
+// DeleteMany removes all the given keys from the map, and returns the number of keys that were in the map.
+func (m *Map[K, V]) DeleteMany(keys ...K) int {
+	return m.items.DeleteMany(applyKeyFuncToKeys(m.keyF, keys)...)
+}
+
 // Merge copies the items from in to the map, overwriting any conflicting keys.
 // Deprecated: Call Copy instead.
 func (m *Map[K, V]) Merge(in MapI[K, V]) {
@@ -97,7 +178,17 @@ func (m *Map[K, V]) Copy(in MapI[K, V]) {
 	if m.items == nil {
 		m.items = make(map[K]V, in.Len())
 	}
-	m.items.Copy(in)
+	copyWithKeyFunc(m.keyF, m.items, in)
+}
+
+// CopyFunc copies the keys and values of in into this map.
+// When a key is in both maps, resolve is called with the key, the existing value and the incoming value,
+// and the value it returns is stored. Use it to combine values, like adding counts. If resolve is nil, CopyFunc works like Copy.
+func (m *Map[K, V]) CopyFunc(in MapI[K, V], resolve func(k K, existing, incoming V) V) {
+	if m.items == nil {
+		m.items = make(map[K]V, in.Len())
+	}
+	copyFuncWithKeyFunc(m.keyF, m.items, in, resolve)
 }
 
 // Equal returns true if all the keys and values are equal.
@@ -105,7 +196,7 @@ func (m *Map[K, V]) Copy(in MapI[K, V]) {
 // If the values are not comparable, you should implement the Equaler interface on the values.
 // Otherwise, you will get a runtime panic.
 func (m *Map[K, V]) Equal(m2 MapI[K, V]) bool {
-	return m.items.Equal(m2)
+	return equalWithKeyFunc(m.keyF, m.eqF, m.items, m2)
 }
 
 // MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
@@ -121,7 +212,10 @@ func (m *Map[K, V]) MarshalBinary() ([]byte, error) {
 //	  gob.Register(new(Map[keytype,valuetype]))
 //	}
 func (m *Map[K, V]) UnmarshalBinary(data []byte) (err error) {
-	return m.items.UnmarshalBinary(data)
+	if err = m.items.UnmarshalBinary(data); err == nil {
+		m.items = applyKeyFuncToItems(m.keyF, m.items)
+	}
+	return
 }
 
 // MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
@@ -132,7 +226,10 @@ func (m *Map[K, V]) MarshalJSON() (out []byte, err error) {
 // UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a Map.
 // The JSON must start with an object.
 func (m *Map[K, V]) UnmarshalJSON(in []byte) (err error) {
-	return m.items.UnmarshalJSON(in)
+	if err = m.items.UnmarshalJSON(in); err == nil {
+		m.items = applyKeyFuncToItems(m.keyF, m.items)
+	}
+	return
 }
 
 // String returns the map as a string.
@@ -162,7 +259,9 @@ func (m *Map[K, V]) Insert(seq iter.Seq2[K, V]) {
 		m.items = map[K]V{}
 	}
 
-	m.items.Insert(seq)
+	for k, v := range seq {
+		m.items[applyKeyFunc(m.keyF, k)] = v
+	}
 }
 
 // CollectMap collects key-value pairs from seq into a new Map
@@ -173,11 +272,19 @@ func CollectMap[K comparable, V any](seq iter.Seq2[K, V]) *Map[K, V] {
 	return m
 }
 
-// Clone returns a copy of the Map. This is a shallow clone:
+// AsReadOnly returns a read-only view of the map, whose methods that would change the map panic with ErrReadOnly.
+// Changes made to the map through other references are seen by the view.
+func (m *Map[K, V]) AsReadOnly() *ReadOnlyMap[K, V] {
+	return NewReadOnlyMap[K, V](m)
+}
+
+// Clone returns a copy of the Map, including its key function. This is a shallow clone:
 // the new keys and values are set using ordinary assignment.
 func (m *Map[K, V]) Clone() *Map[K, V] {
 	m1 := new(Map[K, V])
 	m1.items = m.items.Clone()
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
 	return m1
 }
 
@@ -185,3 +292,33 @@ func (m *Map[K, V]) Clone() *Map[K, V] {
 func (m *Map[K, V]) DeleteFunc(del func(K, V) bool) {
 	m.items.DeleteFunc(del)
 }
+
+// Filter returns a new Map with the items of the map for which keep returns true.
+// The new map has the same key function as the map, and the map is not changed.
+func (m *Map[K, V]) Filter(keep func(K, V) bool) *Map[K, V] {
+	m1 := new(Map[K, V])
+	m1.items = m.items.Filter(keep)
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	return m1
+}
+
+// Hash returns a hash of the keys and values of the map, made with seed. Maps with the same keys and with values
+// that are equal under == have the same hash. Values are not compared with an Equaler or a function given to
+// SetEqualFunc, so maps that are Equal because of those may have different hashes.
+// Use it to detect changes, or to use the contents of the map as a key, without serializing the map.
+// The values are hashed as interfaces, so Hash panics if a value is not comparable.
+func (m *Map[K, V]) Hash(seed maphash.Seed) uint64 {
+	return m.items.Hash(seed)
+}
+
+// CloneDeep returns a copy of the map in which each value that implements Cloner is copied by calling its
+// Clone method. Values that are Map types are copied with CloneDeep, so maps of maps are copied at every level.
+// Other values, including slices, are copied using ordinary assignment.
+func (m *Map[K, V]) CloneDeep() *Map[K, V] {
+	m1 := new(Map[K, V])
+	m1.items = m.items.CloneDeep()
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	return m1
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/gob"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -36,3 +38,71 @@ func TestMap_Clone(t *testing.T) {
 	m3 := m2.Clone()
 	assert.True(t, m1.Equal(m3))
 }
+
+func ExampleMap_SetKeyFunc() {
+	m := new(Map[string, int])
+	m.SetKeyFunc(strings.TrimSpace)
+	m.Set(" a ", 1)
+	fmt.Println(m.Get("a"), m.Has("a  "))
+	// Output: 1 true
+}
+
+func TestMap_SetKeyFunc(t *testing.T) {
+	m := NewMap(map[string]int{"A": 1, " b": 2})
+	m.SetKeyFunc(func(k string) string {
+		return strings.ToLower(strings.TrimSpace(k))
+	})
+	assert.ElementsMatch(t, []string{"a", "b"}, m.Keys(), "existing keys are changed")
+	assert.Equal(t, 1, m.Get(" A"))
+	m.SetMany(Pair[string, int]{"C", 3})
+	assert.Equal(t, []int{2, 3}, m.GetMany("B", "c "))
+	assert.True(t, m.Equal(mapT{"A": 1, "b": 2, "c": 3}))
+	m.Copy(mapT{"D": 4})
+	m.Insert(mapT{"E": 5}.All())
+	assert.True(t, m.Has("d"))
+	assert.True(t, m.Has("e"))
+	assert.Equal(t, 2, m.DeleteMany("D", "E"))
+	assert.Equal(t, 3, m.Delete("C"))
+	assert.Equal(t, 2, m.Len())
+
+	assert.NoError(t, m.UnmarshalJSON([]byte(`{"X":1}`)))
+	assert.Equal(t, []string{"x"}, m.Keys())
+
+	m2 := m.Clone()
+	m2.Set("Y", 2)
+	assert.True(t, m2.Has("y"))
+
+	m.SetKeyFunc(nil)
+	m.Set("Z", 3)
+	assert.False(t, m.Has("z"))
+}
+
+// TestMap_Parity makes sure Map has every method of StdMap, so that one can be swapped for the other.
+func TestMap_Parity(t *testing.T) {
+	mt := reflect.TypeOf(new(Map[string, int]))
+	st := reflect.TypeOf(StdMap[string, int]{})
+	for i := range st.NumMethod() {
+		name := st.Method(i).Name
+		_, ok := mt.MethodByName(name)
+		assert.True(t, ok, "Map is missing %s", name)
+	}
+	// Map's own methods should all be on the pointer
+	assert.Equal(t, 0, reflect.TypeOf(Map[string, int]{}).NumMethod())
+}
+
+func TestMap_Delete(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1})
+	assert.Equal(t, 1, m.Delete("a"))
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestNewMap(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3, "c": 4})
+	assert.True(t, m.Equal(StdMap[string, int]{"a": 1, "b": 3, "c": 4}))
+	assert.Equal(t, 0, NewMap[string, int]().Len())
+	m = NewMap[string, int](nil, map[string]int{})
+	assert.Equal(t, 0, m.Len())
+	m.Set("a", 1)
+	assert.Equal(t, 1, m.Get("a"))
+}
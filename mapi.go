@@ -1,26 +1,43 @@
 package maps
 
-import "iter"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrDuplicateKey is returned by IndexBy when more than one item has the same key.
+var ErrDuplicateKey = errors.New("maps: duplicate key")
 
 // MapI is the interface used by all the Map types.
 type MapI[K comparable, V any] interface {
+	MapReader[K, V]
 	Setter[K, V]
+	Clear()
+	// Merge copies the items of the given map into the map.
+	// Deprecated: Use Copy instead. Merge will be removed from MapI in a future release.
+	Merge(MapI[K, V])
+	// Copy copies the items of the given map into the map, overwriting the values of keys that are in both.
+	Copy(MapI[K, V])
+	Delete(k K) V
+	Insert(seq iter.Seq2[K, V])
+	DeleteFunc(del func(K, V) bool)
+}
+
+// MapReader is the part of MapI that reads a map without changing it.
+type MapReader[K comparable, V any] interface {
 	Getter[K, V]
 	Loader[K, V]
-	Clear()
 	Len() int
 	Range(func(k K, v V) bool)
 	Has(k K) bool
 	Keys() []K
 	Values() []V
-	Merge(MapI[K, V])
 	Equal(MapI[K, V]) bool
-	Delete(k K) V
 	All() iter.Seq2[K, V]
 	KeysIter() iter.Seq[K]
 	ValuesIter() iter.Seq[V]
-	Insert(seq iter.Seq2[K, V])
-	DeleteFunc(del func(K, V) bool)
 	String() string
 }
 
@@ -39,17 +56,55 @@ type Loader[K comparable, V any] interface {
 	Load(k K) (v V, ok bool)
 }
 
+// Keys returns an iterator over the keys of m. It mirrors the Keys function of the standard maps package.
+func Keys[K comparable, V any](m MapReader[K, V]) iter.Seq[K] {
+	return m.KeysIter()
+}
+
+// Values returns an iterator over the values of m. It mirrors the Values function of the standard maps package.
+func Values[K comparable, V any](m MapReader[K, V]) iter.Seq[V] {
+	return m.ValuesIter()
+}
+
+// Copy sets all the keys and values of src in dst, overwriting the values of keys that are in both.
+// It mirrors the Copy function of the standard maps package.
+//
+// The items of src are gathered before they are set, so src and dst may be the same "safe" map.
+func Copy[K comparable, V any](dst MapI[K, V], src MapReader[K, V]) {
+	var pairs []Pair[K, V]
+	src.Range(func(k K, v V) bool {
+		pairs = append(pairs, Pair[K, V]{k, v})
+		return true
+	})
+	for _, p := range pairs {
+		dst.Set(p.Key, p.Value)
+	}
+}
+
+// DeleteFunc deletes any key/value pairs of m for which del returns true.
+// It mirrors the DeleteFunc function of the standard maps package.
+func DeleteFunc[K comparable, V any](m MapI[K, V], del func(K, V) bool) {
+	m.DeleteFunc(del)
+}
+
+// Equal returns true if m1 and m2 have the same keys, and the values of those keys are equal using ==.
+// It mirrors the Equal function of the standard maps package.
+// If one of the maps is a "safe" map, its more efficient to pass that map as m2.
+func Equal[K, V comparable](m1, m2 MapReader[K, V]) bool {
+	return EqualFunc[K, V, V](m1, m2, func(v1, v2 V) bool { return v1 == v2 })
+}
+
 // EqualFunc returns true if all the keys and values of the m1 and m2 are equal.
 //
 // The function eq is called on the values to determine equality. Keys are compared using ==.
 // If one of the maps is a "safe" map, its more efficient to pass that map as m2.
-func EqualFunc[K comparable, V1, V2 any](m1 MapI[K, V1], m2 MapI[K, V2], eq func(V1, V2) bool) bool {
+func EqualFunc[K comparable, V1, V2 any](m1 MapReader[K, V1], m2 MapReader[K, V2], eq func(V1, V2) bool) bool {
 	if m1.Len() != m2.Len() {
 		return false
 	}
 	ret := true
 	m2.Range(func(k K, v V2) bool {
-		if !m1.Has(k) || !eq(m1.Get(k), v) {
+		if v1, ok := m1.Load(k); !ok || !eq(v1, v) {
 			ret = false
 			return false
 		}
@@ -57,3 +112,218 @@ func EqualFunc[K comparable, V1, V2 any](m1 MapI[K, V1], m2 MapI[K, V2], eq func
 	})
 	return ret
 }
+
+// TransformValues sets the items of src in dst, with each value changed by calling f with its key and value,
+// and returns dst. Choose the kind of map to return by passing it as dst, as in:
+//
+//	m := TransformValues(NewSliceMap[string, *User](), dtos, toUser)
+//
+// The items of src are gathered before they are set, so f may call methods of dst.
+func TransformValues[K comparable, V, V2 any, M MapI[K, V2]](dst M, src MapReader[K, V], f func(K, V) V2) M {
+	var pairs []Pair[K, V2]
+	src.Range(func(k K, v V) bool {
+		pairs = append(pairs, Pair[K, V2]{k, f(k, v)})
+		return true
+	})
+	for _, p := range pairs {
+		dst.Set(p.Key, p.Value)
+	}
+	return dst
+}
+
+// TransformKeys sets the items of src in dst, with each key changed by calling f with the key and its value,
+// and returns dst. Choose the kind of map to return by passing it as dst.
+//
+// When an item gets a key that is already in dst, either from an earlier item or because dst was not empty,
+// resolve is called with the key, the value in dst and the incoming value, and the value it returns is stored.
+// If resolve is nil, the incoming value is stored. Items are ranged in the order of src, so to get
+// predictable results from collisions, use an ordered map as src.
+func TransformKeys[K, K2 comparable, V any, M MapI[K2, V]](dst M, src MapReader[K, V], f func(K, V) K2, resolve func(k K2, existing, incoming V) V) M {
+	var pairs []Pair[K2, V]
+	src.Range(func(k K, v V) bool {
+		pairs = append(pairs, Pair[K2, V]{f(k, v), v})
+		return true
+	})
+	for _, p := range pairs {
+		if resolve != nil {
+			if existing, ok := dst.Load(p.Key); ok {
+				p.Value = resolve(p.Key, existing, p.Value)
+			}
+		}
+		dst.Set(p.Key, p.Value)
+	}
+	return dst
+}
+
+// Reduce calls f with an accumulator and each key and value of m, and returns the final accumulator.
+// The accumulator starts as init, and is replaced by the value f returns. Items are ranged in the order of m.
+func Reduce[K comparable, V, A any](m MapReader[K, V], init A, f func(A, K, V) A) A {
+	acc := init
+	m.Range(func(k K, v V) bool {
+		acc = f(acc, k, v)
+		return true
+	})
+	return acc
+}
+
+// Zip sets each key of keys to the value at the same position in values in dst, and returns dst.
+// Choose the kind of map to return by passing it as dst. Keys are set in order, so a repeated key gets its last value.
+// If keys and values have different lengths, nothing is set and an error is returned.
+func Zip[K comparable, V any, M MapI[K, V]](dst M, keys []K, values []V) (M, error) {
+	if len(keys) != len(values) {
+		return dst, fmt.Errorf("maps: cannot zip %d keys with %d values", len(keys), len(values))
+	}
+	for i, k := range keys {
+		dst.Set(k, values[i])
+	}
+	return dst, nil
+}
+
+// Unzip returns the keys and values of m as two slices, in the order of m.
+// The value at each position of values belongs to the key at the same position of keys.
+func Unzip[K comparable, V any](m MapReader[K, V]) (keys []K, values []V) {
+	m.Range(func(k K, v V) bool {
+		keys = append(keys, k)
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// IndexBy sets each item of items in dst under the key that keyFn returns for it, and returns dst.
+// Choose the kind of map to return by passing it as dst, as in:
+//
+//	users, err := IndexBy(NewSliceMap[int, User](), rows, func(u User) int { return u.ID })
+//
+// If two items have the same key, or an item has a key that is already in dst, nothing is set and
+// an error that wraps ErrDuplicateKey is returned. To combine such items instead, use IndexByFunc.
+func IndexBy[T any, K comparable, M MapI[K, T]](dst M, items []T, keyFn func(T) K) (M, error) {
+	keys := make([]K, len(items))
+	seen := make(StdMap[K, struct{}], len(items))
+	for i, item := range items {
+		k := keyFn(item)
+		if seen.Has(k) || dst.Has(k) {
+			return dst, fmt.Errorf("%w: %v", ErrDuplicateKey, k)
+		}
+		seen[k] = struct{}{}
+		keys[i] = k
+	}
+	for i, k := range keys {
+		dst.Set(k, items[i])
+	}
+	return dst, nil
+}
+
+// IndexByFunc is like IndexBy, but calls resolve when an item has a key that is already in dst.
+// The value returned by resolve is stored. If resolve is nil, the later item is stored.
+func IndexByFunc[T any, K comparable, M MapI[K, T]](dst M, items []T, keyFn func(T) K, resolve func(k K, existing, incoming T) T) M {
+	for _, item := range items {
+		k := keyFn(item)
+		if resolve != nil {
+			if existing, ok := dst.Load(k); ok {
+				item = resolve(k, existing, item)
+			}
+		}
+		dst.Set(k, item)
+	}
+	return dst
+}
+
+// MinKey returns the item of m with the smallest key. If m is empty, ok will be false.
+func MinKey[K cmp.Ordered, V any](m MapReader[K, V]) (k K, v V, ok bool) {
+	return extreme(m, func(k1, k2 K, _, _ V) bool { return cmp.Less(k1, k2) })
+}
+
+// MaxKey returns the item of m with the largest key. If m is empty, ok will be false.
+func MaxKey[K cmp.Ordered, V any](m MapReader[K, V]) (k K, v V, ok bool) {
+	return extreme(m, func(k1, k2 K, _, _ V) bool { return cmp.Less(k2, k1) })
+}
+
+// MinValueBy returns the item of m with the smallest value, using less to compare the values.
+// If more than one item has the smallest value, the first one in the order of m is returned.
+// If m is empty, ok will be false.
+func MinValueBy[K comparable, V any](m MapReader[K, V], less func(a, b V) bool) (k K, v V, ok bool) {
+	return extreme(m, func(_, _ K, v1, v2 V) bool { return less(v1, v2) })
+}
+
+// MaxValueBy returns the item of m with the largest value, using less to compare the values.
+// If more than one item has the largest value, the first one in the order of m is returned.
+// If m is empty, ok will be false.
+func MaxValueBy[K comparable, V any](m MapReader[K, V], less func(a, b V) bool) (k K, v V, ok bool) {
+	return extreme(m, func(_, _ K, v1, v2 V) bool { return less(v2, v1) })
+}
+
+// extreme returns the first item of m for which no other item is better.
+func extreme[K comparable, V any](m MapReader[K, V], better func(k1, k2 K, v1, v2 V) bool) (k K, v V, ok bool) {
+	m.Range(func(k2 K, v2 V) bool {
+		if !ok || better(k2, k, v2, v) {
+			k, v, ok = k2, v2, true
+		}
+		return true
+	})
+	return
+}
+
+// Number is a constraint for the numeric types that SumValues and AvgValues work with.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// SumValues returns the sum of the values of m.
+// The values are ranged once, so for a "safe" map the sum is taken while holding the lock once.
+// To combine values in other ways, use Reduce.
+func SumValues[K comparable, V Number](m MapReader[K, V]) V {
+	return Reduce(m, 0, func(sum V, _ K, v V) V { return sum + v })
+}
+
+// AvgValues returns the average of the values of m. If m is empty, zero is returned.
+// The values are ranged once, so for a "safe" map the average is taken while holding the lock once.
+func AvgValues[K comparable, V Number](m MapReader[K, V]) float64 {
+	var sum float64
+	var count int
+	m.Range(func(_ K, v V) bool {
+		sum += float64(v)
+		count++
+		return true
+	})
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// UniqueValues returns the values of m with duplicates removed, in the order of m.
+// A value that appears more than once is placed where it first appears.
+func UniqueValues[K, V comparable](m MapReader[K, V]) (values []V) {
+	seen := make(map[V]struct{})
+	m.Range(func(_ K, v V) bool {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			values = append(values, v)
+		}
+		return true
+	})
+	return
+}
+
+// Compare compares the items of m1 and m2 in order, like slices.Compare. Keys are compared with cmp.Compare,
+// and the values of equal keys with cmpValue. The result is the comparison of the first items that differ,
+// or if one map is a prefix of the other, the shorter map is less.
+//
+// Use it with ordered maps, like SliceMap and SafeSliceMap, to sort them.
+func Compare[K cmp.Ordered, V any](m1, m2 MapReader[K, V], cmpValue func(V, V) int) int {
+	// gather the items first, so that a "safe" map is not locked while the other is ranged
+	k1, v1 := Unzip(m1)
+	k2, v2 := Unzip(m2)
+	for i := range min(len(k1), len(k2)) {
+		if c := cmp.Compare(k1[i], k2[i]); c != 0 {
+			return c
+		}
+		if c := cmpValue(v1[i], v2[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(k1), len(k2))
+}
@@ -2,12 +2,16 @@ package maps
 
 import (
 	"bytes"
+	"cmp"
 	"encoding/gob"
 	"encoding/json"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"iter"
 	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -18,7 +22,7 @@ func makeMapi[M any](sources ...mapT) MapI[string, int] {
 	m = new(M)
 	i := m.(MapI[string, int])
 	for _, s := range sources {
-		i.Merge(s)
+		i.Copy(s)
 	}
 	return i
 }
@@ -26,7 +30,7 @@ func makeMapi[M any](sources ...mapT) MapI[string, int] {
 func runMapiTests[M any](t *testing.T, f makeF) {
 	testClear(t, f)
 	testLen(t, f)
-	testMerge(t, f)
+	testCopy(t, f)
 	testGetHasLoad(t, f)
 	testRange(t, f)
 	testSet(t, f)
@@ -69,7 +73,7 @@ func testLen(t *testing.T, f makeF) {
 	assert.Equal(t, 2, f(mapT{"a": 1, "b": 2}).Len())
 }
 
-func testMerge(t *testing.T, f makeF) {
+func testCopy(t *testing.T, f makeF) {
 	tests := []struct {
 		name     string
 		m1       mapTI
@@ -84,13 +88,18 @@ func testMerge(t *testing.T, f makeF) {
 		{"from cast map", f(mapT{"a": 1}), Cast(map[string]int{"b": 2}), mapT{"a": 1, "b": 2}},
 	}
 	for _, tt := range tests {
-		t.Run("Merge "+tt.name, func(t *testing.T) {
-			tt.m1.Merge(tt.m2)
+		t.Run("Copy "+tt.name, func(t *testing.T) {
+			tt.m1.Copy(tt.m2)
 			if !tt.m1.Equal(tt.expected) {
-				t.Errorf("Merge error. Expected: %q, got %q", tt.expected, tt.m1)
+				t.Errorf("Copy error. Expected: %q, got %q", tt.expected, tt.m1)
 			}
 		})
 	}
+	t.Run("Merge", func(t *testing.T) {
+		m := f(mapT{"a": 1})
+		m.Merge(mapT{"b": 2})
+		assert.True(t, m.Equal(mapT{"a": 1, "b": 2}))
+	})
 }
 
 func testGetHasLoad(t *testing.T, f makeF) {
@@ -401,3 +410,575 @@ func isEqual(i int, s string) bool {
 	i2, _ := strconv.Atoi(s)
 	return i == i2
 }
+
+func TestSetGetDeleteMany(t *testing.T) {
+	type manyI interface {
+		MapI[string, int]
+		SetMany(pairs ...Pair[string, int])
+		GetMany(keys ...string) []int
+		DeleteMany(keys ...string) int
+	}
+	tests := []struct {
+		name string
+		m    manyI
+	}{
+		{"StdMap", StdMap[string, int]{}},
+		{"Map", new(Map[string, int])},
+		{"SafeMap", new(SafeMap[string, int])},
+		{"SliceMap", new(SliceMap[string, int])},
+		{"SafeSliceMap", new(SafeSliceMap[string, int])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m
+			assert.Equal(t, []int{0, 0}, m.GetMany("a", "b"))
+			assert.Equal(t, 0, m.DeleteMany("a"))
+
+			m.SetMany(Pair[string, int]{"b", 2}, Pair[string, int]{"a", 1}, Pair[string, int]{"c", 3})
+			assert.Equal(t, 3, m.Len())
+			assert.Equal(t, []int{1, 0, 3}, m.GetMany("a", "z", "c"))
+			assert.Nil(t, m.GetMany())
+
+			assert.Equal(t, 2, m.DeleteMany("a", "c", "z", "a"))
+			assert.True(t, m.Equal(StdMap[string, int]{"b": 2}))
+			assert.Equal(t, []string{"b"}, m.Keys())
+			assert.Equal(t, 1, m.DeleteMany("b"))
+			assert.Equal(t, 0, m.Len())
+		})
+	}
+}
+
+func TestStdFuncs(t *testing.T) {
+	m := NewSliceMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(Keys[string, int](m)))
+	assert.Equal(t, []int{1, 2}, slices.Collect(Values[string, int](m)))
+
+	s := NewSafeMap[string, int]()
+	Copy[string, int](s, m)
+	assert.True(t, Equal[string, int](s, m))
+	Copy[string, int](s, s)
+	assert.Equal(t, 2, s.Len())
+
+	DeleteFunc[string, int](s, func(k string, v int) bool { return v == 1 })
+	assert.False(t, Equal[string, int](s, m))
+	assert.True(t, Equal[string, int](s, NewStdMap(StdMap[string, int]{"b": 2})))
+	assert.True(t, Equal[string, int](NewMap[string, int](), NewSafeSliceMap[string, int]()))
+}
+
+func TestLoadAndDelete(t *testing.T) {
+	type loadDeleter interface {
+		MapI[string, int]
+		LoadAndDelete(k string) (int, bool)
+	}
+	tests := []struct {
+		name string
+		m    loadDeleter
+	}{
+		{"StdMap", NewStdMap[string, int]()},
+		{"Map", NewMap[string, int]()},
+		{"SafeMap", NewSafeMap[string, int]()},
+		{"SliceMap", NewSliceMap[string, int]()},
+		{"SafeSliceMap", NewSafeSliceMap[string, int]()},
+		{"SyncMap", new(SyncMap[string, int])},
+		{"ReadMostlyMap", NewReadMostlyMap[string, int]()},
+		{"StripedSliceMap", NewStripedSliceMap[string, int](4)},
+		{"COWMap", NewCOWMap[string, int]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m
+			v, ok := m.LoadAndDelete("a")
+			assert.False(t, ok)
+			assert.Zero(t, v)
+
+			m.Set("a", 0)
+			m.Set("b", 2)
+			v, ok = m.LoadAndDelete("a")
+			assert.True(t, ok, "a stored zero value is reported as loaded")
+			assert.Zero(t, v)
+			assert.False(t, m.Has("a"))
+
+			v, ok = m.LoadAndDelete("b")
+			assert.True(t, ok)
+			assert.Equal(t, 2, v)
+			assert.Equal(t, 0, m.Len())
+		})
+	}
+}
+
+func TestSwap(t *testing.T) {
+	type swapper interface {
+		MapI[string, int]
+		Swap(k string, v int) (int, bool)
+	}
+	tests := []struct {
+		name string
+		m    swapper
+	}{
+		{"StdMap", NewStdMap[string, int]()},
+		{"Map", NewMap[string, int]()},
+		{"SafeMap", NewSafeMap[string, int]()},
+		{"SliceMap", NewSliceMap[string, int]()},
+		{"SafeSliceMap", NewSafeSliceMap[string, int]()},
+		{"SyncMap", new(SyncMap[string, int])},
+		{"ReadMostlyMap", NewReadMostlyMap[string, int]()},
+		{"StripedSliceMap", NewStripedSliceMap[string, int](4)},
+		{"COWMap", NewCOWMap[string, int]()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m
+			v, ok := m.Swap("a", 1)
+			assert.False(t, ok)
+			assert.Zero(t, v)
+
+			v, ok = m.Swap("a", 2)
+			assert.True(t, ok)
+			assert.Equal(t, 1, v)
+			assert.Equal(t, 2, m.Get("a"))
+			assert.Equal(t, 1, m.Len())
+		})
+	}
+}
+
+func TestGetOrSet(t *testing.T) {
+	type getOrSetter interface {
+		MapI[string, int]
+		GetOrSet(k string, v int) int
+	}
+	tests := []struct {
+		name string
+		m    getOrSetter
+	}{
+		{"StdMap", NewStdMap[string, int]()},
+		{"Map", NewMap[string, int]()},
+		{"SafeMap", NewSafeMap[string, int]()},
+		{"SliceMap", NewSliceMap[string, int]()},
+		{"SafeSliceMap", NewSafeSliceMap[string, int]()},
+		{"SyncMap", new(SyncMap[string, int])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m
+			assert.Equal(t, 1, m.GetOrSet("a", 1))
+			assert.Equal(t, 1, m.GetOrSet("a", 2))
+			assert.Equal(t, 1, m.Get("a"))
+			m.Set("b", 0)
+			assert.Equal(t, 0, m.GetOrSet("b", 3))
+			assert.Equal(t, 2, m.Len())
+		})
+	}
+}
+
+func TestGetMultiple(t *testing.T) {
+	src := StdMap[string, int]{"a": 1, "b": 2, "c": 3}
+	want := StdMap[string, int]{"a": 1, "c": 3}
+
+	t.Run("StdMap", func(t *testing.T) {
+		m := src.Clone().GetMultiple("c", "a", "z")
+		assert.True(t, m.Equal(want))
+		assert.Equal(t, 0, src.GetMultiple().Len())
+	})
+	t.Run("Map", func(t *testing.T) {
+		m := NewMap(src)
+		m.SetKeyFunc(strings.ToLower)
+		m2 := m.GetMultiple("C", "a", "z")
+		assert.True(t, m2.Equal(want))
+		assert.True(t, m2.Has("A"))
+	})
+	t.Run("SafeMap", func(t *testing.T) {
+		m := NewSafeMap(src).GetMultiple("c", "a", "z")
+		assert.True(t, m.Equal(want))
+		assert.Equal(t, 2, m.Len())
+	})
+	t.Run("SliceMap", func(t *testing.T) {
+		m := NewSliceMap[string, int]()
+		m.Set("c", 3)
+		m.Set("b", 2)
+		m.Set("a", 1)
+		m2 := m.GetMultiple("a", "c", "z")
+		assert.Equal(t, []string{"c", "a"}, m2.Keys())
+		m2.Set("d", 4)
+		assert.False(t, m.Has("d"))
+		assert.Equal(t, 0, (*SliceMap[string, int])(nil).GetMultiple("a").Len())
+	})
+	t.Run("SafeSliceMap", func(t *testing.T) {
+		m := NewSafeSliceMap[string, int]()
+		m.SetSortFunc(func(k1, k2 string, _, _ int) bool { return k1 > k2 })
+		m.Copy(src)
+		m2 := m.GetMultiple("a", "c")
+		assert.Equal(t, []string{"c", "a"}, m2.Keys())
+		m2.Set("b", 2)
+		assert.Equal(t, []string{"c", "b", "a"}, m2.Keys())
+	})
+}
+
+func TestFilter(t *testing.T) {
+	src := StdMap[string, int]{"a": 1, "b": 2, "c": 3, "d": 4}
+	want := StdMap[string, int]{"b": 2, "d": 4}
+	even := func(_ string, v int) bool { return v%2 == 0 }
+
+	t.Run("StdMap", func(t *testing.T) {
+		assert.True(t, src.Filter(even).Equal(want))
+		assert.Equal(t, 4, src.Len())
+	})
+	t.Run("Map", func(t *testing.T) {
+		m := NewMap(src)
+		m.SetKeyFunc(strings.ToLower)
+		m2 := m.Filter(even)
+		assert.True(t, m2.Equal(want))
+		assert.True(t, m2.Has("B"))
+		assert.Equal(t, 4, m.Len())
+	})
+	t.Run("SafeMap", func(t *testing.T) {
+		m := NewSafeMap(src).Filter(even)
+		assert.True(t, m.Equal(want))
+		assert.Equal(t, 2, m.Len())
+	})
+	t.Run("SliceMap", func(t *testing.T) {
+		m := NewSliceMap[string, int]()
+		m.Set("d", 4)
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m2 := m.Filter(even)
+		assert.Equal(t, []string{"d", "b"}, m2.Keys())
+		m2.Set("z", 0)
+		assert.False(t, m.Has("z"))
+		assert.Equal(t, 0, m.Filter(func(string, int) bool { return false }).Len())
+		assert.Equal(t, 0, (*SliceMap[string, int])(nil).Filter(even).Len())
+	})
+	t.Run("SafeSliceMap", func(t *testing.T) {
+		m := NewSafeSliceMap[string, int]()
+		m.SetSortFunc(func(k1, k2 string, _, _ int) bool { return k1 > k2 })
+		m.Copy(src)
+		m2 := m.Filter(even)
+		assert.Equal(t, []string{"d", "b"}, m2.Keys())
+		m2.Set("c", 3)
+		assert.Equal(t, []string{"d", "c", "b"}, m2.Keys())
+	})
+}
+
+func ExampleTransformValues() {
+	prices := NewSliceMap[string, int]()
+	prices.Set("apple", 120)
+	prices.Set("pear", 95)
+	labels := TransformValues(NewSliceMap[string, string](), prices, func(_ string, cents int) string {
+		return fmt.Sprintf("$%d.%02d", cents/100, cents%100)
+	})
+	fmt.Println(labels)
+	// Output: {"apple":"$1.20","pear":"$0.95"}
+}
+
+func TestTransformValues(t *testing.T) {
+	src := StdMap[string, int]{"a": 1, "b": 2}
+	m := TransformValues(NewSafeMap[string, string](), src, func(k string, v int) string {
+		return k + strconv.Itoa(v)
+	})
+	assert.True(t, m.Equal(StdMap[string, string]{"a": "a1", "b": "b2"}))
+
+	// dst may be src
+	m2 := NewSafeMap(src)
+	TransformValues(m2, m2, func(_ string, v int) int { return v * 10 })
+	assert.True(t, m2.Equal(StdMap[string, int]{"a": 10, "b": 20}))
+
+	assert.Equal(t, 0, TransformValues(NewMap[string, int](), NewStdMap[string, int](), func(_ string, v int) int { return v }).Len())
+}
+
+func TestTransformKeys(t *testing.T) {
+	src := NewSliceMap[string, int]()
+	src.Set("a", 1)
+	src.Set("A", 2)
+	src.Set("b", 3)
+	lower := func(k string, _ int) string { return strings.ToLower(k) }
+
+	m := TransformKeys(NewStdMap[string, int](), src, lower, nil)
+	assert.True(t, m.Equal(StdMap[string, int]{"a": 2, "b": 3}), "the last value wins")
+
+	sum := func(_ string, existing, incoming int) int { return existing + incoming }
+	m2 := TransformKeys(NewSliceMap[string, int](), src, lower, sum)
+	assert.Equal(t, []string{"a", "b"}, m2.Keys())
+	assert.Equal(t, []int{3, 3}, m2.Values())
+
+	m3 := TransformKeys(NewMap(map[string]int{"b": 10}), src, lower, sum)
+	assert.True(t, m3.Equal(StdMap[string, int]{"a": 3, "b": 13}), "items already in dst are resolved too")
+}
+
+func ExampleReduce() {
+	stock := NewSliceMap[string, int]()
+	stock.Set("apples", 3)
+	stock.Set("pears", 5)
+	total := Reduce(stock, 0, func(sum int, _ string, n int) int { return sum + n })
+	names := Reduce(stock, "", func(s string, k string, _ int) string { return s + k + ";" })
+	fmt.Println(total, names)
+	// Output: 8 apples;pears;
+}
+
+func TestReduce(t *testing.T) {
+	assert.Equal(t, 5, Reduce[string, int](NewMap[string, int](), 5, func(a int, _ string, v int) int { return a + v }))
+	m := NewSafeMap(map[string]int{"a": 2, "b": 3})
+	assert.Equal(t, 6, Reduce(m, 1, func(a int, _ string, v int) int { return a * v }))
+}
+
+func TestCopyFunc(t *testing.T) {
+	type copyFuncer interface {
+		MapI[string, int]
+		CopyFunc(in MapI[string, int], resolve func(k string, existing, incoming int) int)
+	}
+	tests := []struct {
+		name string
+		m    copyFuncer
+	}{
+		{"StdMap", NewStdMap[string, int]()},
+		{"Map", NewMap[string, int]()},
+		{"SafeMap", NewSafeMap[string, int]()},
+		{"SliceMap", NewSliceMap[string, int]()},
+		{"SafeSliceMap", NewSafeSliceMap[string, int]()},
+	}
+	sum := func(_ string, existing, incoming int) int { return existing + incoming }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m
+			m.CopyFunc(mapT{"a": 1, "b": 2}, sum)
+			assert.True(t, m.Equal(mapT{"a": 1, "b": 2}))
+			m.CopyFunc(mapT{"b": 3, "c": 4}, sum)
+			assert.True(t, m.Equal(mapT{"a": 1, "b": 5, "c": 4}))
+			m.CopyFunc(mapT{"a": 9}, nil)
+			assert.Equal(t, 9, m.Get("a"))
+		})
+	}
+}
+
+func TestCopyFunc_Concurrent(t *testing.T) {
+	sum := func(_ string, existing, incoming int) int { return existing + incoming }
+	maps := []interface {
+		MapI[string, int]
+		CopyFunc(in MapI[string, int], resolve func(k string, existing, incoming int) int)
+	}{NewSafeMap[string, int](), NewSafeSliceMap[string, int]()}
+	for _, m := range maps {
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.CopyFunc(mapT{"a": 1}, sum)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 50, m.Get("a"))
+	}
+}
+
+func ExampleZip() {
+	m, err := Zip(NewSliceMap[string, int](), []string{"a", "b"}, []int{1, 2})
+	fmt.Println(m, err)
+	keys, values := Unzip(m)
+	fmt.Println(keys, values)
+	// Output: {"a":1,"b":2} <nil>
+	// [a b] [1 2]
+}
+
+func TestZip(t *testing.T) {
+	m, err := Zip(NewSafeMap[string, int](), []string{"a", "b", "a"}, []int{1, 2, 3})
+	assert.NoError(t, err)
+	assert.True(t, m.Equal(mapT{"a": 3, "b": 2}))
+
+	m2, err := Zip(NewStdMap[string, int](), []string{"a", "b"}, []int{1})
+	assert.Error(t, err)
+	assert.Equal(t, 0, m2.Len())
+
+	m2, err = Zip[string, int](NewStdMap[string, int](), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, m2.Len())
+}
+
+func TestUnzip(t *testing.T) {
+	keys, values := Unzip(NewStdMap(mapT{"a": 1, "b": 2, "c": 3}))
+	assert.Len(t, keys, 3)
+	for i, k := range keys {
+		assert.Equal(t, int(k[0]-'a'+1), values[i])
+	}
+	keys, values = Unzip[string, int](NewMap[string, int]())
+	assert.Nil(t, keys)
+	assert.Nil(t, values)
+}
+
+func TestIndexBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	users := []user{{2, "b"}, {1, "a"}}
+	id := func(u user) int { return u.ID }
+
+	m, err := IndexBy(NewSliceMap[int, user](), users, id)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, m.Keys())
+	assert.Equal(t, "a", m.Get(1).Name)
+
+	_, err = IndexBy(m, []user{{3, "c"}, {1, "z"}}, id)
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+	assert.Equal(t, 2, m.Len(), "nothing is set when there is a duplicate")
+
+	_, err = IndexBy(NewMap[int, user](), []user{{3, "c"}, {3, "d"}}, id)
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+
+	m2 := IndexByFunc(NewStdMap[int, user](), []user{{3, "c"}, {3, "d"}}, id, nil)
+	assert.Equal(t, "d", m2.Get(3).Name)
+	m2 = IndexByFunc(m2, []user{{3, "e"}}, id, func(_ int, existing, incoming user) user {
+		return user{existing.ID, existing.Name + incoming.Name}
+	})
+	assert.Equal(t, "de", m2.Get(3).Name)
+}
+
+func TestMinMax(t *testing.T) {
+	m := NewSliceMap[string, int]()
+	m.Set("b", 3)
+	m.Set("c", 1)
+	m.Set("a", 3)
+	m.Set("d", 1)
+
+	k, v, ok := MinKey(m)
+	assert.True(t, ok)
+	assert.Equal(t, "a", k)
+	assert.Equal(t, 3, v)
+	k, v, ok = MaxKey(m)
+	assert.True(t, ok)
+	assert.Equal(t, "d", k)
+	assert.Equal(t, 1, v)
+
+	less := func(a, b int) bool { return a < b }
+	k, v, ok = MinValueBy(m, less)
+	assert.True(t, ok)
+	assert.Equal(t, "c", k, "ties go to the first item")
+	assert.Equal(t, 1, v)
+	k, v, ok = MaxValueBy(m, less)
+	assert.True(t, ok)
+	assert.Equal(t, "b", k)
+	assert.Equal(t, 3, v)
+
+	empty := NewMap[string, int]()
+	_, _, ok = MinKey(empty)
+	assert.False(t, ok)
+	_, _, ok = MaxValueBy(empty, less)
+	assert.False(t, ok)
+}
+
+func TestSumAvgValues(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 1, "b": 2, "c": 4})
+	assert.Equal(t, 7, SumValues(m))
+	assert.InDelta(t, 7.0/3, AvgValues(m), 1e-9)
+
+	type celsius float32
+	temps := NewStdMap(map[string]celsius{"mon": 20.5, "tue": 22.5})
+	assert.Equal(t, celsius(43), SumValues(temps))
+	assert.Equal(t, 21.5, AvgValues(temps))
+
+	empty := NewMap[string, uint8]()
+	assert.Equal(t, uint8(0), SumValues(empty))
+	assert.Equal(t, 0.0, AvgValues(empty))
+}
+
+func TestUniqueValues(t *testing.T) {
+	m := NewSliceMap[string, string]()
+	m.Set("ann", "open")
+	m.Set("bob", "closed")
+	m.Set("cid", "open")
+	m.Set("dee", "pending")
+	assert.Equal(t, []string{"open", "closed", "pending"}, UniqueValues(m))
+	assert.Nil(t, UniqueValues(NewMap[string, string]()))
+}
+
+func TestCompare(t *testing.T) {
+	newSM := func(kv ...any) *SliceMap[string, int] {
+		m := NewSliceMap[string, int]()
+		for i := 0; i < len(kv); i += 2 {
+			m.Set(kv[i].(string), kv[i+1].(int))
+		}
+		return m
+	}
+	a := newSM("a", 1, "b", 2)
+	assert.Equal(t, 0, Compare(a, a.Clone(), cmp.Compare[int]))
+	assert.Equal(t, -1, Compare(a, newSM("a", 1, "c", 0), cmp.Compare[int]))
+	assert.Equal(t, 1, Compare(a, newSM("a", 1, "b", 1), cmp.Compare[int]))
+	assert.Equal(t, 1, Compare(a, newSM("a", 1), cmp.Compare[int]))
+	assert.Equal(t, -1, Compare(newSM(), a, cmp.Compare[int]))
+
+	s := NewSafeSliceMap[string, int]()
+	s.Set("a", 1)
+	s.Set("b", 2)
+	assert.Equal(t, 0, Compare(s, s, cmp.Compare[int]))
+	assert.Equal(t, 0, Compare[string, int](s, a, cmp.Compare[int]))
+}
+
+func TestMapI_SetEqualFunc(t *testing.T) {
+	type equalFuncer interface {
+		MapI[string, []int]
+		SetEqualFunc(eq func(a, b []int) bool)
+	}
+	tests := []struct {
+		name string
+		f    func() equalFuncer
+	}{
+		{"Map", func() equalFuncer { return NewMap[string, []int]() }},
+		{"SafeMap", func() equalFuncer { return NewSafeMap[string, []int]() }},
+		{"SliceMap", func() equalFuncer { return NewSliceMap[string, []int]() }},
+		{"SafeSliceMap", func() equalFuncer { return NewSafeSliceMap[string, []int]() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.f()
+			m.Set("a", []int{1, 2})
+			m2 := NewStdMap(map[string][]int{"a": {1, 2}})
+			assert.Panics(t, func() { m.Equal(m2) })
+
+			m.SetEqualFunc(slices.Equal[[]int])
+			assert.True(t, m.Equal(m2))
+			m2["a"] = []int{1}
+			assert.False(t, m.Equal(m2))
+
+			m.SetEqualFunc(nil)
+			assert.Panics(t, func() { m.Equal(m2) })
+		})
+	}
+}
+
+func TestMapI_SetEqualFuncCopies(t *testing.T) {
+	m := NewSafeMap(map[string][]int{"a": {1}})
+	m.SetEqualFunc(slices.Equal[[]int])
+	assert.True(t, m.CompareAndSwap("a", []int{1}, []int{2}))
+	assert.True(t, m.CompareAndDelete("a", []int{2}))
+
+	m.Set("a", []int{1})
+	other := NewStdMap(map[string][]int{"a": {1}})
+	assert.True(t, m.Clone().Equal(other))
+	assert.True(t, m.Filter(func(string, []int) bool { return true }).Equal(other))
+	assert.True(t, m.GetMultiple("a").Equal(other))
+
+	sm := NewSafeSliceMap(map[string][]int{"a": {1}})
+	sm.SetEqualFunc(slices.Equal[[]int])
+	assert.True(t, sm.Clone().Equal(other))
+	assert.True(t, sm.GetMultiple("a").EqualOrdered(other))
+	assert.True(t, sm.Filter(func(string, []int) bool { return true }).Equal(other))
+
+	dm := NewDefaultMap(func(string) []int { return nil }, map[string][]int{"a": {1}})
+	dm.SetEqualFunc(slices.Equal[[]int])
+	assert.True(t, dm.Clone().Equal(other))
+
+	sdm := NewSafeDefaultMap(func(string) []int { return nil }, map[string][]int{"a": {1}})
+	sdm.SetEqualFunc(slices.Equal[[]int])
+	assert.True(t, sdm.Clone().Equal(other))
+
+	key := new(int)
+	im := NewIdentityMap(map[*int][]int{key: {1}})
+	im.SetEqualFunc(slices.Equal[[]int])
+	assert.True(t, im.Clone().Equal(NewStdMap(map[*int][]int{key: {1}})))
+
+	nm := new(NestedMap[string])
+	nm.Set("a", []int{1})
+	nm.SetEqualFunc(func(a, b any) bool {
+		return slices.Equal(a.([]int), b.([]int))
+	})
+	assert.True(t, nm.Clone().Equal(NewStdMap(map[string]any{"a": []int{1}})))
+}
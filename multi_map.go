@@ -0,0 +1,404 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// MultiMap is a map that can hold more than one value for each key, like HTTP headers or tags.
+//
+// Set adds a value to the values of a key, rather than replacing them. The MapI methods that return
+// a single value, like Get and Load, return the first value of the key. The methods that range over the map,
+// like Range, All and Values, visit every value of every key, so a key with two values is visited twice.
+// Len and Keys count each key only once. Use GetAll to get all the values of a key.
+//
+// The zero value is ready to use. Do not make a copy of a MultiMap using the equality operator (=). Use Clone instead.
+type MultiMap[K comparable, V any] struct {
+	items map[K][]V
+}
+
+// NewMultiMap creates a new MultiMap.
+// Pass in zero or more standard maps and the values of those maps will be added to the new MultiMap.
+func NewMultiMap[K comparable, V any](sources ...map[K]V) *MultiMap[K, V] {
+	m := &MultiMap[K, V]{items: make(map[K][]V, sourcesLen(sources))}
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// Set adds v to the values of the key k.
+func (m *MultiMap[K, V]) Set(k K, v V) {
+	if m.items == nil {
+		m.items = make(map[K][]V)
+	}
+	m.items[k] = append(m.items[k], v)
+}
+
+// SetAll replaces all the values of the key k with values.
+// If values is empty, the key is removed.
+func (m *MultiMap[K, V]) SetAll(k K, values ...V) {
+	if len(values) == 0 {
+		m.Delete(k)
+		return
+	}
+	if m.items == nil {
+		m.items = make(map[K][]V)
+	}
+	m.items[k] = slices.Clone(values)
+}
+
+// Get returns the first value of the key. If the key does not exist, the zero value will be returned.
+func (m *MultiMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Load returns the first value of the key, and a boolean indicating whether the key exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *MultiMap[K, V]) Load(k K) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	var values []V
+	if values, ok = m.items[k]; ok {
+		v = values[0]
+	}
+	return
+}
+
+// GetAll returns a new slice with all the values of the key, in the order they were added.
+// If the key does not exist, nil is returned.
+func (m *MultiMap[K, V]) GetAll(k K) []V {
+	if m == nil {
+		return nil
+	}
+	return slices.Clone(m.items[k])
+}
+
+// CountOf returns the number of values of the key.
+func (m *MultiMap[K, V]) CountOf(k K) int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items[k])
+}
+
+// Has returns true if the key exists.
+func (m *MultiMap[K, V]) Has(k K) (exists bool) {
+	if m == nil {
+		return
+	}
+	_, exists = m.items[k]
+	return
+}
+
+// Delete removes the key and all its values from the map, and returns the first value.
+// If the key does not exist, the zero value will be returned.
+func (m *MultiMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	if values, ok := m.items[k]; ok {
+		v = values[0]
+		delete(m.items, k)
+	}
+	return
+}
+
+// DeleteValue removes every value of the key k that is equal to v, and returns true if any were removed.
+// If no values are left, the key is removed.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *MultiMap[K, V]) DeleteValue(k K, v V) bool {
+	if m == nil {
+		return false
+	}
+	values, ok := m.items[k]
+	if !ok {
+		return false
+	}
+	values2 := slices.DeleteFunc(values, func(v2 V) bool {
+		return equalValues(v, v2)
+	})
+	if len(values2) == len(values) {
+		return false
+	}
+	m.setValues(k, values2)
+	return true
+}
+
+// setValues sets the values of the key, removing the key if there are no values.
+func (m *MultiMap[K, V]) setValues(k K, values []V) {
+	if len(values) == 0 {
+		delete(m.items, k)
+	} else {
+		m.items[k] = values
+	}
+}
+
+// Clear removes all the items in the map.
+func (m *MultiMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.items = nil
+}
+
+// Len returns the number of keys in the map.
+func (m *MultiMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items)
+}
+
+// Range calls the given function for each value of each key in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+// The order of the keys is not determinate, but the values of each key are visited in the order they were added.
+func (m *MultiMap[K, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	for k, values := range m.items {
+		for _, v := range values {
+			if !f(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map. Each key is listed once.
+func (m *MultiMap[K, V]) Keys() (keys []K) {
+	if m.Len() == 0 {
+		return
+	}
+	keys = make([]K, 0, len(m.items))
+	for k := range m.items {
+		keys = append(keys, k)
+	}
+	return
+}
+
+// Values returns a new slice containing all the values of all the keys.
+func (m *MultiMap[K, V]) Values() (values []V) {
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Merge adds the items from in to the map.
+// Deprecated: Call Copy instead.
+func (m *MultiMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy adds every key and value of in to this map. Values are added to the values of existing keys.
+func (m *MultiMap[K, V]) Copy(in MapI[K, V]) {
+	if in == nil {
+		return
+	}
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if the maps have the same keys, and each key has the same values in the same order.
+// A MultiMap can be compared with any other MapI, in which case each key of the MultiMap must have only one value.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *MultiMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m == nil {
+		return equalMulti(nil, m2)
+	}
+	return equalMulti(m.items, m2)
+}
+
+// multiItems returns the values of m grouped by key.
+func multiItems[K comparable, V any](m MapI[K, V]) map[K][]V {
+	switch mm := m.(type) {
+	case *MultiMap[K, V]:
+		if mm == nil {
+			return nil
+		}
+		return mm.items
+	case *SliceMultiMap[K, V]:
+		if mm == nil {
+			return nil
+		}
+		return mm.items.items
+	}
+	items := make(map[K][]V, m.Len())
+	m.Range(func(k K, v V) bool {
+		items[k] = append(items[k], v)
+		return true
+	})
+	return items
+}
+
+// equalMulti returns true if m2 has the same keys as items, with the same values in the same order.
+func equalMulti[K comparable, V any](items map[K][]V, m2 MapI[K, V]) bool {
+	if m2 == nil {
+		return len(items) == 0
+	}
+	if len(items) != m2.Len() {
+		return false
+	}
+	for k, values := range multiItems(m2) {
+		values2, ok := items[k]
+		if !ok || !slices.EqualFunc(values, values2, func(a, b V) bool { return equalValues(a, b) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// String outputs the map as a string, with the values of each key in a list.
+func (m *MultiMap[K, V]) String() string {
+	if m == nil {
+		return ""
+	}
+	s := fmt.Sprintf("%#v", m.items)
+	loc := strings.IndexRune(s, '{')
+	return s[loc:]
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *MultiMap[K, V]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	var items map[K][]V
+	if m != nil {
+		items = m.items
+	}
+	err := enc.Encode(items)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// MultiMap. Keys with no values are not added.
+func (m *MultiMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var items map[K][]V
+
+	b := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(b)
+	if err = dec.Decode(&items); err == nil {
+		for k, values := range items {
+			if len(values) == 0 {
+				delete(items, k)
+			}
+		}
+		m.items = items
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object,
+// where the value of each key is an array of its values.
+func (m *MultiMap[K, V]) MarshalJSON() (out []byte, err error) {
+	if m == nil {
+		return json.Marshal(map[K][]V(nil))
+	}
+	return json.Marshal(m.items)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a MultiMap.
+// The JSON must start with an object, and the value of each key must be an array of values.
+// Keys with empty arrays are not added.
+func (m *MultiMap[K, V]) UnmarshalJSON(in []byte) (err error) {
+	var items map[K][]V
+	if err = json.Unmarshal(in, &items); err == nil {
+		m.items = make(map[K][]V, len(items))
+		for k, values := range items {
+			if len(values) > 0 {
+				m.items[k] = values
+			}
+		}
+	}
+	return
+}
+
+// All returns an iterator over every value of every key in the map.
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over the keys in the map. Each key is visited once.
+func (m *MultiMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		if m == nil {
+			return
+		}
+		for k := range m.items {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesIter returns an iterator over all the values of all the keys in the map.
+func (m *MultiMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Values are added to the values of existing keys.
+func (m *MultiMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// CollectMultiMap collects key-value pairs from seq into a new MultiMap
+// and returns it. Repeated keys keep all their values.
+func CollectMultiMap[K comparable, V any](seq iter.Seq2[K, V]) *MultiMap[K, V] {
+	m := new(MultiMap[K, V])
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the MultiMap. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *MultiMap[K, V]) Clone() *MultiMap[K, V] {
+	m1 := new(MultiMap[K, V])
+	if m == nil || m.items == nil {
+		return m1
+	}
+	m1.items = make(map[K][]V, len(m.items))
+	for k, values := range m.items {
+		m1.items[k] = slices.Clone(values)
+	}
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+// Only the matching values are removed. A key is removed when it has no values left.
+func (m *MultiMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil {
+		return
+	}
+	for k, values := range m.items {
+		m.setValues(k, slices.DeleteFunc(values, func(v V) bool {
+			return del(k, v)
+		}))
+	}
+}
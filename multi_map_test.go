@@ -0,0 +1,177 @@
+package maps
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gob.Register(new(MultiMap[string, int]))
+}
+
+func ExampleMultiMap() {
+	m := new(MultiMap[string, string])
+	m.Set("Accept", "text/html")
+	m.Set("Accept", "application/json")
+	m.Set("Host", "example.com")
+	fmt.Println(m.Get("Accept"))
+	fmt.Println(m.GetAll("Accept"))
+	fmt.Println(m.Len(), m.CountOf("Accept"))
+	// Output: text/html
+	// [text/html application/json]
+	// 2 2
+}
+
+func TestMultiMap(t *testing.T) {
+	var m MultiMap[string, int]
+	assert.Equal(t, 0, m.Get("a"))
+	_, ok := m.Load("a")
+	assert.False(t, ok)
+
+	m.Set("a", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+	m.Set("a", 1)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 3, m.CountOf("a"))
+	assert.Equal(t, 0, m.CountOf("z"))
+	assert.Equal(t, []int{1, 2, 1}, m.GetAll("a"))
+	assert.Nil(t, m.GetAll("z"))
+	v, ok := m.Load("a")
+	assert.Equal(t, 1, v)
+	assert.True(t, ok)
+	assert.True(t, m.Has("b"))
+
+	keys := m.Keys()
+	slices.Sort(keys)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	values := m.Values()
+	slices.Sort(values)
+	assert.Equal(t, []int{1, 1, 2, 3}, values)
+
+	var count int
+	for range m.All() {
+		count++
+	}
+	assert.Equal(t, 4, count)
+	count = 0
+	for range m.KeysIter() {
+		count++
+	}
+	assert.Equal(t, 2, count)
+
+	// GetAll returns a copy
+	all := m.GetAll("a")
+	all[0] = 10
+	assert.Equal(t, 1, m.Get("a"))
+
+	assert.True(t, m.DeleteValue("a", 1))
+	assert.Equal(t, []int{2}, m.GetAll("a"))
+	assert.False(t, m.DeleteValue("a", 5))
+	assert.False(t, m.DeleteValue("z", 5))
+	assert.True(t, m.DeleteValue("a", 2))
+	assert.False(t, m.Has("a"))
+
+	m.SetAll("c", 4, 5)
+	assert.Equal(t, []int{4, 5}, m.GetAll("c"))
+	m.SetAll("c")
+	assert.False(t, m.Has("c"))
+
+	m.Set("b", 4)
+	assert.Equal(t, 3, m.Delete("b"))
+	assert.Equal(t, 0, m.Delete("b"))
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestMultiMap_Equal(t *testing.T) {
+	m := NewMultiMap(map[string]int{"a": 1, "b": 2})
+	assert.True(t, m.Equal(mapT{"a": 1, "b": 2}))
+	assert.False(t, m.Equal(mapT{"a": 1, "b": 3}))
+	assert.False(t, m.Equal(mapT{"a": 1}))
+
+	m.Set("a", 3)
+	assert.False(t, m.Equal(mapT{"a": 1, "b": 2}))
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	m2.Set("a", 4)
+	assert.False(t, m.Equal(m2))
+	assert.Equal(t, 2, m.CountOf("a"))
+
+	m3 := new(MultiMap[string, int])
+	assert.True(t, m3.Equal(nil))
+	assert.True(t, m3.Equal(new(MultiMap[string, int])))
+
+	m4 := CollectMultiMap(func(yield func(string, int) bool) {
+		_ = yield("a", 1) && yield("a", 3) && yield("b", 2)
+	})
+	assert.True(t, m.Equal(m4))
+	m5 := CollectMultiMap(func(yield func(string, int) bool) {
+		_ = yield("a", 3) && yield("a", 1) && yield("b", 2)
+	})
+	assert.False(t, m.Equal(m5), "order of values matters")
+}
+
+func TestMultiMap_Copy(t *testing.T) {
+	m := new(MultiMap[string, int])
+	m.Set("a", 1)
+	m.Copy(mapT{"a": 2, "b": 3})
+	assert.Equal(t, []int{1, 2}, m.GetAll("a"))
+	assert.Equal(t, []int{3}, m.GetAll("b"))
+
+	m.DeleteFunc(func(k string, v int) bool {
+		return v != 2
+	})
+	assert.Equal(t, []int{2}, m.GetAll("a"))
+	assert.False(t, m.Has("b"))
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestMultiMap_Marshal(t *testing.T) {
+	m := new(MultiMap[string, int])
+	m.Set("a", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+
+	s, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[1,2],"b":[3]}`, string(s))
+	assert.Equal(t, `{"a":[]int{1, 2}, "b":[]int{3}}`, m.String())
+
+	var m2 MultiMap[string, int]
+	assert.NoError(t, json.Unmarshal([]byte(`{"a":[1,2],"b":[3],"c":[]}`), &m2))
+	assert.True(t, m.Equal(&m2))
+
+	data, err := m.MarshalBinary()
+	assert.NoError(t, err)
+	var m3 MultiMap[string, int]
+	assert.NoError(t, m3.UnmarshalBinary(data))
+	assert.True(t, m.Equal(&m3))
+
+	assert.NoError(t, m3.UnmarshalBinary(gobEncode(t, map[string][]int{"a": {1}, "c": {}})))
+	assert.False(t, m3.Has("c"))
+	assert.Equal(t, 0, m3.Get("c"))
+	assert.Equal(t, 1, m3.Len())
+}
+
+func TestMultiMap_Nil(t *testing.T) {
+	var m *MultiMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.Nil(t, m.GetAll("a"))
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Keys())
+	assert.Nil(t, m.Values())
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.False(t, m.DeleteValue("a", 1))
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "", m.String())
+	assert.Equal(t, 0, m.Clone().Len())
+}
@@ -0,0 +1,159 @@
+package maps
+
+// NestedMap is a Map whose values can be other maps, like the trees produced by decoding JSON or YAML
+// into a map[string]any. Its path methods walk down through the nested maps one key at a time, so that
+// values deep in the tree can be read and written without a type assertion at every level.
+//
+// A nested map can be a map[K]any, or any MapI[K, any], like a Map, StdMap or another NestedMap.
+// Maps created by SetPath are map[K]any, which is what encoding/json produces.
+//
+// The zero value is ready to use.
+type NestedMap[K comparable] struct {
+	Map[K, any]
+}
+
+// NewNestedMap creates a new NestedMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new NestedMap.
+func NewNestedMap[K comparable](sources ...map[K]any) *NestedMap[K] {
+	m := new(NestedMap[K])
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// GetPath returns the value found by following path through the nested maps.
+// ok is false if a key in the path is missing, or if a value before the end of the path is not a map.
+func (m *NestedMap[K]) GetPath(path ...K) (v any, ok bool) {
+	if len(path) == 0 {
+		return
+	}
+	v, ok = m.Load(path[0])
+	for _, k := range path[1:] {
+		if !ok {
+			return
+		}
+		v, ok = loadNested(v, k)
+	}
+	return
+}
+
+// GetPathAs returns the value found by following path through the nested maps of m, converted to T.
+// ok is false if the value is not found, or if it is not a T.
+func GetPathAs[T any, K comparable](m *NestedMap[K], path ...K) (v T, ok bool) {
+	var i any
+	if i, ok = m.GetPath(path...); ok {
+		v, ok = i.(T)
+	}
+	return
+}
+
+// HasPath returns true if a value exists at the end of path.
+func (m *NestedMap[K]) HasPath(path ...K) (ok bool) {
+	_, ok = m.GetPath(path...)
+	return
+}
+
+// SetPath sets the value at the end of path, creating any missing maps along the way.
+// It returns false and does not change the map if a value before the end of the path exists but is not a map.
+// SetPath panics if path is empty.
+func (m *NestedMap[K]) SetPath(value any, path ...K) bool {
+	if len(path) == 0 {
+		panic("SetPath requires at least one key")
+	}
+	if len(path) == 1 {
+		m.Set(path[0], value)
+		return true
+	}
+	child, ok := m.Load(path[0])
+	if !ok {
+		child = make(map[K]any)
+	}
+	if !setNested(child, value, path[1:]) {
+		return false
+	}
+	if !ok {
+		m.Set(path[0], child)
+	}
+	return true
+}
+
+// DeletePath removes the value at the end of path and returns it.
+// ok is false if the value was not found. Maps along the path are not removed, even if they become empty.
+func (m *NestedMap[K]) DeletePath(path ...K) (v any, ok bool) {
+	if len(path) == 0 {
+		return
+	}
+	if len(path) == 1 {
+		if v, ok = m.Load(path[0]); ok {
+			m.Delete(path[0])
+		}
+		return
+	}
+	var parent any
+	if parent, ok = m.GetPath(path[:len(path)-1]...); !ok {
+		return
+	}
+	k := path[len(path)-1]
+	switch p := parent.(type) {
+	case map[K]any:
+		if v, ok = p[k]; ok {
+			delete(p, k)
+		}
+	case MapI[K, any]:
+		if v, ok = p.Load(k); ok {
+			p.Delete(k)
+		}
+	default:
+		ok = false
+	}
+	return
+}
+
+// Clone returns a copy of the NestedMap with the same key function and value comparison function.
+// This is a shallow clone: the nested maps are shared with the original.
+func (m *NestedMap[K]) Clone() *NestedMap[K] {
+	m1 := new(NestedMap[K])
+	m1.items = m.items.Clone()
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	return m1
+}
+
+// loadNested returns the value of k in node, if node is a map.
+func loadNested[K comparable](node any, k K) (v any, ok bool) {
+	switch n := node.(type) {
+	case map[K]any:
+		v, ok = n[k]
+	case MapI[K, any]:
+		v, ok = n.Load(k)
+	}
+	return
+}
+
+// setNested sets the value at the end of path in node, creating any missing maps along the way.
+func setNested[K comparable](node any, value any, path []K) bool {
+	var child any
+	var ok bool
+	if len(path) > 1 {
+		if child, ok = loadNested(node, path[0]); !ok {
+			child = make(map[K]any)
+		}
+		if !setNested(child, value, path[1:]) {
+			return false
+		}
+		if ok {
+			return true
+		}
+		value = child
+	}
+	switch n := node.(type) {
+	case map[K]any:
+		n[path[0]] = value
+	case MapI[K, any]:
+		n.Set(path[0], value)
+	default:
+		return false
+	}
+	return true
+}
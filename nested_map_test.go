@@ -0,0 +1,133 @@
+package maps
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gob.Register(new(NestedMap[string]))
+}
+
+func ExampleNestedMap() {
+	m := new(NestedMap[string])
+	_ = json.Unmarshal([]byte(`{"server":{"http":{"port":8080}}}`), m)
+	port, _ := GetPathAs[float64](m, "server", "http", "port")
+	fmt.Println(port)
+	m.SetPath("localhost", "server", "http", "host")
+	m.SetPath(true, "server", "tls", "enabled")
+	s, _ := json.Marshal(m)
+	fmt.Println(string(s))
+	// Output: 8080
+	// {"server":{"http":{"host":"localhost","port":8080},"tls":{"enabled":true}}}
+}
+
+func TestNestedMap_GetPath(t *testing.T) {
+	inner := new(Map[string, any])
+	inner.Set("d", 4)
+	m := NewNestedMap(map[string]any{
+		"a": map[string]any{
+			"b": map[string]any{"c": 3},
+			"x": 1,
+		},
+		"m": inner,
+	})
+
+	v, ok := m.GetPath("a", "b", "c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	v, ok = m.GetPath("m", "d")
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+
+	_, ok = m.GetPath("a", "z", "c")
+	assert.False(t, ok)
+	_, ok = m.GetPath("a", "x", "c")
+	assert.False(t, ok, "x is not a map")
+	_, ok = m.GetPath()
+	assert.False(t, ok)
+	assert.True(t, m.HasPath("a", "x"))
+	assert.False(t, m.HasPath("a", "y"))
+
+	i, ok := GetPathAs[int](m, "a", "b", "c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, i)
+	_, ok = GetPathAs[string](m, "a", "b", "c")
+	assert.False(t, ok)
+}
+
+func TestNestedMap_SetPath(t *testing.T) {
+	var m NestedMap[string]
+	assert.True(t, m.SetPath(1, "a"))
+	assert.True(t, m.SetPath(2, "b", "c", "d"))
+	assert.Equal(t, map[string]any{"c": map[string]any{"d": 2}}, m.Get("b"))
+	assert.True(t, m.SetPath(3, "b", "c", "e"))
+	assert.True(t, m.SetPath(4, "b", "f"))
+	v, _ := m.GetPath("b", "c", "e")
+	assert.Equal(t, 3, v)
+
+	// a value in the way is not replaced
+	assert.False(t, m.SetPath(5, "a", "x"))
+	assert.False(t, m.SetPath(5, "b", "f", "x", "y"))
+	assert.Equal(t, 1, m.Get("a"))
+	v, _ = m.GetPath("b", "f")
+	assert.Equal(t, 4, v)
+
+	inner := new(Map[string, any])
+	m.Set("m", inner)
+	assert.True(t, m.SetPath(6, "m", "n", "o"))
+	v, _ = inner.Load("n")
+	assert.Equal(t, map[string]any{"o": 6}, v)
+
+	assert.Panics(t, func() {
+		m.SetPath(1)
+	})
+}
+
+func TestNestedMap_DeletePath(t *testing.T) {
+	var m NestedMap[string]
+	m.SetPath(1, "a", "b", "c")
+	m.SetPath(2, "a", "b", "d")
+	m.SetPath(3, "m")
+	inner := new(Map[string, any])
+	inner.Set("x", 4)
+	m.Set("n", inner)
+
+	v, ok := m.DeletePath("a", "b", "c")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.False(t, m.HasPath("a", "b", "c"))
+	assert.True(t, m.HasPath("a", "b", "d"))
+
+	_, ok = m.DeletePath("a", "b", "c")
+	assert.False(t, ok)
+	_, ok = m.DeletePath("m", "x")
+	assert.False(t, ok)
+	_, ok = m.DeletePath()
+	assert.False(t, ok)
+
+	v, ok = m.DeletePath("n", "x")
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+	assert.Equal(t, 0, inner.Len())
+
+	v, ok = m.DeletePath("m")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+	assert.False(t, m.Has("m"))
+
+	m2 := m.Clone()
+	assert.True(t, m2.HasPath("a", "b", "d"))
+	m2.Delete("a")
+	assert.True(t, m.Has("a"))
+
+	m.SetKeyFunc(strings.ToLower)
+	m3 := m.Clone()
+	assert.True(t, m3.Has("A"), "the key function is cloned")
+	assert.True(t, m3.HasPath("A", "b", "d"))
+}
@@ -0,0 +1,365 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// KeyNormalizer changes string keys into the form a NormalizedMap stores them under.
+// Keys that normalize to the same string refer to the same item.
+type KeyNormalizer interface {
+	Normalize(k string) string
+}
+
+// NormalizeFunc is a function that is a KeyNormalizer. A nil NormalizeFunc does not change the keys.
+//
+// For example, to treat the composed and decomposed forms of Unicode characters as the same key,
+// use NormalizeFunc(norm.NFC.String) from the golang.org/x/text/unicode/norm package.
+type NormalizeFunc func(k string) string
+
+// Normalize calls f.
+func (f NormalizeFunc) Normalize(k string) string {
+	if f == nil {
+		return k
+	}
+	return f(k)
+}
+
+// CaseFold is a KeyNormalizer that ignores the case of the keys.
+type CaseFold struct{}
+
+// Normalize returns k in lower case.
+func (CaseFold) Normalize(k string) string {
+	return strings.ToLower(k)
+}
+
+// NormalizedMap is a map with string keys that are normalized by N before they are used,
+// so keys that are written differently but normalize to the same string refer to the same item.
+//
+// The map remembers the key that was used when an item was first added, and uses that key when ranging
+// and marshaling. Setting an existing item with a different key that normalizes to the same string
+// changes the value, but not the key.
+//
+// The zero value is ready to use with the zero value of N.
+// Do not make a copy of a NormalizedMap using the equality operator (=). Use Clone instead.
+type NormalizedMap[V any, N KeyNormalizer] struct {
+	items      map[string]normalizedItem[V]
+	normalizer N
+}
+
+// normalizedItem is an item of a map whose keys are changed before they are stored.
+// key is the key as it was given when the item was added.
+type normalizedItem[V any] struct {
+	key   string
+	value V
+}
+
+// NewNormalizedMap creates a new NormalizedMap that uses normalizer to normalize its keys.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new NormalizedMap.
+func NewNormalizedMap[V any, N KeyNormalizer](normalizer N, sources ...map[string]V) *NormalizedMap[V, N] {
+	m := &NormalizedMap[V, N]{items: make(map[string]normalizedItem[V], sourcesLen(sources)), normalizer: normalizer}
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// normalize returns the key that items are stored under.
+func (m *NormalizedMap[V, N]) normalize(k string) string {
+	return m.normalizer.Normalize(k)
+}
+
+// Set sets the key to the given value.
+// If an item with a key that normalizes to the same string exists, its value is changed, but its key is not.
+func (m *NormalizedMap[V, N]) Set(k string, v V) {
+	if m.items == nil {
+		m.items = make(map[string]normalizedItem[V])
+	}
+	f := m.normalize(k)
+	if item, ok := m.items[f]; ok {
+		k = item.key
+	}
+	m.items[f] = normalizedItem[V]{k, v}
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *NormalizedMap[V, N]) Load(k string) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	var item normalizedItem[V]
+	if item, ok = m.items[m.normalize(k)]; ok {
+		v = item.value
+	}
+	return
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *NormalizedMap[V, N]) Get(k string) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the key exists.
+func (m *NormalizedMap[V, N]) Has(k string) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// Key returns the key of the item as it was given when the item was added, and whether the item exists.
+func (m *NormalizedMap[V, N]) Key(k string) (key string, ok bool) {
+	if m == nil {
+		return
+	}
+	var item normalizedItem[V]
+	if item, ok = m.items[m.normalize(k)]; ok {
+		key = item.key
+	}
+	return
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *NormalizedMap[V, N]) Delete(k string) (v V) {
+	if m == nil {
+		return
+	}
+	f := m.normalize(k)
+	if item, ok := m.items[f]; ok {
+		v = item.value
+		delete(m.items, f)
+	}
+	return
+}
+
+// Clear removes all the items in the map.
+func (m *NormalizedMap[V, N]) Clear() {
+	if m == nil {
+		return
+	}
+	m.items = nil
+}
+
+// Len returns the number of items in the map.
+func (m *NormalizedMap[V, N]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items)
+}
+
+// Range calls the given function for each key,value pair in the map, using the keys as they were first given.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+func (m *NormalizedMap[V, N]) Range(f func(k string, v V) bool) {
+	if m == nil {
+		return
+	}
+	for _, item := range m.items {
+		if !f(item.key, item.value) {
+			return
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map, as they were first given.
+func (m *NormalizedMap[V, N]) Keys() (keys []string) {
+	if m.Len() == 0 {
+		return
+	}
+	keys = make([]string, 0, len(m.items))
+	for _, item := range m.items {
+		keys = append(keys, item.key)
+	}
+	return
+}
+
+// Values returns a new slice containing the values of the map.
+func (m *NormalizedMap[V, N]) Values() (values []V) {
+	if m.Len() == 0 {
+		return
+	}
+	values = make([]V, 0, len(m.items))
+	for _, item := range m.items {
+		values = append(values, item.value)
+	}
+	return
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *NormalizedMap[V, N]) Merge(in MapI[string, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+func (m *NormalizedMap[V, N]) Copy(in MapI[string, V]) {
+	if in == nil {
+		return
+	}
+	in.Range(func(k string, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal, comparing the keys after they are normalized.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *NormalizedMap[V, N]) Equal(m2 MapI[string, V]) bool {
+	if m2 == nil {
+		return m.Len() == 0
+	}
+	if m.Len() != m2.Len() {
+		return false
+	}
+	// Since the lengths are the same, the maps are equal only if every key of m2 matches a different item of m.
+	// Keys of m2 that normalize to the same string would leave an item of m unmatched.
+	seen := make(map[string]struct{}, m.Len())
+	ret := true
+	m2.Range(func(k string, v V) bool {
+		nk := m.normalize(k)
+		item, ok := m.items[nk]
+		if _, dup := seen[nk]; dup || !ok || !equalValues(v, item.value) {
+			ret = false
+			return false
+		}
+		seen[nk] = struct{}{}
+		return true
+	})
+	return ret
+}
+
+// std returns the items of the map as a standard map, using the keys as they were first given.
+func (m *NormalizedMap[V, N]) std() StdMap[string, V] {
+	if m == nil {
+		return nil
+	}
+	s := make(StdMap[string, V], len(m.items))
+	for _, item := range m.items {
+		s[item.key] = item.value
+	}
+	return s
+}
+
+// String outputs the map as a string.
+func (m *NormalizedMap[V, N]) String() string {
+	if m == nil {
+		return ""
+	}
+	s := fmt.Sprintf("%#v", map[string]V(m.std()))
+	loc := strings.IndexRune(s, '{')
+	return s[loc:]
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *NormalizedMap[V, N]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(map[string]V(m.std()))
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// NormalizedMap.
+func (m *NormalizedMap[V, N]) UnmarshalBinary(data []byte) (err error) {
+	var items StdMap[string, V]
+	if err = items.UnmarshalBinary(data); err == nil {
+		m.Clear()
+		m.Copy(items)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *NormalizedMap[V, N]) MarshalJSON() (out []byte, err error) {
+	return json.Marshal(map[string]V(m.std()))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a NormalizedMap.
+// The JSON must start with an object. If the object has keys that normalize to the same string,
+// which one is kept is not determinate.
+func (m *NormalizedMap[V, N]) UnmarshalJSON(in []byte) (err error) {
+	var items StdMap[string, V]
+	if err = items.UnmarshalJSON(in); err == nil {
+		m.Clear()
+		m.Copy(items)
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map, using the keys as they were first given.
+func (m *NormalizedMap[V, N]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map, as they were first given.
+func (m *NormalizedMap[V, N]) KeysIter() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		m.Range(func(k string, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+func (m *NormalizedMap[V, N]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ string, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *NormalizedMap[V, N]) Insert(seq iter.Seq2[string, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// CollectNormalizedMap collects key-value pairs from seq into a new NormalizedMap
+// that uses normalizer, and returns it.
+func CollectNormalizedMap[V any, N KeyNormalizer](normalizer N, seq iter.Seq2[string, V]) *NormalizedMap[V, N] {
+	m := &NormalizedMap[V, N]{normalizer: normalizer}
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the NormalizedMap, including its normalizer. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *NormalizedMap[V, N]) Clone() *NormalizedMap[V, N] {
+	m1 := new(NormalizedMap[V, N])
+	if m == nil {
+		return m1
+	}
+	m1.normalizer = m.normalizer
+	if m.items != nil {
+		m1.items = make(map[string]normalizedItem[V], len(m.items))
+		for f, item := range m.items {
+			m1.items[f] = item
+		}
+	}
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+// del receives the keys as they were first given.
+func (m *NormalizedMap[V, N]) DeleteFunc(del func(string, V) bool) {
+	if m == nil {
+		return
+	}
+	for f, item := range m.items {
+		if del(item.key, item.value) {
+			delete(m.items, f)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizedMap_Mapi(t *testing.T) {
+	runMapiTests[NormalizedMap[int, NormalizeFunc]](t, makeMapi[NormalizedMap[int, NormalizeFunc]])
+}
+
+func init() {
+	gob.Register(new(NormalizedMap[int, NormalizeFunc]))
+}
+
+// composeAcute is a stand-in for norm.NFC.String that only composes an e with a combining acute accent.
+func composeAcute(k string) string {
+	return strings.ReplaceAll(k, "e\u0301", "\u00e9")
+}
+
+func ExampleNormalizedMap() {
+	m := NewNormalizedMap[int](NormalizeFunc(strings.TrimSpace))
+	m.Set(" id ", 1)
+	m.Set("id", 2)
+	fmt.Println(m.Get("id "), m.Len())
+	// Output: 2 1
+}
+
+func TestNormalizedMap(t *testing.T) {
+	m := NewNormalizedMap(NormalizeFunc(composeAcute), map[string]int{"caf\u00e9": 1})
+	assert.True(t, m.Has("cafe\u0301"))
+	m.Set("cafe\u0301", 2)
+	assert.Equal(t, 1, m.Len())
+	assert.Equal(t, 2, m.Get("caf\u00e9"))
+	k, _ := m.Key("cafe\u0301")
+	assert.Equal(t, "caf\u00e9", k)
+
+	m2 := m.Clone()
+	assert.True(t, m2.Has("cafe\u0301"), "the normalizer is cloned")
+
+	m3 := CollectNormalizedMap(NormalizeFunc(composeAcute), m.All())
+	assert.True(t, m3.Equal(mapT{"cafe\u0301": 2}))
+
+	var m4 NormalizedMap[int, NormalizeFunc]
+	m4.Set("cafe\u0301", 1)
+	assert.False(t, m4.Has("caf\u00e9"), "a nil NormalizeFunc does not change keys")
+}
+
+func TestNormalizedMap_EqualCollidingKeys(t *testing.T) {
+	m := NewNormalizedMap[int](CaseFold{}, map[string]int{"a": 1, "b": 2})
+	assert.False(t, m.Equal(StdMap[string, int]{"A": 1, "a": 1}))
+	assert.True(t, m.Equal(StdMap[string, int]{"A": 1, "b": 2}))
+	assert.False(t, m.Equal(StdMap[string, int]{"A": 1, "b": 3}))
+}
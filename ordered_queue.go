@@ -0,0 +1,323 @@
+package maps
+
+import "iter"
+
+// OrderedQueue is a first-in, first-out queue of unique keys, each with a value. It is a thin wrapper
+// over a SliceMap that adds queue operations, while still allowing items to be looked up
+// or removed by key.
+//
+// Enqueuing a key that is already in the queue does nothing, which makes OrderedQueue suitable as a
+// queue of unique jobs, where a job that is already waiting should not be run twice.
+//
+// The zero value is ready to use. OrderedQueue is not safe for concurrent use. See SafeOrderedQueue.
+type OrderedQueue[K comparable, V any] struct {
+	sm SliceMap[K, V]
+}
+
+// NewOrderedQueue creates a new, empty OrderedQueue.
+func NewOrderedQueue[K comparable, V any]() *OrderedQueue[K, V] {
+	return new(OrderedQueue[K, V])
+}
+
+// Enqueue adds the key and value to the back of the queue and returns true.
+// If the key is already in the queue, its position and value are not changed and false is returned.
+func (q *OrderedQueue[K, V]) Enqueue(k K, v V) bool {
+	if q == nil {
+		panic("cannot enqueue on a nil OrderedQueue")
+	}
+	if q.sm.Has(k) {
+		return false
+	}
+	q.sm.Set(k, v)
+	return true
+}
+
+// Dequeue removes the item at the front of the queue and returns it.
+// If the queue is empty, ok will be false.
+func (q *OrderedQueue[K, V]) Dequeue() (k K, v V, ok bool) {
+	if q == nil {
+		return
+	}
+	return q.sm.popFront()
+}
+
+// PeekFront returns the item at the front of the queue without removing it.
+// If the queue is empty, ok will be false.
+func (q *OrderedQueue[K, V]) PeekFront() (k K, v V, ok bool) {
+	if q.Len() == 0 {
+		return
+	}
+	k = q.sm.order[0]
+	return k, q.sm.items[k], true
+}
+
+// PeekBack returns the item at the back of the queue without removing it.
+// If the queue is empty, ok will be false.
+func (q *OrderedQueue[K, V]) PeekBack() (k K, v V, ok bool) {
+	if q.Len() == 0 {
+		return
+	}
+	k = q.sm.order[len(q.sm.order)-1]
+	return k, q.sm.items[k], true
+}
+
+// Get returns the value of the given key. If the key is not in the queue, the zero value is returned.
+func (q *OrderedQueue[K, V]) Get(k K) (v V) {
+	if q == nil {
+		return
+	}
+	return q.sm.Get(k)
+}
+
+// Load returns the value of the given key, and a boolean indicating whether the key is in the queue.
+func (q *OrderedQueue[K, V]) Load(k K) (v V, ok bool) {
+	if q == nil {
+		return
+	}
+	return q.sm.Load(k)
+}
+
+// Has returns true if the key is in the queue.
+func (q *OrderedQueue[K, V]) Has(k K) bool {
+	if q == nil {
+		return false
+	}
+	return q.sm.Has(k)
+}
+
+// Delete removes the key from wherever it is in the queue and returns its value.
+// If the key is not in the queue, the zero value is returned.
+func (q *OrderedQueue[K, V]) Delete(k K) (v V) {
+	if q == nil {
+		return
+	}
+	return q.sm.Delete(k)
+}
+
+// Len returns the number of items in the queue.
+func (q *OrderedQueue[K, V]) Len() int {
+	if q == nil {
+		return 0
+	}
+	return q.sm.Len()
+}
+
+// Clear removes all the items from the queue.
+func (q *OrderedQueue[K, V]) Clear() {
+	if q == nil {
+		return
+	}
+	q.sm.Clear()
+}
+
+// Keys returns the keys in the queue, from front to back.
+func (q *OrderedQueue[K, V]) Keys() []K {
+	if q == nil {
+		return nil
+	}
+	return q.sm.Keys()
+}
+
+// Values returns the values in the queue, from front to back.
+func (q *OrderedQueue[K, V]) Values() []V {
+	if q == nil {
+		return nil
+	}
+	return q.sm.Values()
+}
+
+// All returns an iterator over the items in the queue, from front to back.
+func (q *OrderedQueue[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if q == nil {
+			return
+		}
+		q.sm.Range(yield)
+	}
+}
+
+// String outputs the queue as a string, from front to back.
+func (q *OrderedQueue[K, V]) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.sm.String()
+}
+
+// Clone returns a copy of the queue. This is a shallow clone of the keys and values.
+func (q *OrderedQueue[K, V]) Clone() *OrderedQueue[K, V] {
+	q2 := new(OrderedQueue[K, V])
+	if q == nil {
+		return q2
+	}
+	q2.sm.Copy(&q.sm)
+	return q2
+}
+
+// SafeOrderedQueue is an OrderedQueue that is safe for concurrent use. It is a thin wrapper over a SafeSliceMap.
+//
+// Dequeue removes and returns the front item while holding the lock once, so each item is
+// dequeued by only one go routine.
+//
+// The zero value is ready to use. Do not make a copy of a SafeOrderedQueue using the equality operator.
+// Use Clone() instead.
+type SafeOrderedQueue[K comparable, V any] struct {
+	m SafeSliceMap[K, V]
+}
+
+// NewSafeOrderedQueue creates a new, empty SafeOrderedQueue.
+func NewSafeOrderedQueue[K comparable, V any]() *SafeOrderedQueue[K, V] {
+	return new(SafeOrderedQueue[K, V])
+}
+
+// Enqueue adds the key and value to the back of the queue and returns true.
+// If the key is already in the queue, its position and value are not changed and false is returned.
+func (q *SafeOrderedQueue[K, V]) Enqueue(k K, v V) bool {
+	if q == nil {
+		panic("cannot enqueue on a nil SafeOrderedQueue")
+	}
+	q.m.mu.Lock()
+	defer q.m.mu.Unlock()
+	if q.m.sm.Has(k) {
+		return false
+	}
+	q.m.sm.Set(k, v)
+	return true
+}
+
+// Dequeue removes the item at the front of the queue and returns it.
+// If the queue is empty, ok will be false.
+func (q *SafeOrderedQueue[K, V]) Dequeue() (k K, v V, ok bool) {
+	if q == nil {
+		return
+	}
+	q.m.mu.Lock()
+	defer q.m.mu.Unlock()
+	return q.m.sm.popFront()
+}
+
+// PeekFront returns the item at the front of the queue without removing it.
+// If the queue is empty, ok will be false.
+func (q *SafeOrderedQueue[K, V]) PeekFront() (k K, v V, ok bool) {
+	if q == nil {
+		return
+	}
+	q.m.mu.RLock()
+	defer q.m.mu.RUnlock()
+	if len(q.m.sm.order) == 0 {
+		return
+	}
+	k = q.m.sm.order[0]
+	return k, q.m.sm.items[k], true
+}
+
+// PeekBack returns the item at the back of the queue without removing it.
+// If the queue is empty, ok will be false.
+func (q *SafeOrderedQueue[K, V]) PeekBack() (k K, v V, ok bool) {
+	if q == nil {
+		return
+	}
+	q.m.mu.RLock()
+	defer q.m.mu.RUnlock()
+	if len(q.m.sm.order) == 0 {
+		return
+	}
+	k = q.m.sm.order[len(q.m.sm.order)-1]
+	return k, q.m.sm.items[k], true
+}
+
+// Get returns the value of the given key. If the key is not in the queue, the zero value is returned.
+func (q *SafeOrderedQueue[K, V]) Get(k K) (v V) {
+	if q == nil {
+		return
+	}
+	return q.m.Get(k)
+}
+
+// Load returns the value of the given key, and a boolean indicating whether the key is in the queue.
+func (q *SafeOrderedQueue[K, V]) Load(k K) (v V, ok bool) {
+	if q == nil {
+		return
+	}
+	return q.m.Load(k)
+}
+
+// Has returns true if the key is in the queue.
+func (q *SafeOrderedQueue[K, V]) Has(k K) bool {
+	if q == nil {
+		return false
+	}
+	return q.m.Has(k)
+}
+
+// Delete removes the key from wherever it is in the queue and returns its value.
+// If the key is not in the queue, the zero value is returned.
+func (q *SafeOrderedQueue[K, V]) Delete(k K) (v V) {
+	if q == nil {
+		return
+	}
+	return q.m.Delete(k)
+}
+
+// Len returns the number of items in the queue.
+func (q *SafeOrderedQueue[K, V]) Len() int {
+	if q == nil {
+		return 0
+	}
+	return q.m.Len()
+}
+
+// Clear removes all the items from the queue.
+func (q *SafeOrderedQueue[K, V]) Clear() {
+	if q == nil {
+		return
+	}
+	q.m.Clear()
+}
+
+// Keys returns a copy of the keys in the queue, from front to back.
+func (q *SafeOrderedQueue[K, V]) Keys() []K {
+	if q == nil {
+		return nil
+	}
+	return q.m.Keys()
+}
+
+// Values returns a copy of the values in the queue, from front to back.
+func (q *SafeOrderedQueue[K, V]) Values() []V {
+	if q == nil {
+		return nil
+	}
+	return q.m.Values()
+}
+
+// All returns an iterator over the items in the queue, from front to back.
+// The queue is locked during the iteration, so the loop body must not call other methods of the SafeOrderedQueue.
+func (q *SafeOrderedQueue[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if q == nil {
+			return
+		}
+		q.m.Range(yield)
+	}
+}
+
+// String outputs the queue as a string, from front to back.
+func (q *SafeOrderedQueue[K, V]) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.m.String()
+}
+
+// Clone returns a copy of the queue. This is a shallow clone of the keys and values.
+func (q *SafeOrderedQueue[K, V]) Clone() *SafeOrderedQueue[K, V] {
+	q2 := new(SafeOrderedQueue[K, V])
+	if q == nil {
+		return q2
+	}
+	q.m.mu.RLock()
+	defer q.m.mu.RUnlock()
+	q2.m.sm.Copy(&q.m.sm)
+	return q2
+}
@@ -0,0 +1,127 @@
+package maps
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleOrderedQueue() {
+	q := new(OrderedQueue[string, int])
+	q.Enqueue("build", 1)
+	q.Enqueue("test", 2)
+	q.Enqueue("build", 3) // already waiting, so ignored
+	q.Enqueue("deploy", 4)
+
+	for q.Len() > 0 {
+		k, v, _ := q.Dequeue()
+		fmt.Println(k, v)
+	}
+	// Output: build 1
+	// test 2
+	// deploy 4
+}
+
+func TestOrderedQueue(t *testing.T) {
+	var q OrderedQueue[string, int]
+	_, _, ok := q.PeekFront()
+	assert.False(t, ok)
+	_, _, ok = q.Dequeue()
+	assert.False(t, ok)
+
+	assert.True(t, q.Enqueue("a", 1))
+	assert.True(t, q.Enqueue("b", 2))
+	assert.True(t, q.Enqueue("c", 3))
+	assert.False(t, q.Enqueue("a", 4))
+	assert.Equal(t, 1, q.Get("a"))
+	assert.Equal(t, `{"a":1,"b":2,"c":3}`, q.String())
+
+	k, v, ok := q.PeekFront()
+	assert.Equal(t, "a", k)
+	assert.Equal(t, 1, v)
+	assert.True(t, ok)
+	k, v, _ = q.PeekBack()
+	assert.Equal(t, "c", k)
+	assert.Equal(t, 3, v)
+
+	assert.Equal(t, 2, q.Delete("b"))
+	assert.False(t, q.Has("b"))
+	q2 := q.Clone()
+
+	k, _, _ = q.Dequeue()
+	assert.Equal(t, "a", k)
+	assert.False(t, q.Has("a"))
+	assert.True(t, q.Enqueue("a", 5), "a dequeued key can be queued again")
+	assert.Equal(t, []string{"c", "a"}, q.Keys())
+	assert.Equal(t, []int{3, 5}, q.Values())
+
+	assert.Equal(t, []string{"a", "c"}, q2.Keys(), "the clone is not changed")
+	for k, v := range q2.All() {
+		assert.Equal(t, q2.Get(k), v)
+	}
+	q2.Clear()
+	assert.Equal(t, 0, q2.Len())
+}
+
+func TestOrderedQueue_Nil(t *testing.T) {
+	var q *OrderedQueue[string, int]
+	assert.Equal(t, 0, q.Len())
+	assert.False(t, q.Has("a"))
+	assert.Nil(t, q.Keys())
+	assert.Equal(t, "", q.String())
+	_, _, ok := q.Dequeue()
+	assert.False(t, ok)
+	_, _, ok = q.PeekBack()
+	assert.False(t, ok)
+	assert.Equal(t, 0, q.Clone().Len())
+	assert.Panics(t, func() {
+		q.Enqueue("a", 1)
+	})
+}
+
+func TestSafeOrderedQueue(t *testing.T) {
+	q := NewSafeOrderedQueue[int, int]()
+	assert.True(t, q.Enqueue(1, 10))
+	assert.False(t, q.Enqueue(1, 20))
+	assert.True(t, q.Enqueue(2, 20))
+	k, _, _ := q.PeekFront()
+	assert.Equal(t, 1, k)
+	k, _, _ = q.PeekBack()
+	assert.Equal(t, 2, k)
+	assert.Equal(t, `{1:10,2:20}`, q.Clone().String())
+	assert.Equal(t, 20, q.Delete(2))
+	q.Clear()
+	_, _, ok := q.PeekFront()
+	assert.False(t, ok)
+
+	// every item is dequeued exactly once
+	const count = 1000
+	for i := range count {
+		q.Enqueue(i, i)
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[int]int)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				k, _, ok := q.Dequeue()
+				if !ok {
+					return
+				}
+				mu.Lock()
+				seen[k]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, count)
+	for _, n := range seen {
+		assert.Equal(t, 1, n)
+	}
+}
@@ -0,0 +1,7 @@
+package maps
+
+// Pair is a key and value pair from a map.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
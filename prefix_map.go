@@ -0,0 +1,514 @@
+package maps
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// PrefixMap is a map with string keys that is stored as a radix tree, so that all the keys that start with
+// a prefix can be found without looking at the other keys. Use it for routing tables, autocomplete and
+// other lookups by prefix.
+//
+// Ranging over a PrefixMap visits the keys in lexicographic (byte) order.
+//
+// The zero value is ready to use. A PrefixMap is not safe for concurrent use.
+type PrefixMap[V any] struct {
+	root  prefixNode[V]
+	count int
+}
+
+// prefixNode is a node of the tree. The key of a node is the concatenation of the labels from the root.
+// The labels of the children of a node all start with different bytes, and the children are sorted by that byte.
+type prefixNode[V any] struct {
+	label    string
+	children []*prefixNode[V]
+	value    V
+	ok       bool // true if the node holds a value
+}
+
+// NewPrefixMap creates a new PrefixMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new PrefixMap.
+func NewPrefixMap[V any](sources ...map[string]V) *PrefixMap[V] {
+	m := new(PrefixMap[V])
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// child returns the child of n whose label starts with b, and its index.
+// If there is no such child, it returns nil and the index where it would be inserted.
+func (n *prefixNode[V]) child(b byte) (int, *prefixNode[V]) {
+	i, found := slices.BinarySearchFunc(n.children, b, func(c *prefixNode[V], b byte) int {
+		return cmp.Compare(c.label[0], b)
+	})
+	if found {
+		return i, n.children[i]
+	}
+	return i, nil
+}
+
+// compactChild removes the child at i if it has no value and no children,
+// or merges it with its only child if it has no value.
+func (n *prefixNode[V]) compactChild(i int) {
+	c := n.children[i]
+	if c.ok {
+		return
+	}
+	switch len(c.children) {
+	case 0:
+		n.children = slices.Delete(n.children, i, i+1)
+	case 1:
+		gc := c.children[0]
+		gc.label = c.label + gc.label
+		n.children[i] = gc
+	}
+}
+
+// walk calls yield for the node and everything below it, in order. key is the key of the node.
+func (n *prefixNode[V]) walk(key string, yield func(string, V) bool) bool {
+	if n.ok && !yield(key, n.value) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(key+c.label, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// size returns the number of values in the node and everything below it.
+func (n *prefixNode[V]) size() (count int) {
+	if n.ok {
+		count++
+	}
+	for _, c := range n.children {
+		count += c.size()
+	}
+	return
+}
+
+// Set sets the key to the given value.
+func (m *PrefixMap[V]) Set(k string, v V) {
+	n := &m.root
+	for k != "" {
+		i, c := n.child(k[0])
+		if c == nil {
+			n.children = slices.Insert(n.children, i, &prefixNode[V]{label: k, value: v, ok: true})
+			m.count++
+			return
+		}
+		common := commonPrefixLen(c.label, k)
+		if common < len(c.label) {
+			// split the child so that the common part is its own node
+			split := &prefixNode[V]{label: c.label[:common], children: []*prefixNode[V]{c}}
+			c.label = c.label[common:]
+			n.children[i] = split
+			c = split
+		}
+		n = c
+		k = k[common:]
+	}
+	if !n.ok {
+		m.count++
+	}
+	n.value = v
+	n.ok = true
+}
+
+// commonPrefixLen returns the number of bytes at the start of a and b that are the same.
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *PrefixMap[V]) Load(k string) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	n := &m.root
+	for k != "" {
+		_, c := n.child(k[0])
+		if c == nil || !strings.HasPrefix(k, c.label) {
+			return
+		}
+		k = k[len(c.label):]
+		n = c
+	}
+	return n.value, n.ok
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *PrefixMap[V]) Get(k string) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the key exists.
+func (m *PrefixMap[V]) Has(k string) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// LongestPrefix returns the longest key in the map that is a prefix of s, and its value.
+// ok is false if no key is a prefix of s.
+func (m *PrefixMap[V]) LongestPrefix(s string) (k string, v V, ok bool) {
+	if m == nil {
+		return
+	}
+	n := &m.root
+	var key string
+	for {
+		if n.ok {
+			k, v, ok = key, n.value, true
+		}
+		if len(key) == len(s) {
+			return
+		}
+		_, c := n.child(s[len(key)])
+		if c == nil || !strings.HasPrefix(s[len(key):], c.label) {
+			return
+		}
+		key = s[:len(key)+len(c.label)]
+		n = c
+	}
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *PrefixMap[V]) Delete(k string) (v V) {
+	if m == nil {
+		return
+	}
+	var ok bool
+	if v, ok = m.root.delete(k); ok {
+		m.count--
+	}
+	return
+}
+
+// delete removes the key below n and returns its value.
+func (n *prefixNode[V]) delete(k string) (v V, ok bool) {
+	if k == "" {
+		if n.ok {
+			v, ok = n.value, true
+			var zero V
+			n.value = zero
+			n.ok = false
+		}
+		return
+	}
+	i, c := n.child(k[0])
+	if c == nil || !strings.HasPrefix(k, c.label) {
+		return
+	}
+	if v, ok = c.delete(k[len(c.label):]); ok {
+		n.compactChild(i)
+	}
+	return
+}
+
+// DeletePrefix removes all the keys that start with prefix, and returns the number of keys removed.
+func (m *PrefixMap[V]) DeletePrefix(prefix string) (count int) {
+	if m == nil {
+		return
+	}
+	if prefix == "" {
+		count = m.count
+		m.Clear()
+		return
+	}
+	count = m.root.deletePrefix(prefix)
+	m.count -= count
+	return
+}
+
+// deletePrefix removes all the keys below n that start with prefix, which must not be empty.
+func (n *prefixNode[V]) deletePrefix(prefix string) (count int) {
+	i, c := n.child(prefix[0])
+	if c == nil {
+		return
+	}
+	switch {
+	case strings.HasPrefix(c.label, prefix):
+		// everything under c starts with prefix
+		count = c.size()
+		n.children = slices.Delete(n.children, i, i+1)
+	case strings.HasPrefix(prefix, c.label):
+		if count = c.deletePrefix(prefix[len(c.label):]); count > 0 {
+			n.compactChild(i)
+		}
+	}
+	return
+}
+
+// WithPrefix returns an iterator over all the items whose keys start with prefix, in lexicographic order.
+func (m *PrefixMap[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		if m == nil {
+			return
+		}
+		n := &m.root
+		var key string
+		for rest := prefix; rest != ""; {
+			_, c := n.child(rest[0])
+			switch {
+			case c == nil:
+				return
+			case strings.HasPrefix(rest, c.label):
+				rest = rest[len(c.label):]
+			case strings.HasPrefix(c.label, rest):
+				rest = ""
+			default:
+				return
+			}
+			key += c.label
+			n = c
+		}
+		n.walk(key, yield)
+	}
+}
+
+// Clear removes all the items in the map.
+func (m *PrefixMap[V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.root = prefixNode[V]{}
+	m.count = 0
+}
+
+// Len returns the number of items in the map.
+func (m *PrefixMap[V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+// Range calls the given function for each key,value pair in the map, in lexicographic key order.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+// f must not change the map.
+func (m *PrefixMap[V]) Range(f func(k string, v V) bool) {
+	if m == nil {
+		return
+	}
+	m.root.walk("", f)
+}
+
+// Keys returns a new slice containing the keys of the map, in lexicographic order.
+func (m *PrefixMap[V]) Keys() (keys []string) {
+	if m.Len() == 0 {
+		return
+	}
+	keys = make([]string, 0, m.count)
+	m.Range(func(k string, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return
+}
+
+// Values returns a new slice containing the values of the map, in the order of their keys.
+func (m *PrefixMap[V]) Values() (values []V) {
+	if m.Len() == 0 {
+		return
+	}
+	values = make([]V, 0, m.count)
+	m.Range(func(_ string, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *PrefixMap[V]) Merge(in MapI[string, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+func (m *PrefixMap[V]) Copy(in MapI[string, V]) {
+	if in == nil {
+		return
+	}
+	in.Range(func(k string, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *PrefixMap[V]) Equal(m2 MapI[string, V]) bool {
+	if m2 == nil {
+		return m.Len() == 0
+	}
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k string, v V) bool {
+		if v2, ok := m.Load(k); !ok || !equalValues(v, v2) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// String outputs the map as a string, in lexicographic key order.
+func (m *PrefixMap[V]) String() string {
+	var s string
+
+	if m == nil {
+		return s
+	}
+
+	s = "{"
+	m.Range(func(k string, v V) bool {
+		s += fmt.Sprintf(`%#v:%#v,`, k, v)
+		return true
+	})
+	s = strings.TrimRight(s, ",")
+	s += "}"
+	return s
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *PrefixMap[V]) MarshalBinary() (data []byte, err error) {
+	buf := new(bytes.Buffer)
+	encoder := gob.NewEncoder(buf)
+
+	err = encoder.Encode(m.Keys())
+	if err == nil {
+		err = encoder.Encode(m.Values())
+	}
+	data = buf.Bytes()
+	return
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// PrefixMap.
+func (m *PrefixMap[V]) UnmarshalBinary(data []byte) (err error) {
+	var keys []string
+	var values []V
+
+	buf := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buf)
+	if err = dec.Decode(&keys); err == nil {
+		err = dec.Decode(&values)
+	}
+	if err == nil && len(keys) != len(values) {
+		err = fmt.Errorf("maps: cannot unmarshal %d keys with %d values", len(keys), len(values))
+	}
+	if err == nil {
+		m.Clear()
+		for i, k := range keys {
+			m.Set(k, values[i])
+		}
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *PrefixMap[V]) MarshalJSON() (data []byte, err error) {
+	items := make(map[string]V, m.Len())
+	m.Range(func(k string, v V) bool {
+		items[k] = v
+		return true
+	})
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a PrefixMap.
+// The JSON must start with an object.
+func (m *PrefixMap[V]) UnmarshalJSON(data []byte) (err error) {
+	var items map[string]V
+
+	if err = json.Unmarshal(data, &items); err == nil {
+		m.Clear()
+		for k, v := range items {
+			m.Set(k, v)
+		}
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map, in lexicographic key order.
+func (m *PrefixMap[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map, in lexicographic order.
+func (m *PrefixMap[V]) KeysIter() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		m.Range(func(k string, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map, in the order of their keys.
+func (m *PrefixMap[V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ string, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *PrefixMap[V]) Insert(seq iter.Seq2[string, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// CollectPrefixMap collects key-value pairs from seq into a new PrefixMap
+// and returns it.
+func CollectPrefixMap[V any](seq iter.Seq2[string, V]) *PrefixMap[V] {
+	m := new(PrefixMap[V])
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the PrefixMap. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *PrefixMap[V]) Clone() *PrefixMap[V] {
+	m1 := new(PrefixMap[V])
+	m1.Insert(m.All())
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *PrefixMap[V]) DeleteFunc(del func(string, V) bool) {
+	var keys []string
+	m.Range(func(k string, v V) bool {
+		if del(k, v) {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	for _, k := range keys {
+		m.Delete(k)
+	}
+}
@@ -0,0 +1,139 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixMap_Mapi(t *testing.T) {
+	runMapiTests[PrefixMap[int]](t, makeMapi[PrefixMap[int]])
+}
+
+func init() {
+	gob.Register(new(PrefixMap[int]))
+}
+
+func ExamplePrefixMap_WithPrefix() {
+	m := new(PrefixMap[int])
+	m.Set("apple", 1)
+	m.Set("apricot", 2)
+	m.Set("banana", 3)
+	m.Set("ap", 4)
+	for k, v := range m.WithPrefix("ap") {
+		fmt.Println(k, v)
+	}
+	// Output: ap 4
+	// apple 1
+	// apricot 2
+}
+
+func ExamplePrefixMap_LongestPrefix() {
+	m := new(PrefixMap[string])
+	m.Set("/", "root")
+	m.Set("/api/", "api")
+	m.Set("/api/users/", "users")
+	k, v, _ := m.LongestPrefix("/api/users/42")
+	fmt.Println(k, v)
+	k, v, _ = m.LongestPrefix("/api/orders")
+	fmt.Println(k, v)
+	// Output: /api/users/ users
+	// /api/ api
+}
+
+func prefixKeys(m *PrefixMap[int], prefix string) (keys []string) {
+	for k := range m.WithPrefix(prefix) {
+		keys = append(keys, k)
+	}
+	return
+}
+
+func TestPrefixMap(t *testing.T) {
+	var m PrefixMap[int]
+	assert.Nil(t, prefixKeys(&m, "a"))
+	for i, k := range []string{"test", "team", "toast", "te", "", "tea", "x"} {
+		m.Set(k, i)
+	}
+	assert.Equal(t, 7, m.Len())
+	assert.Equal(t, []string{"", "te", "tea", "team", "test", "toast", "x"}, m.Keys())
+	assert.Equal(t, 4, m.Get(""))
+	assert.Equal(t, 3, m.Get("te"))
+	assert.False(t, m.Has("t"))
+	assert.False(t, m.Has("teams"))
+	m.Set("te", 10)
+	assert.Equal(t, 7, m.Len())
+	assert.Equal(t, 10, m.Get("te"))
+
+	assert.Equal(t, []string{"te", "tea", "team", "test"}, prefixKeys(&m, "te"))
+	assert.Equal(t, []string{"tea", "team"}, prefixKeys(&m, "tea"))
+	assert.Equal(t, []string{"te", "tea", "team", "test", "toast"}, prefixKeys(&m, "t"))
+	assert.Equal(t, []string{"toast"}, prefixKeys(&m, "to"))
+	assert.Nil(t, prefixKeys(&m, "tx"))
+	assert.Nil(t, prefixKeys(&m, "teal"))
+	assert.Equal(t, m.Keys(), prefixKeys(&m, ""))
+
+	k, v, ok := m.LongestPrefix("teapot")
+	assert.Equal(t, "tea", k)
+	assert.Equal(t, 5, v)
+	assert.True(t, ok)
+	k, _, ok = m.LongestPrefix("abc")
+	assert.Equal(t, "", k)
+	assert.True(t, ok)
+
+	assert.Equal(t, 10, m.Delete("te"))
+	assert.Equal(t, 0, m.Delete("te"))
+	assert.Equal(t, 0, m.Delete("t"))
+	assert.Equal(t, []string{"tea", "team", "test"}, prefixKeys(&m, "te"))
+	assert.Equal(t, 2, m.DeletePrefix("tea"))
+	assert.Equal(t, []string{"", "test", "toast", "x"}, m.Keys())
+	assert.Equal(t, 0, m.DeletePrefix("q"))
+	assert.Equal(t, 2, m.DeletePrefix("t"))
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 2, m.DeletePrefix(""))
+	assert.Equal(t, 0, m.Len())
+
+	_, _, ok = m.LongestPrefix("abc")
+	assert.False(t, ok)
+
+	assert.Error(t, m.UnmarshalBinary(gobEncode(t, []string{"a", "b"}, []int{1})))
+}
+
+func TestPrefixMap_Random(t *testing.T) {
+	var m PrefixMap[int]
+	std := StdMap[string, int]{}
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range 2000 {
+		k := strconv.FormatInt(int64(r.IntN(500)), 3)
+		if r.IntN(3) == 0 {
+			assert.Equal(t, std.Delete(k), m.Delete(k))
+		} else {
+			std.Set(k, i)
+			m.Set(k, i)
+		}
+		if i%100 == 0 {
+			prefix := k[:r.IntN(len(k)+1)]
+			assert.Equal(t, deleteStdPrefix(std, prefix), m.DeletePrefix(prefix))
+		}
+	}
+	keys := std.Keys()
+	slices.Sort(keys)
+	assert.Equal(t, keys, m.Keys())
+	assert.True(t, m.Equal(std))
+}
+
+// deleteStdPrefix deletes the keys of m that start with prefix and returns how many were deleted.
+func deleteStdPrefix(m StdMap[string, int], prefix string) (count int) {
+	for k := range m {
+		if strings.HasPrefix(k, prefix) {
+			delete(m, k)
+			count++
+		}
+	}
+	return
+}
@@ -0,0 +1,452 @@
+package maps
+
+import (
+	"bytes"
+	"cmp"
+	"container/heap"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// errNoLess is returned when unmarshaling into a PriorityMap that was not created with NewPriorityMap.
+var errNoLess = errors.New("maps: cannot unmarshal into a PriorityMap with no less function")
+
+// PriorityMap is a map that keeps its items ordered by value, like a priority queue that can also
+// be accessed by key. The order is given by a Less function on the values.
+//
+// PopMin and PopMax remove the item with the lowest or highest value in O(log n) time, and changing the value
+// of a key with Set moves it to its new place in O(log n) time, which is what algorithms like Dijkstra's
+// need to decrease a key. Items with equal values come out in the order they were added.
+//
+// Range, Keys, Values and the iterators visit the items from the lowest value to the highest,
+// which takes O(n log n) time.
+//
+// The zero value is NOT settable. Use NewPriorityMap to create a PriorityMap.
+type PriorityMap[K comparable, V any] struct {
+	items map[K]*priorityEntry[K, V]
+	min   priorityHeap[K, V]
+	max   priorityHeap[K, V]
+	less  func(a, b V) bool
+	tick  uint64
+}
+
+type priorityEntry[K comparable, V any] struct {
+	key   K
+	value V
+	tick  uint64
+	index [2]int // the positions of the entry in the min and max heaps
+}
+
+// priorityHeap is a heap of entries. The min heap has side 0, and the max heap has side 1.
+type priorityHeap[K comparable, V any] struct {
+	entries []*priorityEntry[K, V]
+	side    int
+	less    func(a, b V) bool
+}
+
+func (h *priorityHeap[K, V]) Len() int {
+	return len(h.entries)
+}
+
+func (h *priorityHeap[K, V]) Less(i, j int) bool {
+	a, b := h.entries[i], h.entries[j]
+	if h.side == 1 {
+		a, b = b, a
+	}
+	if h.less(a.value, b.value) {
+		return true
+	}
+	if h.less(b.value, a.value) {
+		return false
+	}
+	return h.entries[i].tick < h.entries[j].tick
+}
+
+func (h *priorityHeap[K, V]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.entries[i].index[h.side] = i
+	h.entries[j].index[h.side] = j
+}
+
+func (h *priorityHeap[K, V]) Push(x any) {
+	e := x.(*priorityEntry[K, V])
+	e.index[h.side] = len(h.entries)
+	h.entries = append(h.entries, e)
+}
+
+func (h *priorityHeap[K, V]) Pop() any {
+	n := len(h.entries)
+	e := h.entries[n-1]
+	h.entries[n-1] = nil
+	h.entries = h.entries[:n-1]
+	return e
+}
+
+// NewPriorityMap creates a new PriorityMap that orders its values with less,
+// which returns true when a is lower than b.
+func NewPriorityMap[K comparable, V any](less func(a, b V) bool) *PriorityMap[K, V] {
+	if less == nil {
+		panic("a PriorityMap needs a less function")
+	}
+	return &PriorityMap[K, V]{
+		items: make(map[K]*priorityEntry[K, V]),
+		min:   priorityHeap[K, V]{side: 0, less: less},
+		max:   priorityHeap[K, V]{side: 1, less: less},
+		less:  less,
+	}
+}
+
+// Set sets the key to the given value, and moves the key to its place in the order.
+func (m *PriorityMap[K, V]) Set(k K, v V) {
+	if m.less == nil {
+		panic("cannot call Set() on a PriorityMap with no less function")
+	}
+	if e, ok := m.items[k]; ok {
+		e.value = v
+		heap.Fix(&m.min, e.index[0])
+		heap.Fix(&m.max, e.index[1])
+		return
+	}
+	m.tick++
+	e := &priorityEntry[K, V]{key: k, value: v, tick: m.tick}
+	m.items[k] = e
+	heap.Push(&m.min, e)
+	heap.Push(&m.max, e)
+}
+
+// remove removes the entry from the map.
+func (m *PriorityMap[K, V]) remove(e *priorityEntry[K, V]) {
+	heap.Remove(&m.min, e.index[0])
+	heap.Remove(&m.max, e.index[1])
+	delete(m.items, e.key)
+}
+
+// PeekMin returns the item with the lowest value without removing it. ok is false if the map is empty.
+func (m *PriorityMap[K, V]) PeekMin() (k K, v V, ok bool) {
+	if m.Len() == 0 {
+		return
+	}
+	e := m.min.entries[0]
+	return e.key, e.value, true
+}
+
+// PeekMax returns the item with the highest value without removing it. ok is false if the map is empty.
+func (m *PriorityMap[K, V]) PeekMax() (k K, v V, ok bool) {
+	if m.Len() == 0 {
+		return
+	}
+	e := m.max.entries[0]
+	return e.key, e.value, true
+}
+
+// PopMin removes and returns the item with the lowest value. ok is false if the map is empty.
+func (m *PriorityMap[K, V]) PopMin() (k K, v V, ok bool) {
+	if k, v, ok = m.PeekMin(); ok {
+		m.remove(m.min.entries[0])
+	}
+	return
+}
+
+// PopMax removes and returns the item with the highest value. ok is false if the map is empty.
+func (m *PriorityMap[K, V]) PopMax() (k K, v V, ok bool) {
+	if k, v, ok = m.PeekMax(); ok {
+		m.remove(m.max.entries[0])
+	}
+	return
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *PriorityMap[K, V]) Load(k K) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	var e *priorityEntry[K, V]
+	if e, ok = m.items[k]; ok {
+		v = e.value
+	}
+	return
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *PriorityMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the key exists.
+func (m *PriorityMap[K, V]) Has(k K) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *PriorityMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	if e, ok := m.items[k]; ok {
+		v = e.value
+		m.remove(e)
+	}
+	return
+}
+
+// Clear removes all the items in the map.
+func (m *PriorityMap[K, V]) Clear() {
+	if m == nil || m.less == nil {
+		return
+	}
+	m.items = make(map[K]*priorityEntry[K, V])
+	m.min.entries = nil
+	m.max.entries = nil
+}
+
+// Len returns the number of items in the map.
+func (m *PriorityMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items)
+}
+
+// sorted returns the entries from the lowest value to the highest.
+func (m *PriorityMap[K, V]) sorted() []*priorityEntry[K, V] {
+	if m.Len() == 0 {
+		return nil
+	}
+	entries := slices.Clone(m.min.entries)
+	slices.SortFunc(entries, func(a, b *priorityEntry[K, V]) int {
+		switch {
+		case m.less(a.value, b.value):
+			return -1
+		case m.less(b.value, a.value):
+			return 1
+		default:
+			return cmp.Compare(a.tick, b.tick)
+		}
+	})
+	return entries
+}
+
+// Range calls the given function for each key,value pair in the map, from the lowest value to the highest.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+func (m *PriorityMap[K, V]) Range(f func(k K, v V) bool) {
+	for _, e := range m.sorted() {
+		if !f(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map, from the lowest value to the highest.
+func (m *PriorityMap[K, V]) Keys() (keys []K) {
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return
+}
+
+// Values returns a new slice containing the values of the map, from the lowest to the highest.
+func (m *PriorityMap[K, V]) Values() (values []V) {
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *PriorityMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+func (m *PriorityMap[K, V]) Copy(in MapI[K, V]) {
+	if in == nil {
+		return
+	}
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal. The order is not compared.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *PriorityMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m2 == nil {
+		return m.Len() == 0
+	}
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k K, v V) bool {
+		if v2, ok := m.Load(k); !ok || !equalValues(v, v2) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// String outputs the map as a string, from the lowest value to the highest.
+func (m *PriorityMap[K, V]) String() string {
+	var s string
+
+	if m == nil {
+		return s
+	}
+
+	s = "{"
+	m.Range(func(k K, v V) bool {
+		s += fmt.Sprintf(`%#v:%#v,`, k, v)
+		return true
+	})
+	s = strings.TrimRight(s, ",")
+	s += "}"
+	return s
+}
+
+// std returns the items of the map as a standard map.
+func (m *PriorityMap[K, V]) std() map[K]V {
+	if m == nil {
+		return nil
+	}
+	s := make(map[K]V, len(m.items))
+	for k, e := range m.items {
+		s[k] = e.value
+	}
+	return s
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+// The less function is not serialized.
+func (m *PriorityMap[K, V]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(m.std())
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// PriorityMap. The map must have been created with NewPriorityMap, so that it has a less function.
+// Otherwise, an error is returned.
+func (m *PriorityMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var items StdMap[K, V]
+	if m.less == nil {
+		return errNoLess
+	}
+	if err = items.UnmarshalBinary(data); err == nil {
+		m.Clear()
+		m.Copy(items)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *PriorityMap[K, V]) MarshalJSON() (out []byte, err error) {
+	return json.Marshal(m.std())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a PriorityMap.
+// The JSON must start with an object. The map must have been created with NewPriorityMap, so that it has a less function.
+// Since JSON objects are unordered, the order of items with equal values is not determinate.
+// If the map has no less function, an error is returned.
+func (m *PriorityMap[K, V]) UnmarshalJSON(in []byte) (err error) {
+	var items StdMap[K, V]
+	if m.less == nil {
+		return errNoLess
+	}
+	if err = items.UnmarshalJSON(in); err == nil {
+		m.Clear()
+		m.Copy(items)
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map, from the lowest value to the highest.
+func (m *PriorityMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map, from the lowest value to the highest.
+func (m *PriorityMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map, from the lowest to the highest.
+func (m *PriorityMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *PriorityMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// CollectPriorityMap collects key-value pairs from seq into a new PriorityMap that orders its values with less,
+// and returns it.
+func CollectPriorityMap[K comparable, V any](less func(a, b V) bool, seq iter.Seq2[K, V]) *PriorityMap[K, V] {
+	m := NewPriorityMap[K](less)
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the PriorityMap, with the same less function and order. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *PriorityMap[K, V]) Clone() *PriorityMap[K, V] {
+	if m == nil || m.less == nil {
+		return new(PriorityMap[K, V])
+	}
+	m1 := NewPriorityMap[K](m.less)
+	m1.tick = m.tick
+	for _, e := range m.min.entries {
+		e1 := *e
+		m1.items[e.key] = &e1
+	}
+	m1.min.entries = make([]*priorityEntry[K, V], len(m.min.entries))
+	m1.max.entries = make([]*priorityEntry[K, V], len(m.max.entries))
+	for _, e1 := range m1.items {
+		m1.min.entries[e1.index[0]] = e1
+		m1.max.entries[e1.index[1]] = e1
+	}
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *PriorityMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil {
+		return
+	}
+	for _, e := range slices.Clone(m.min.entries) {
+		if del(e.key, e.value) {
+			m.remove(e)
+		}
+	}
+}
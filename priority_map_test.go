@@ -0,0 +1,143 @@
+package maps
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePriorityMap() {
+	// Dijkstra's algorithm on a small graph
+	edges := map[string]map[string]int{
+		"a": {"b": 4, "c": 1},
+		"c": {"b": 2, "d": 7},
+		"b": {"d": 1},
+	}
+	dist := map[string]int{"a": 0}
+	queue := NewPriorityMap[string](cmp.Less[int])
+	queue.Set("a", 0)
+	for queue.Len() > 0 {
+		node, d, _ := queue.PopMin()
+		for next, w := range edges[node] {
+			if old, ok := dist[next]; !ok || d+w < old {
+				dist[next] = d + w
+				queue.Set(next, d+w) // adds the node, or decreases its key
+			}
+		}
+	}
+	fmt.Println(dist["b"], dist["d"])
+	// Output: 3 4
+}
+
+func TestPriorityMap(t *testing.T) {
+	m := NewPriorityMap[string](cmp.Less[int])
+	m.Set("a", 5)
+	m.Set("b", 1)
+	m.Set("c", 9)
+	m.Set("d", 5)
+	assert.Equal(t, []string{"b", "a", "d", "c"}, m.Keys(), "equal values keep the order they were added")
+	assert.Equal(t, `{"b":1,"a":5,"d":5,"c":9}`, m.String())
+
+	m.Set("c", 0)
+	k, v, ok := m.PeekMin()
+	assert.Equal(t, "c", k)
+	assert.Equal(t, 0, v)
+	assert.True(t, ok)
+	k, v, _ = m.PeekMax()
+	assert.Equal(t, "a", k)
+	assert.Equal(t, 5, v)
+
+	k, _, _ = m.PopMax()
+	assert.Equal(t, "a", k)
+	k, _, _ = m.PopMax()
+	assert.Equal(t, "d", k)
+	k, _, _ = m.PopMin()
+	assert.Equal(t, "c", k)
+	assert.Equal(t, 1, m.Len())
+	assert.Equal(t, 1, m.Get("b"))
+	assert.Equal(t, 1, m.Delete("b"))
+	_, _, ok = m.PopMin()
+	assert.False(t, ok)
+	_, _, ok = m.PopMax()
+	assert.False(t, ok)
+}
+
+func TestPriorityMap_Random(t *testing.T) {
+	m := NewPriorityMap[int](cmp.Less[int])
+	std := StdMap[int, int]{}
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 2000 {
+		k, v := r.IntN(300), r.IntN(1000)
+		if r.IntN(4) == 0 {
+			assert.Equal(t, std.Delete(k), m.Delete(k))
+		} else {
+			m.Set(k, v)
+			std[k] = v
+		}
+	}
+	assert.True(t, m.Equal(std))
+	assert.True(t, slices.IsSorted(m.Values()))
+
+	m2 := m.Clone()
+	m2.DeleteFunc(func(k int, v int) bool {
+		return v%2 == 0
+	})
+	var values []int
+	for m2.Len() > 0 {
+		_, v, _ := m2.PopMin()
+		assert.Equal(t, 1, v%2)
+		values = append(values, v)
+	}
+	assert.True(t, slices.IsSorted(values))
+	assert.True(t, m.Equal(std), "the clone does not change the original")
+
+	var maxes []int
+	for m.Len() > 0 {
+		_, v, _ := m.PopMax()
+		maxes = append(maxes, v)
+	}
+	assert.True(t, slices.IsSortedFunc(maxes, func(a, b int) int { return b - a }))
+}
+
+func TestPriorityMap_Marshal(t *testing.T) {
+	m := CollectPriorityMap(cmp.Less[int], StdMap[string, int]{"a": 2, "b": 1}.All())
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+	m2 := NewPriorityMap[string](cmp.Less[int])
+	assert.NoError(t, json.Unmarshal(b, m2))
+	assert.True(t, m.Equal(m2))
+	assert.Equal(t, []string{"b", "a"}, m2.Keys())
+
+	b, err = m.MarshalBinary()
+	assert.NoError(t, err)
+	m3 := NewPriorityMap[string](cmp.Less[int])
+	assert.NoError(t, m3.UnmarshalBinary(b))
+	assert.True(t, m.Equal(m3))
+
+	var m4 PriorityMap[string, int]
+	assert.Error(t, m4.UnmarshalBinary(b))
+	assert.Error(t, json.Unmarshal([]byte(`{"a":1}`), &m4))
+}
+
+func TestPriorityMap_Nil(t *testing.T) {
+	var m *PriorityMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.Nil(t, m.Keys())
+	assert.Equal(t, "", m.String())
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, 0, m.Clone().Len())
+	_, _, ok := m.PopMin()
+	assert.False(t, ok)
+
+	assert.Panics(t, func() {
+		new(PriorityMap[string, int]).Set("a", 1)
+	})
+}
@@ -0,0 +1,232 @@
+package maps
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Interval is the half-open range of values from Lo up to, but not including, Hi.
+// An Interval with Lo >= Hi is empty.
+type Interval[K cmp.Ordered] struct {
+	Lo K
+	Hi K
+}
+
+// Contains returns true if k is in the interval.
+func (i Interval[K]) Contains(k K) bool {
+	return i.Lo <= k && k < i.Hi
+}
+
+// String returns the interval as a string, like [1,5).
+func (i Interval[K]) String() string {
+	return fmt.Sprintf("[%#v,%#v)", i.Lo, i.Hi)
+}
+
+// RangeSet is a set of values that is made of ranges rather than single values, like a list of
+// allowed IP addresses or of time windows. Each range is a half-open Interval.
+//
+// Adding a range that overlaps or touches the ranges already in the set joins them into one range,
+// so the set always has the fewest ranges that cover its values. The ranges are kept in order.
+//
+// The zero value is ready to use. Do not make a copy of a RangeSet using the equality operator (=). Use Clone instead.
+type RangeSet[K cmp.Ordered] struct {
+	ranges []Interval[K]
+}
+
+// NewRangeSet creates a new RangeSet with the given intervals.
+func NewRangeSet[K cmp.Ordered](intervals ...Interval[K]) *RangeSet[K] {
+	m := new(RangeSet[K])
+	for _, i := range intervals {
+		m.AddRange(i.Lo, i.Hi)
+	}
+	return m
+}
+
+// search returns the position of the first range for which f returns true.
+func (m *RangeSet[K]) search(f func(i Interval[K]) bool) int {
+	return sort.Search(len(m.ranges), func(i int) bool {
+		return f(m.ranges[i])
+	})
+}
+
+// AddRange adds the values from lo up to, but not including, hi. If lo >= hi, nothing is added.
+func (m *RangeSet[K]) AddRange(lo, hi K) {
+	if !(lo < hi) {
+		return
+	}
+	// i is the first range that ends at or after lo, and j is the first range that starts after hi.
+	// The ranges from i up to j overlap or touch the new range.
+	i := m.search(func(r Interval[K]) bool { return r.Hi >= lo })
+	j := m.search(func(r Interval[K]) bool { return r.Lo > hi })
+	if i < j {
+		lo = min(lo, m.ranges[i].Lo)
+		hi = max(hi, m.ranges[j-1].Hi)
+	}
+	m.ranges = slices.Replace(m.ranges, i, j, Interval[K]{lo, hi})
+}
+
+// DeleteRange removes the values from lo up to, but not including, hi. If lo >= hi, nothing is removed.
+func (m *RangeSet[K]) DeleteRange(lo, hi K) {
+	if m == nil || !(lo < hi) {
+		return
+	}
+	// i is the first range that ends after lo, and j is the first range that starts at or after hi.
+	// The ranges from i up to j overlap the deleted range.
+	i := m.search(func(r Interval[K]) bool { return r.Hi > lo })
+	j := m.search(func(r Interval[K]) bool { return r.Lo >= hi })
+	if i >= j {
+		return
+	}
+	var rest []Interval[K]
+	if first := m.ranges[i]; first.Lo < lo {
+		rest = append(rest, Interval[K]{first.Lo, lo})
+	}
+	if last := m.ranges[j-1]; last.Hi > hi {
+		rest = append(rest, Interval[K]{hi, last.Hi})
+	}
+	m.ranges = slices.Replace(m.ranges, i, j, rest...)
+}
+
+// Contains returns true if k is in one of the ranges of the set.
+func (m *RangeSet[K]) Contains(k K) bool {
+	if m == nil {
+		return false
+	}
+	i := m.search(func(r Interval[K]) bool { return r.Hi > k })
+	return i < len(m.ranges) && m.ranges[i].Lo <= k
+}
+
+// ContainsRange returns true if all the values from lo up to, but not including, hi are in the set.
+// An empty range is always contained.
+func (m *RangeSet[K]) ContainsRange(lo, hi K) bool {
+	if !(lo < hi) {
+		return true
+	}
+	if m == nil {
+		return false
+	}
+	i := m.search(func(r Interval[K]) bool { return r.Hi > lo })
+	return i < len(m.ranges) && m.ranges[i].Lo <= lo && m.ranges[i].Hi >= hi
+}
+
+// Find returns the range that contains k, and false if k is not in the set.
+func (m *RangeSet[K]) Find(k K) (Interval[K], bool) {
+	if m == nil {
+		return Interval[K]{}, false
+	}
+	i := m.search(func(r Interval[K]) bool { return r.Hi > k })
+	if i < len(m.ranges) && m.ranges[i].Lo <= k {
+		return m.ranges[i], true
+	}
+	return Interval[K]{}, false
+}
+
+// Clear removes all the ranges in the set.
+func (m *RangeSet[K]) Clear() {
+	if m == nil {
+		return
+	}
+	m.ranges = nil
+}
+
+// Len returns the number of ranges in the set. Ranges that touch are joined, so they count as one range.
+func (m *RangeSet[K]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.ranges)
+}
+
+// Intervals returns a new slice containing the ranges of the set, in order.
+func (m *RangeSet[K]) Intervals() []Interval[K] {
+	if m == nil {
+		return nil
+	}
+	return slices.Clone(m.ranges)
+}
+
+// All returns an iterator over the ranges of the set, in order.
+func (m *RangeSet[K]) All() iter.Seq[Interval[K]] {
+	return func(yield func(Interval[K]) bool) {
+		for _, r := range m.Intervals() {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// Equal returns true if the two sets have the same ranges.
+func (m *RangeSet[K]) Equal(m2 *RangeSet[K]) bool {
+	if m.Len() != m2.Len() {
+		return false
+	}
+	return m.Len() == 0 || slices.Equal(m.ranges, m2.ranges)
+}
+
+// String returns the set as a string, like {[1,5),[7,9)}.
+func (m *RangeSet[K]) String() string {
+	var s []string
+	for _, r := range m.Intervals() {
+		s = append(s, r.String())
+	}
+	return "{" + strings.Join(s, ",") + "}"
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the set to a byte stream.
+func (m *RangeSet[K]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(m.Intervals())
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a RangeSet.
+// The ranges are added to the set.
+func (m *RangeSet[K]) UnmarshalBinary(data []byte) (err error) {
+	var ranges []Interval[K]
+
+	b := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(b)
+	if err = dec.Decode(&ranges); err == nil {
+		for _, r := range ranges {
+			m.AddRange(r.Lo, r.Hi)
+		}
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the set into a JSON array of ranges,
+// where each range is an array of its low and high values, like [[1,5],[7,9]].
+func (m *RangeSet[K]) MarshalJSON() (out []byte, err error) {
+	ranges := make([][2]K, 0, m.Len())
+	for _, r := range m.Intervals() {
+		ranges = append(ranges, [2]K{r.Lo, r.Hi})
+	}
+	return json.Marshal(ranges)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a JSON array of ranges to a RangeSet.
+// The ranges are added to the set.
+func (m *RangeSet[K]) UnmarshalJSON(in []byte) (err error) {
+	var ranges [][2]K
+	if err = json.Unmarshal(in, &ranges); err == nil {
+		for _, r := range ranges {
+			m.AddRange(r[0], r[1])
+		}
+	}
+	return
+}
+
+// Clone returns a copy of the RangeSet.
+func (m *RangeSet[K]) Clone() *RangeSet[K] {
+	return &RangeSet[K]{ranges: m.Intervals()}
+}
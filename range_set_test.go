@@ -0,0 +1,131 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleRangeSet() {
+	s := new(RangeSet[int])
+	s.AddRange(1, 5)
+	s.AddRange(5, 8)
+	s.AddRange(10, 12)
+	fmt.Println(s, s.Contains(7), s.Contains(8))
+	// Output: {[1,8),[10,12)} true false
+}
+
+func TestRangeSet_AddRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		add      [][2]int
+		expected string
+	}{
+		{"empty", [][2]int{{3, 3}, {5, 2}}, "{}"},
+		{"separate", [][2]int{{5, 7}, {1, 3}}, "{[1,3),[5,7)}"},
+		{"touching", [][2]int{{1, 3}, {3, 5}}, "{[1,5)}"},
+		{"overlapping", [][2]int{{1, 4}, {3, 6}}, "{[1,6)}"},
+		{"inside", [][2]int{{1, 10}, {3, 6}}, "{[1,10)}"},
+		{"covering", [][2]int{{3, 4}, {6, 7}, {1, 10}}, "{[1,10)}"},
+		{"bridging", [][2]int{{1, 3}, {5, 7}, {9, 11}, {2, 6}}, "{[1,7),[9,11)}"},
+		{"bridging touch", [][2]int{{1, 3}, {5, 7}, {3, 5}}, "{[1,7)}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s RangeSet[int]
+			for _, r := range tt.add {
+				s.AddRange(r[0], r[1])
+			}
+			assert.Equal(t, tt.expected, s.String())
+		})
+	}
+}
+
+func TestRangeSet_DeleteRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		lo, hi   int
+		expected string
+	}{
+		{"empty", 4, 4, "{[1,5),[7,10)}"},
+		{"outside", 5, 7, "{[1,5),[7,10)}"},
+		{"middle", 2, 3, "{[1,2),[3,5),[7,10)}"},
+		{"start", 0, 2, "{[2,5),[7,10)}"},
+		{"across", 3, 8, "{[1,3),[8,10)}"},
+		{"all", 0, 20, "{}"},
+		{"exact", 7, 10, "{[1,5)}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewRangeSet(Interval[int]{1, 5}, Interval[int]{7, 10})
+			s.DeleteRange(tt.lo, tt.hi)
+			assert.Equal(t, tt.expected, s.String())
+		})
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	s := NewRangeSet(Interval[float64]{0.5, 1}, Interval[float64]{2, 3})
+	assert.True(t, s.Contains(0.5))
+	assert.False(t, s.Contains(1))
+	assert.False(t, s.Contains(1.5))
+	assert.False(t, s.Contains(5))
+	assert.True(t, s.ContainsRange(2, 3))
+	assert.True(t, s.ContainsRange(2.5, 2.6))
+	assert.False(t, s.ContainsRange(0.5, 2.5))
+	assert.True(t, s.ContainsRange(9, 9))
+
+	r, ok := s.Find(2.5)
+	assert.True(t, ok)
+	assert.Equal(t, Interval[float64]{2, 3}, r)
+	assert.True(t, r.Contains(2))
+	_, ok = s.Find(1.5)
+	assert.False(t, ok)
+
+	assert.Equal(t, 2, s.Len())
+	var intervals []Interval[float64]
+	for r := range s.All() {
+		intervals = append(intervals, r)
+	}
+	assert.Equal(t, s.Intervals(), intervals)
+
+	s2 := s.Clone()
+	assert.True(t, s.Equal(s2))
+	s2.AddRange(1, 2)
+	assert.False(t, s.Equal(s2))
+	assert.Equal(t, 1, s2.Len())
+	s2.Clear()
+	assert.Equal(t, 0, s2.Len())
+}
+
+func TestRangeSet_Marshal(t *testing.T) {
+	s := NewRangeSet(Interval[int]{1, 5}, Interval[int]{7, 10})
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "[[1,5],[7,10]]", string(b))
+	var s2 RangeSet[int]
+	assert.NoError(t, json.Unmarshal(b, &s2))
+	assert.True(t, s.Equal(&s2))
+
+	b, err = s.MarshalBinary()
+	assert.NoError(t, err)
+	var s3 RangeSet[int]
+	assert.NoError(t, s3.UnmarshalBinary(b))
+	assert.True(t, s.Equal(&s3))
+}
+
+func TestRangeSet_Nil(t *testing.T) {
+	var s *RangeSet[string]
+	assert.Equal(t, 0, s.Len())
+	assert.False(t, s.Contains("a"))
+	assert.False(t, s.ContainsRange("a", "b"))
+	assert.Nil(t, s.Intervals())
+	assert.True(t, s.Equal(nil))
+	assert.True(t, s.Equal(new(RangeSet[string])))
+	assert.Equal(t, "{}", s.String())
+	assert.Equal(t, 0, s.Clone().Len())
+	s.DeleteRange("a", "b")
+}
@@ -0,0 +1,266 @@
+package maps
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// ReadMostlyMap is a map that is safe for concurrent use and that is optimized for maps that are read
+// much more often than they are written, like configuration lookups.
+//
+// Readers use an immutable snapshot of the map that is published atomically, so reads never lock.
+// Writers make a copy of the map, change the copy, and then publish it as the new snapshot.
+// Writes are serialized by a mutex, and since every write copies the entire map, writes are expensive.
+// To make several changes with only one copy, use Do.
+//
+// Ranging over a ReadMostlyMap ranges over the snapshot that was current when the range started,
+// so the range function may call any method of the map, including ones that change it.
+//
+// The zero value is ready to use. Do not make a copy of a ReadMostlyMap using the equality operator (=). Use Clone instead.
+type ReadMostlyMap[K comparable, V any] struct {
+	mu    sync.Mutex // serializes writers
+	items atomic.Pointer[StdMap[K, V]]
+}
+
+// NewReadMostlyMap creates a new ReadMostlyMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new ReadMostlyMap.
+func NewReadMostlyMap[K comparable, V any](sources ...map[K]V) *ReadMostlyMap[K, V] {
+	m := new(ReadMostlyMap[K, V])
+	items := make(StdMap[K, V], sourcesLen(sources))
+	for _, i := range sources {
+		items.Copy(Cast(i))
+	}
+	m.items.Store(&items)
+	return m
+}
+
+// snapshot returns the current contents of the map, which must not be changed.
+func (m *ReadMostlyMap[K, V]) snapshot() StdMap[K, V] {
+	if m == nil {
+		return nil
+	}
+	if p := m.items.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// write calls f with a copy of the current contents of the map, and then publishes the copy.
+func (m *ReadMostlyMap[K, V]) write(f func(items StdMap[K, V])) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items := m.snapshot().Clone()
+	if items == nil {
+		items = StdMap[K, V]{}
+	}
+	f(items)
+	m.items.Store(&items)
+}
+
+// Do calls f with a writable copy of the map, and then publishes the copy when f returns.
+// Use this to make several changes to the map with only one copy of the map.
+// Readers will not see any of the changes until f returns.
+//
+// The view is only valid during the call to f and must not be kept after f returns.
+// Other writers are blocked while f is called, so f must not call methods of the ReadMostlyMap
+// that change it.
+func (m *ReadMostlyMap[K, V]) Do(f func(view MapI[K, V])) {
+	m.write(func(items StdMap[K, V]) {
+		f(items)
+	})
+}
+
+// Clear resets the map to an empty map.
+func (m *ReadMostlyMap[K, V]) Clear() {
+	m.mu.Lock()
+	m.items.Store(nil)
+	m.mu.Unlock()
+}
+
+// Set sets the key to the given value.
+func (m *ReadMostlyMap[K, V]) Set(k K, v V) {
+	m.write(func(items StdMap[K, V]) {
+		items[k] = v
+	})
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load(). Load does not lock.
+func (m *ReadMostlyMap[K, V]) Load(k K) (v V, ok bool) {
+	return m.snapshot().Load(k)
+}
+
+// Get returns the value based on its key. If the key does not exist, the zero value will be returned.
+// Get does not lock.
+func (m *ReadMostlyMap[K, V]) Get(k K) (v V) {
+	return m.snapshot().Get(k)
+}
+
+// Has returns true if the given key exists in the map. Has does not lock.
+func (m *ReadMostlyMap[K, V]) Has(k K) (exists bool) {
+	return m.snapshot().Has(k)
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *ReadMostlyMap[K, V]) Delete(k K) (v V) {
+	v, _ = m.LoadAndDelete(k)
+	return
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+func (m *ReadMostlyMap[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	m.write(func(items StdMap[K, V]) {
+		previous, loaded = items.Swap(k, v)
+	})
+	return
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m *ReadMostlyMap[K, V]) LoadAndDelete(k K) (v V, loaded bool) {
+	if !m.Has(k) {
+		return // prevent an unnecessary copy
+	}
+	m.write(func(items StdMap[K, V]) {
+		v, loaded = items.LoadAndDelete(k)
+	})
+	return
+}
+
+// Values returns a slice of the values. It will return a nil slice if the map is empty.
+func (m *ReadMostlyMap[K, V]) Values() []V {
+	return m.snapshot().Values()
+}
+
+// Keys returns a slice of the keys. It will return a nil slice if the map is empty.
+func (m *ReadMostlyMap[K, V]) Keys() []K {
+	return m.snapshot().Keys()
+}
+
+// Len returns the number of items in the map.
+func (m *ReadMostlyMap[K, V]) Len() int {
+	return m.snapshot().Len()
+}
+
+// Range will call the given function with every key and value in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+// The range is over a snapshot of the map, so f may call any method of the map.
+func (m *ReadMostlyMap[K, V]) Range(f func(k K, v V) bool) {
+	m.snapshot().Range(f)
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Use Copy instead.
+func (m *ReadMostlyMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the keys and values of in into this map, overwriting any duplicates.
+func (m *ReadMostlyMap[K, V]) Copy(in MapI[K, V]) {
+	if in == nil || in.Len() == 0 {
+		return
+	}
+	m.write(func(items StdMap[K, V]) {
+		items.Copy(in)
+	})
+}
+
+// Equal returns true if all the keys and values are equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *ReadMostlyMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	return m.snapshot().Equal(m2)
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *ReadMostlyMap[K, V]) MarshalBinary() ([]byte, error) {
+	return m.snapshot().MarshalBinary()
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// ReadMostlyMap.
+func (m *ReadMostlyMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var items StdMap[K, V]
+	if err = items.UnmarshalBinary(data); err == nil {
+		m.mu.Lock()
+		m.items.Store(&items)
+		m.mu.Unlock()
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *ReadMostlyMap[K, V]) MarshalJSON() (out []byte, err error) {
+	return m.snapshot().MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a ReadMostlyMap.
+// The JSON must start with an object.
+func (m *ReadMostlyMap[K, V]) UnmarshalJSON(in []byte) (err error) {
+	var items StdMap[K, V]
+	if err = items.UnmarshalJSON(in); err == nil {
+		m.mu.Lock()
+		m.items.Store(&items)
+		m.mu.Unlock()
+	}
+	return
+}
+
+// String outputs the map as a string.
+func (m *ReadMostlyMap[K, V]) String() string {
+	return m.snapshot().String()
+}
+
+// All returns an iterator over all the items in the map.
+// The iteration is over a snapshot of the map, so the loop may call any method of the map.
+func (m *ReadMostlyMap[K, V]) All() iter.Seq2[K, V] {
+	return m.snapshot().All()
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+// The iteration is over a snapshot of the map, so the loop may call any method of the map.
+func (m *ReadMostlyMap[K, V]) KeysIter() iter.Seq[K] {
+	return m.snapshot().KeysIter()
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+// The iteration is over a snapshot of the map, so the loop may call any method of the map.
+func (m *ReadMostlyMap[K, V]) ValuesIter() iter.Seq[V] {
+	return m.snapshot().ValuesIter()
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *ReadMostlyMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	m.write(func(items StdMap[K, V]) {
+		items.Insert(seq)
+	})
+}
+
+// CollectReadMostlyMap collects key-value pairs from seq into a new ReadMostlyMap
+// and returns it.
+func CollectReadMostlyMap[K comparable, V any](seq iter.Seq2[K, V]) *ReadMostlyMap[K, V] {
+	m := new(ReadMostlyMap[K, V])
+	items := CollectStdMap(seq)
+	m.items.Store(&items)
+	return m
+}
+
+// Clone returns a copy of the ReadMostlyMap. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *ReadMostlyMap[K, V]) Clone() *ReadMostlyMap[K, V] {
+	m1 := new(ReadMostlyMap[K, V])
+	items := m.snapshot().Clone()
+	m1.items.Store(&items)
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *ReadMostlyMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	m.write(func(items StdMap[K, V]) {
+		items.DeleteFunc(del)
+	})
+}
@@ -0,0 +1,83 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadMostlyMap_Mapi(t *testing.T) {
+	runMapiTests[ReadMostlyMap[string, int]](t, makeMapi[ReadMostlyMap[string, int]])
+}
+
+func init() {
+	gob.Register(new(ReadMostlyMap[string, int]))
+}
+
+func ExampleReadMostlyMap_Do() {
+	m := NewReadMostlyMap(map[string]int{"a": 1})
+	// make several changes with one copy of the map
+	m.Do(func(view MapI[string, int]) {
+		view.Set("b", 2)
+		view.Delete("a")
+	})
+	fmt.Print(m)
+	// Output: {"b":2}
+}
+
+func TestReadMostlyMap(t *testing.T) {
+	m := NewReadMostlyMap(map[string]int{"a": 1, "b": 2})
+
+	// snapshots are not changed by later writes
+	s := m.All()
+	m.Set("c", 3)
+	count := 0
+	for range s {
+		count++
+	}
+	assert.Equal(t, 2, count)
+
+	// changing the map while ranging
+	m.Range(func(k string, v int) bool {
+		m.Delete(k)
+		return true
+	})
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Delete("a"))
+
+	m2 := CollectReadMostlyMap(StdMap[string, int]{"a": 1}.All())
+	m3 := m2.Clone()
+	m3.Set("b", 2)
+	assert.Equal(t, 1, m2.Len())
+	assert.Equal(t, 2, m3.Len())
+
+	var m4 ReadMostlyMap[string, int]
+	assert.Equal(t, 0, m4.Clone().Len())
+}
+
+func TestReadMostlyMap_Concurrent(t *testing.T) {
+	m := new(ReadMostlyMap[string, int])
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				m.Set(strconv.Itoa(j), j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				m.Get(strconv.Itoa(j))
+				m.Len()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, m.Len())
+}
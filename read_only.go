@@ -0,0 +1,235 @@
+package maps
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+)
+
+// ErrReadOnly is the value passed to panic when a method that would change a ReadOnlyMap or ReadOnlySet is called.
+// Methods that have an error result, like UnmarshalJSON, return it instead.
+var ErrReadOnly = errors.New("maps: cannot change a read-only view")
+
+// ReadOnlyMap is a read-only view of another map. Its methods that read the map are passed on to the
+// underlying map, and its methods that would change the map panic with ErrReadOnly.
+//
+// Use it to hand a map to code that must not change it, without copying the map.
+// Since it is a view, changes made to the underlying map through other references are seen by the view.
+// To hand out contents that never change, copy the map first, or use a COWMap snapshot.
+//
+// ReadOnlyMap implements MapI, so it can be passed to functions that take a MapI.
+type ReadOnlyMap[K comparable, V any] struct {
+	m MapReader[K, V]
+}
+
+// NewReadOnlyMap returns a read-only view of m.
+func NewReadOnlyMap[K comparable, V any](m MapReader[K, V]) *ReadOnlyMap[K, V] {
+	if r, ok := m.(*ReadOnlyMap[K, V]); ok {
+		return r
+	}
+	return &ReadOnlyMap[K, V]{m: m}
+}
+
+// Get returns the value based on its key. If the key does not exist, the zero value will be returned.
+func (r *ReadOnlyMap[K, V]) Get(k K) (v V) {
+	return r.m.Get(k)
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+func (r *ReadOnlyMap[K, V]) Load(k K) (v V, ok bool) {
+	return r.m.Load(k)
+}
+
+// Has returns true if the given key exists in the map.
+func (r *ReadOnlyMap[K, V]) Has(k K) bool {
+	return r.m.Has(k)
+}
+
+// Len returns the number of items in the map.
+func (r *ReadOnlyMap[K, V]) Len() int {
+	return r.m.Len()
+}
+
+// Range calls f with every key and value in the map, in the order of the underlying map.
+// If f returns false, it stops the iteration.
+func (r *ReadOnlyMap[K, V]) Range(f func(k K, v V) bool) {
+	r.m.Range(f)
+}
+
+// Keys returns a slice of the keys.
+func (r *ReadOnlyMap[K, V]) Keys() []K {
+	return r.m.Keys()
+}
+
+// Values returns a slice of the values.
+func (r *ReadOnlyMap[K, V]) Values() []V {
+	return r.m.Values()
+}
+
+// Equal returns true if all the keys and values are equal.
+func (r *ReadOnlyMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	return r.m.Equal(m2)
+}
+
+// All returns an iterator over all the items in the map.
+func (r *ReadOnlyMap[K, V]) All() iter.Seq2[K, V] {
+	return r.m.All()
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+func (r *ReadOnlyMap[K, V]) KeysIter() iter.Seq[K] {
+	return r.m.KeysIter()
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+func (r *ReadOnlyMap[K, V]) ValuesIter() iter.Seq[V] {
+	return r.m.ValuesIter()
+}
+
+// String outputs the map as a string.
+func (r *ReadOnlyMap[K, V]) String() string {
+	return r.m.String()
+}
+
+// MarshalJSON implements the json.Marshaler interface by marshaling the underlying map.
+func (r *ReadOnlyMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.m)
+}
+
+// Set panics with ErrReadOnly.
+func (r *ReadOnlyMap[K, V]) Set(K, V) {
+	panic(ErrReadOnly)
+}
+
+// Clear panics with ErrReadOnly.
+func (r *ReadOnlyMap[K, V]) Clear() {
+	panic(ErrReadOnly)
+}
+
+// Merge panics with ErrReadOnly.
+func (r *ReadOnlyMap[K, V]) Merge(MapI[K, V]) {
+	panic(ErrReadOnly)
+}
+
+// Copy panics with ErrReadOnly.
+func (r *ReadOnlyMap[K, V]) Copy(MapI[K, V]) {
+	panic(ErrReadOnly)
+}
+
+// Delete panics with ErrReadOnly.
+func (r *ReadOnlyMap[K, V]) Delete(K) V {
+	panic(ErrReadOnly)
+}
+
+// Insert panics with ErrReadOnly.
+func (r *ReadOnlyMap[K, V]) Insert(iter.Seq2[K, V]) {
+	panic(ErrReadOnly)
+}
+
+// DeleteFunc panics with ErrReadOnly.
+func (r *ReadOnlyMap[K, V]) DeleteFunc(func(K, V) bool) {
+	panic(ErrReadOnly)
+}
+
+// ReadOnlySet is a read-only view of another set. Its methods that read the set are passed on to the
+// underlying set, and its methods that would change the set panic with ErrReadOnly.
+//
+// Since it is a view, changes made to the underlying set through other references are seen by the view.
+//
+// ReadOnlySet implements SetI, so it can be passed to functions that take a SetI.
+type ReadOnlySet[K comparable] struct {
+	s SetReader[K]
+}
+
+// NewReadOnlySet returns a read-only view of s.
+func NewReadOnlySet[K comparable](s SetReader[K]) *ReadOnlySet[K] {
+	if r, ok := s.(*ReadOnlySet[K]); ok {
+		return r
+	}
+	return &ReadOnlySet[K]{s: s}
+}
+
+// Has returns true if the value exists in the set.
+func (r *ReadOnlySet[K]) Has(k K) bool {
+	return r.s.Has(k)
+}
+
+// Len returns the number of items in the set.
+func (r *ReadOnlySet[K]) Len() int {
+	return r.s.Len()
+}
+
+// Range calls f with every member of the set. If f returns false, it stops the iteration.
+func (r *ReadOnlySet[K]) Range(f func(k K) bool) {
+	r.s.Range(f)
+}
+
+// Values returns the members of the set as a slice.
+func (r *ReadOnlySet[K]) Values() []K {
+	return r.s.Values()
+}
+
+// Equal returns true if the two sets have the same members.
+func (r *ReadOnlySet[K]) Equal(s2 SetI[K]) bool {
+	return r.s.Equal(s2)
+}
+
+// All returns an iterator over the members of the set.
+func (r *ReadOnlySet[K]) All() iter.Seq[K] {
+	return r.s.All()
+}
+
+// String outputs the set as a string.
+func (r *ReadOnlySet[K]) String() string {
+	return r.s.String()
+}
+
+// MarshalJSON implements the json.Marshaler interface by marshaling the underlying set.
+func (r *ReadOnlySet[K]) MarshalJSON() ([]byte, error) {
+	return r.s.MarshalJSON()
+}
+
+// MarshalBinary implements the BinaryMarshaler interface by marshaling the underlying set.
+func (r *ReadOnlySet[K]) MarshalBinary() ([]byte, error) {
+	return r.s.MarshalBinary()
+}
+
+// UnmarshalJSON returns ErrReadOnly.
+func (r *ReadOnlySet[K]) UnmarshalJSON([]byte) error {
+	return ErrReadOnly
+}
+
+// UnmarshalBinary returns ErrReadOnly.
+func (r *ReadOnlySet[K]) UnmarshalBinary([]byte) error {
+	return ErrReadOnly
+}
+
+// Add panics with ErrReadOnly.
+func (r *ReadOnlySet[K]) Add(...K) SetI[K] {
+	panic(ErrReadOnly)
+}
+
+// Clear panics with ErrReadOnly.
+func (r *ReadOnlySet[K]) Clear() {
+	panic(ErrReadOnly)
+}
+
+// Merge panics with ErrReadOnly.
+func (r *ReadOnlySet[K]) Merge(SetI[K]) {
+	panic(ErrReadOnly)
+}
+
+// Delete panics with ErrReadOnly.
+func (r *ReadOnlySet[K]) Delete(K) {
+	panic(ErrReadOnly)
+}
+
+// Insert panics with ErrReadOnly.
+func (r *ReadOnlySet[K]) Insert(iter.Seq[K]) {
+	panic(ErrReadOnly)
+}
+
+// DeleteFunc panics with ErrReadOnly.
+func (r *ReadOnlySet[K]) DeleteFunc(func(K) bool) {
+	panic(ErrReadOnly)
+}
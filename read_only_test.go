@@ -0,0 +1,127 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleMap_AsReadOnly() {
+	m := NewMap(map[string]int{"a": 1})
+	r := m.AsReadOnly()
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+	m.Set("b", 2)
+	fmt.Println(r.Get("b"))
+	r.Set("c", 3)
+	// Output: 2
+	// maps: cannot change a read-only view
+}
+
+func TestReadOnlyMap(t *testing.T) {
+	m := NewSliceMap[string, int]()
+	m.Set("b", 2)
+	m.Set("a", 1)
+	r := m.AsReadOnly()
+	assert.Same(t, r, NewReadOnlyMap[string, int](r))
+
+	assert.Equal(t, 1, r.Get("a"))
+	v, ok := r.Load("b")
+	assert.Equal(t, 2, v)
+	assert.True(t, ok)
+	assert.True(t, r.Has("a"))
+	assert.Equal(t, 2, r.Len())
+	assert.Equal(t, []string{"b", "a"}, r.Keys())
+	assert.Equal(t, []int{2, 1}, r.Values())
+	assert.True(t, r.Equal(StdMap[string, int]{"a": 1, "b": 2}))
+	assert.Equal(t, `{"b":2,"a":1}`, r.String())
+	var keys []string
+	r.Range(func(k string, v int) bool {
+		keys = append(keys, k)
+		return true
+	})
+	for k := range r.KeysIter() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"b", "a", "b", "a"}, keys)
+	n := 0
+	for range r.All() {
+		n++
+	}
+	for range r.ValuesIter() {
+		n++
+	}
+	assert.Equal(t, 4, n)
+
+	b, err := json.Marshal(r)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(b))
+
+	m2 := NewMap[string, int]()
+	m2.Copy(r) // a read-only view can be used as a source
+	assert.True(t, m2.Equal(m))
+
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Set("c", 3) })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Clear() })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Merge(m2) })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Copy(m2) })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Delete("a") })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Insert(m2.All()) })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.DeleteFunc(func(string, int) bool { return true }) })
+	assert.Equal(t, 2, m.Len())
+
+	var views = []MapI[string, int]{
+		StdMap[string, int]{"a": 1}.AsReadOnly(),
+		NewMap(map[string]int{"a": 1}).AsReadOnly(),
+		NewSafeMap(map[string]int{"a": 1}).AsReadOnly(),
+		NewSafeSliceMap(map[string]int{"a": 1}).AsReadOnly(),
+		NewReadOnlyMap(NewCOWMap(map[string]int{"a": 1}).Snapshot()),
+	}
+	for _, v := range views {
+		assert.Equal(t, 1, v.Get("a"))
+		assert.Panics(t, func() { v.Set("a", 2) })
+	}
+}
+
+func TestReadOnlySet(t *testing.T) {
+	s := NewSet("a", "b")
+	r := s.AsReadOnly()
+	assert.Same(t, r, NewReadOnlySet[string](r))
+
+	assert.True(t, r.Has("a"))
+	assert.Equal(t, 2, r.Len())
+	assert.ElementsMatch(t, []string{"a", "b"}, r.Values())
+	assert.True(t, r.Equal(NewSet("b", "a")))
+	assert.Equal(t, slices.Sorted(s.All()), slices.Sorted(r.All()))
+	assert.Contains(t, r.String(), `"a"`)
+	n := 0
+	r.Range(func(string) bool {
+		n++
+		return true
+	})
+	for range r.All() {
+		n++
+	}
+	assert.Equal(t, 4, n)
+	b, err := json.Marshal(r)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"a"`)
+
+	s.Add("c")
+	assert.True(t, r.Has("c"), "the view sees changes to the set")
+
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Add("d") })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Clear() })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Merge(s) })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Delete("a") })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.Insert(s.All()) })
+	assert.PanicsWithValue(t, ErrReadOnly, func() { r.DeleteFunc(func(string) bool { return true }) })
+	assert.ErrorIs(t, json.Unmarshal([]byte(`["x"]`), r), ErrReadOnly)
+	assert.ErrorIs(t, r.UnmarshalBinary(nil), ErrReadOnly)
+	assert.Equal(t, 3, s.Len())
+}
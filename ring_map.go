@@ -0,0 +1,413 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// RingMap is a map with a fixed capacity that remembers the order its keys were added, like a ring buffer.
+// When a new key is added to a full RingMap, the oldest item is removed to make room for it.
+// Ranging over a RingMap goes from the oldest item to the newest.
+//
+// Setting a value for a key that already exists changes the value, but not the key's position in the map.
+//
+// The zero value is NOT settable. Use NewRingMap to create a RingMap with a capacity.
+//
+// RingMap is useful for tracking the last N items by key, like the most recent events by ID.
+type RingMap[K comparable, V any] struct {
+	items StdMap[K, V]
+	ring  []K
+	start int
+	count int
+
+	onEvict func(k K, v V, reason EvictReason)
+}
+
+// NewRingMap creates a new RingMap that can hold up to capacity items.
+func NewRingMap[K comparable, V any](capacity int) *RingMap[K, V] {
+	if capacity <= 0 {
+		panic("the capacity of a RingMap must be greater than zero")
+	}
+	return &RingMap[K, V]{
+		items: make(map[K]V, capacity),
+		ring:  make([]K, capacity),
+	}
+}
+
+// Cap returns the maximum number of items the map can hold.
+func (m *RingMap[K, V]) Cap() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.ring)
+}
+
+// OnEvict sets a function that is called after an item is removed from the map, so that resources held by
+// the value, like files or connections, can be released. The reason tells whether the item was removed
+// to make room for a new key, or by Delete, DeleteFunc or Clear. Replacing the value of an existing key does not
+// call f. Pass nil to remove the callback. The callback is not copied by Clone.
+func (m *RingMap[K, V]) OnEvict(f func(k K, v V, reason EvictReason)) {
+	m.onEvict = f
+}
+
+// evicted calls the OnEvict callback, if there is one.
+func (m *RingMap[K, V]) evicted(k K, v V, reason EvictReason) {
+	if m.onEvict != nil {
+		m.onEvict(k, v, reason)
+	}
+}
+
+// Set sets the given key to the given value.
+// If the key is new and the map is full, the oldest item will be removed.
+func (m *RingMap[K, V]) Set(k K, v V) {
+	m.Push(k, v)
+}
+
+// Push sets the given key to the given value, and returns the item that was removed to make room for it.
+// If the key is new and the map is full, the oldest item will be removed, and ok will be true.
+func (m *RingMap[K, V]) Push(k K, v V) (evicted Pair[K, V], ok bool) {
+	if m.Cap() == 0 {
+		panic("cannot call Set() on a RingMap with no capacity")
+	}
+	if _, exists := m.items[k]; exists {
+		m.items[k] = v
+		return
+	}
+	if m.items == nil {
+		m.items = make(map[K]V, len(m.ring))
+	}
+	if m.count == len(m.ring) {
+		oldKey := m.ring[m.start]
+		evicted = Pair[K, V]{oldKey, m.items[oldKey]}
+		ok = true
+		delete(m.items, oldKey)
+		m.ring[m.start] = k
+		m.start = (m.start + 1) % len(m.ring)
+	} else {
+		m.ring[m.index(m.count)] = k
+		m.count++
+	}
+	m.items[k] = v
+	if ok {
+		m.evicted(evicted.Key, evicted.Value, EvictedByCapacity)
+	}
+	return
+}
+
+// index returns the location in the ring of the item at the given position, where position 0 is the oldest item.
+func (m *RingMap[K, V]) index(position int) int {
+	return (m.start + position) % len(m.ring)
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (m *RingMap[K, V]) Get(k K) (v V) {
+	if m == nil {
+		return
+	}
+	return m.items.Get(k)
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *RingMap[K, V]) Load(k K) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	return m.items.Load(k)
+}
+
+// Has returns true if the key exists.
+func (m *RingMap[K, V]) Has(k K) bool {
+	if m == nil {
+		return false
+	}
+	return m.items.Has(k)
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *RingMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	var ok bool
+	if v, ok = m.items[k]; !ok {
+		return
+	}
+	delete(m.items, k)
+	var i int
+	for i = 0; i < m.count; i++ {
+		if m.ring[m.index(i)] == k {
+			break
+		}
+	}
+	for ; i < m.count-1; i++ {
+		m.ring[m.index(i)] = m.ring[m.index(i+1)]
+	}
+	var zero K
+	m.count--
+	m.ring[m.index(m.count)] = zero
+	m.evicted(k, v, EvictedByDelete)
+	return
+}
+
+// Clear removes all the items in the map. The capacity is not changed.
+func (m *RingMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	items := m.items
+	m.items = nil
+	clear(m.ring)
+	m.start = 0
+	m.count = 0
+	if m.onEvict != nil {
+		for k, v := range items {
+			m.onEvict(k, v, EvictedByDelete)
+		}
+	}
+}
+
+// Len returns the number of items in the map.
+func (m *RingMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+// Range calls the given function for each key,value pair in the map, from the oldest to the newest.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+func (m *RingMap[K, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	for i := 0; i < m.count; i++ {
+		k := m.ring[m.index(i)]
+		if !f(k, m.items[k]) {
+			break
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map, from the oldest to the newest.
+func (m *RingMap[K, V]) Keys() (keys []K) {
+	if m.Len() == 0 {
+		return
+	}
+	keys = make([]K, 0, m.count)
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return
+}
+
+// Values returns a new slice containing the values of the map, from the oldest to the newest.
+func (m *RingMap[K, V]) Values() (values []V) {
+	if m.Len() == 0 {
+		return
+	}
+	values = make([]V, 0, m.count)
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *RingMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+// If in has more items than will fit, the oldest items will be removed.
+func (m *RingMap[K, V]) Copy(in MapI[K, V]) {
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal, regardless of the order.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *RingMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m == nil {
+		return m2 == nil || m2.Len() == 0
+	}
+	return m.items.Equal(m2)
+}
+
+// String outputs the map as a string.
+func (m *RingMap[K, V]) String() string {
+	var s string
+
+	if m == nil {
+		return s
+	}
+
+	s = "{"
+	m.Range(func(k K, v V) bool {
+		s += fmt.Sprintf(`%#v:%#v,`, k, v)
+		return true
+	})
+	s = strings.TrimRight(s, ",")
+	s += "}"
+	return s
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *RingMap[K, V]) MarshalBinary() (data []byte, err error) {
+	buf := new(bytes.Buffer)
+	encoder := gob.NewEncoder(buf)
+
+	err = encoder.Encode(m.Cap())
+	if err == nil {
+		err = encoder.Encode(m.Keys())
+	}
+	if err == nil {
+		err = encoder.Encode(m.Values())
+	}
+	data = buf.Bytes()
+	return
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// RingMap.
+func (m *RingMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var capacity int
+	var keys []K
+	var values []V
+
+	buf := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buf)
+	if err = dec.Decode(&capacity); err == nil {
+		if err = dec.Decode(&keys); err == nil {
+			err = dec.Decode(&values)
+		}
+	}
+	if err != nil {
+		return
+	}
+	if len(keys) != len(values) {
+		return fmt.Errorf("maps: cannot unmarshal %d keys with %d values", len(keys), len(values))
+	}
+	if err = checkCapacity(capacity, len(keys)); err != nil {
+		return
+	}
+	onEvict := m.onEvict
+	if capacity == 0 {
+		*m = RingMap[K, V]{} // the zero value was marshaled
+	} else {
+		*m = *NewRingMap[K, V](capacity)
+	}
+	m.onEvict = onEvict
+	for i, k := range keys {
+		m.Set(k, values[i])
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *RingMap[K, V]) MarshalJSON() (data []byte, err error) {
+	// Json objects are unordered
+	if m == nil {
+		return
+	}
+	return m.items.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a RingMap.
+// The JSON must start with an object. Since JSON objects are unordered, the order of the items is not determinate.
+// If the map does not have enough capacity to hold all the items, its capacity will be increased.
+func (m *RingMap[K, V]) UnmarshalJSON(data []byte) (err error) {
+	var items map[K]V
+
+	if err = json.Unmarshal(data, &items); err == nil {
+		onEvict := m.onEvict
+		*m = *NewRingMap[K, V](max(m.Cap(), len(items), 1))
+		m.onEvict = onEvict
+		for k, v := range items {
+			m.Set(k, v)
+		}
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map, from the oldest to the newest.
+func (m *RingMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map, from the oldest to the newest.
+func (m *RingMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map, from the oldest to the newest.
+func (m *RingMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden but not moved. If the map fills up, the oldest items will be removed.
+func (m *RingMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// Clone returns a copy of the RingMap. This is a shallow clone:
+// the new keys and values are set using ordinary assignment. Cloning a zero value returns a new zero value.
+func (m *RingMap[K, V]) Clone() *RingMap[K, V] {
+	if m.Cap() == 0 {
+		return new(RingMap[K, V]) // the zero value has no capacity
+	}
+	m1 := NewRingMap[K, V](m.Cap())
+	m1.Copy(m)
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+// Items are ranged from the oldest to the newest.
+func (m *RingMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m.Len() == 0 {
+		return
+	}
+	keys := m.Keys()
+	clear(m.ring)
+	m.start = 0
+	m.count = 0
+	var deleted []Pair[K, V]
+	for _, k := range keys {
+		if v := m.items[k]; del(k, v) {
+			delete(m.items, k)
+			if m.onEvict != nil {
+				deleted = append(deleted, Pair[K, V]{k, v})
+			}
+		} else {
+			m.ring[m.count] = k
+			m.count++
+		}
+	}
+	for _, p := range deleted {
+		m.onEvict(p.Key, p.Value, EvictedByDelete)
+	}
+}
@@ -0,0 +1,170 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingMap_Mapi(t *testing.T) {
+	runMapiTests[RingMap[string, int]](t,
+		func(sources ...mapT) MapI[string, int] {
+			m := NewRingMap[string, int](10)
+			for _, s := range sources {
+				m.Merge(s)
+			}
+			return m
+		},
+	)
+}
+
+func init() {
+	gob.Register(new(RingMap[string, int]))
+}
+
+func ExampleRingMap() {
+	m := NewRingMap[string, int](3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	evicted, ok := m.Push("d", 4)
+	fmt.Println(evicted.Key, evicted.Value, ok)
+	fmt.Println(m)
+	// Output: a 1 true
+	// {"b":2,"c":3,"d":4}
+}
+
+func TestRingMap(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRingMap[string, int](0)
+	})
+	assert.Panics(t, func() {
+		var m RingMap[string, int]
+		m.Set("a", 1)
+	})
+
+	m := NewRingMap[string, int](3)
+	assert.Equal(t, 3, m.Cap())
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		m.Set(k, i)
+	}
+	assert.Equal(t, []string{"c", "d", "e"}, m.Keys())
+	assert.Equal(t, []int{2, 3, 4}, m.Values())
+
+	// existing key does not move or evict
+	_, ok := m.Push("d", 5)
+	assert.False(t, ok)
+	assert.Equal(t, []string{"c", "d", "e"}, m.Keys())
+	assert.Equal(t, 5, m.Get("d"))
+
+	// delete from the middle of a wrapped ring
+	assert.Equal(t, 5, m.Delete("d"))
+	assert.Equal(t, 0, m.Delete("d"))
+	assert.Equal(t, []string{"c", "e"}, m.Keys())
+	m.Set("f", 6)
+	assert.Equal(t, []string{"c", "e", "f"}, m.Keys())
+	evicted, ok := m.Push("g", 7)
+	assert.True(t, ok)
+	assert.Equal(t, Pair[string, int]{"c", 2}, evicted)
+	assert.Equal(t, []string{"e", "f", "g"}, m.Keys())
+
+	m.DeleteFunc(func(k string, v int) bool {
+		return k == "f"
+	})
+	assert.Equal(t, []string{"e", "g"}, m.Keys())
+	m.Set("h", 8)
+	m.Set("i", 9)
+	assert.Equal(t, []string{"g", "h", "i"}, m.Keys())
+
+	m2 := m.Clone()
+	assert.Equal(t, m.Keys(), m2.Keys())
+	m2.Set("j", 10)
+	assert.Equal(t, []string{"g", "h", "i"}, m.Keys())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 3, m.Cap())
+	m.Set("a", 1)
+	assert.Equal(t, []string{"a"}, m.Keys())
+}
+
+func TestRingMap_BinaryMarshal(t *testing.T) {
+	m := NewRingMap[string, int](3)
+	m.Set("b", 2)
+	m.Set("a", 1)
+	data, err := m.MarshalBinary()
+	assert.NoError(t, err)
+
+	var m2 RingMap[string, int]
+	assert.NoError(t, m2.UnmarshalBinary(data))
+	assert.Equal(t, 3, m2.Cap())
+	assert.Equal(t, []string{"b", "a"}, m2.Keys())
+
+	var m3 RingMap[string, int]
+	data, err = m3.MarshalBinary()
+	assert.NoError(t, err)
+	var m4 RingMap[string, int]
+	assert.NoError(t, m4.UnmarshalBinary(data))
+	assert.Equal(t, 0, m4.Cap())
+	assert.Equal(t, 0, m4.Len())
+	assert.Equal(t, 0, m4.Clone().Cap())
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, 1<<60, []string{"a"}, []int{1})))
+
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, -1, []string(nil), []int(nil))))
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, 1, []string{"a", "b"}, []int{1, 2})))
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, 3, []string{"a", "b"}, []int{1})))
+}
+
+// gobEncode encodes each of items in turn, to build input for the UnmarshalBinary functions.
+func gobEncode(t *testing.T, items ...any) []byte {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	for _, item := range items {
+		assert.NoError(t, enc.Encode(item))
+	}
+	return buf.Bytes()
+}
+
+func TestRingMap_Nil(t *testing.T) {
+	var m *RingMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Cap())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Keys())
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "", m.String())
+	assert.NotPanics(t, func() {
+		m.Clear()
+	})
+}
+
+func TestRingMap_OnEvict(t *testing.T) {
+	m := NewRingMap[string, int](2)
+	var evicted []string
+	m.OnEvict(func(k string, v int, reason EvictReason) {
+		evicted = append(evicted, fmt.Sprintf("%s%d %s", k, v, reason))
+	})
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("b", 3) // replacing a value is not an eviction
+	m.Set("c", 4)
+	m.Delete("b")
+	m.Delete("z")
+	m.Set("d", 5)
+	m.DeleteFunc(func(k string, _ int) bool {
+		return k == "d"
+	})
+	m.Clear()
+	assert.Equal(t, []string{"a1 capacity", "b3 delete", "d5 delete", "c4 delete"}, evicted)
+
+	evicted = nil
+	m.OnEvict(nil)
+	m.Set("a", 1)
+	m.Delete("a")
+	assert.Nil(t, evicted)
+}
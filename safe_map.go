@@ -1,8 +1,10 @@
 package maps
 
 import (
+	"hash/maphash"
 	"iter"
 	"sync"
+	"sync/atomic"
 )
 
 // SafeMap is a go map that is safe for concurrent use and that uses a standard set of functions
@@ -18,40 +20,166 @@ import (
 // This will allow you to swap in a different kind of Map just by changing the type.
 //
 // Do not make a copy of a SafeMap using the equality operator (=). Use Clone instead.
+//
+// The mutex that protects the map is not exported. To do several operations while holding the lock once,
+// use Do, Update or Txn.
 type SafeMap[K comparable, V any] struct {
-	sync.RWMutex
+	mu    sync.RWMutex
 	items StdMap[K, V]
+	count atomic.Int64 // the number of items, so that Len does not need to lock
+	keyF  func(K) K
+	eqF   func(a, b V) bool
 }
 
 // NewSafeMap creates a new SafeMap.
 // Pass in zero or more standard maps and the contents of those maps will be copied to the new SafeMap.
+// The new map is pre-sized to hold all the items in sources.
 func NewSafeMap[K comparable, V any](sources ...map[K]V) *SafeMap[K, V] {
 	m := new(SafeMap[K, V])
+	if size := sourcesLen(sources); size > 0 {
+		m.items = make(map[K]V, size)
+	}
 	for _, i := range sources {
 		m.Copy(Cast(i))
 	}
 	return m
 }
 
+// unlock records the number of items in the map and releases the write lock.
+// All changes to the map must be followed by a call to unlock.
+func (m *SafeMap[K, V]) unlock() {
+	m.count.Store(int64(len(m.items)))
+	m.mu.Unlock()
+}
+
+// SetKeyFunc sets a function that changes every key given to the map before it is used,
+// so that keys can be put in a canonical form, like trimming spaces or changing them to lower case.
+// The function is used by every method that receives a key, and keys already in the map are changed too.
+// If more than one key changes to the same key, which value is kept is not determinate.
+//
+// The view passed to the function given to Do does not change keys.
+// To stop changing keys, set the function to nil. The function is not serialized.
+func (m *SafeMap[K, V]) SetKeyFunc(f func(K) K) {
+	m.mu.Lock()
+	defer m.unlock()
+	m.keyF = f
+	m.items = applyKeyFuncToItems(f, m.items)
+}
+
+// SetEqualFunc sets a function that Equal, CompareAndSwap and CompareAndDelete use to compare values, instead of comparing them with == or
+// their Equaler interface. Use it when the values are types you do not own that are not comparable,
+// or when values should be compared loosely. To go back to the default comparison, set the function to nil.
+// The function is not serialized.
+func (m *SafeMap[K, V]) SetEqualFunc(eq func(a, b V) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.eqF = eq
+}
+
 // Clear resets the map to an empty map.
 func (m *SafeMap[K, V]) Clear() {
 	if m.items == nil {
 		return
 	}
-	m.Lock()
+	m.mu.Lock()
 	m.items = nil
-	m.Unlock()
+	m.unlock()
 }
 
 // Set sets the key to the given value.
 func (m *SafeMap[K, V]) Set(k K, v V) {
-	m.Lock()
+	m.mu.Lock()
+	k = applyKeyFunc(m.keyF, k)
 	if m.items == nil {
 		m.items = map[K]V{k: v}
 	} else {
 		m.items[k] = v
 	}
-	m.Unlock()
+	m.unlock()
+}
+
+// Update atomically reads, transforms and writes the value of the given key while holding the lock once.
+//
+// The function f receives the current value and whether the key exists. It returns the new value,
+// and keep, which should be true to store the new value, or false to delete the key from the map.
+// Update returns the value now stored in the map and whether the key exists after the update.
+//
+// Since the map is locked while f is called, f must not call other methods of the SafeMap.
+func (m *SafeMap[K, V]) Update(k K, f func(old V, exists bool) (new V, keep bool)) (v V, ok bool) {
+	m.mu.Lock()
+	defer m.unlock()
+	k = applyKeyFunc(m.keyF, k)
+	old, exists := m.items[k]
+	if v, ok = f(old, exists); ok {
+		if m.items == nil {
+			m.items = map[K]V{}
+		}
+		m.items[k] = v
+	} else {
+		var zero V
+		v = zero
+		if exists {
+			delete(m.items, k)
+		}
+	}
+	return
+}
+
+// Do locks the map once and calls f with an unlocked view of the map, so that several related reads
+// and writes can be done without another go routine seeing the intermediate states.
+// The lock is released when f returns.
+//
+// The view is only valid during the call to f and must not be kept after f returns.
+// Since the map is locked while f is called, f must not call methods of the SafeMap itself.
+func (m *SafeMap[K, V]) Do(f func(view MapI[K, V])) {
+	m.mu.Lock()
+	defer m.unlock()
+	if m.items == nil {
+		m.items = map[K]V{}
+	}
+	f(m.items)
+}
+
+// SetMany sets all the given key and value pairs while holding the lock once.
+func (m *SafeMap[K, V]) SetMany(pairs ...Pair[K, V]) {
+	m.mu.Lock()
+	defer m.unlock()
+	if m.items == nil {
+		m.items = make(map[K]V, len(pairs))
+	}
+	m.items.SetMany(applyKeyFuncToPairs(m.keyF, pairs)...)
+}
+
+// GetMany returns the values for the given keys, in the same order as the keys, while holding the lock once.
+// The zero value is returned for keys that do not exist.
+func (m *SafeMap[K, V]) GetMany(keys ...K) []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.items.GetMany(applyKeyFuncToKeys(m.keyF, keys)...)
+}
+
+// GetMultiple returns a new SafeMap with the items of the given keys, while holding the lock once.
+// Keys that do not exist are skipped. The new map has the same key function as the map.
+func (m *SafeMap[K, V]) GetMultiple(keys ...K) *SafeMap[K, V] {
+	m2 := new(SafeMap[K, V])
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m2.items = m.items.GetMultiple(applyKeyFuncToKeys(m.keyF, keys)...)
+	m2.keyF = m.keyF
+	m2.eqF = m.eqF
+	m2.count.Store(int64(len(m2.items)))
+	return m2
+}
+
+// DeleteMany removes all the given keys from the map while holding the lock once,
+// and returns the number of keys that were in the map.
+func (m *SafeMap[K, V]) DeleteMany(keys ...K) int {
+	if m.items == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.unlock()
+	return m.items.DeleteMany(applyKeyFuncToKeys(m.keyF, keys)...)
 }
 
 // Get returns the value based on its key. If it does not exist, an empty string will be returned.
@@ -72,19 +200,132 @@ func (m *SafeMap[K, V]) Load(k K) (v V, ok bool) {
 	if m.items == nil {
 		return
 	}
-	m.RLock()
+	m.mu.RLock()
 	if m.items != nil {
-		v, ok = m.items[k]
+		v, ok = m.items[applyKeyFunc(m.keyF, k)]
 	}
-	m.RUnlock()
+	m.mu.RUnlock()
 	return
 }
 
+// TryLoad is like Load, but returns immediately if the map is locked by a writer instead of waiting for the lock.
+// The ok result reports whether the lock was acquired. If ok is false, v and found are not meaningful.
+func (m *SafeMap[K, V]) TryLoad(k K) (v V, found bool, ok bool) {
+	if !m.mu.TryRLock() {
+		return
+	}
+	v, found = m.items[applyKeyFunc(m.keyF, k)]
+	m.mu.RUnlock()
+	return v, found, true
+}
+
+// TryStore is like Set, but returns immediately if the map is locked instead of waiting for the lock.
+// It returns true if the value was stored, and false if the lock was not acquired.
+func (m *SafeMap[K, V]) TryStore(k K, v V) bool {
+	if !m.mu.TryLock() {
+		return false
+	}
+	k = applyKeyFunc(m.keyF, k)
+	if m.items == nil {
+		m.items = map[K]V{k: v}
+	} else {
+		m.items[k] = v
+	}
+	m.unlock()
+	return true
+}
+
 // Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
 func (m *SafeMap[K, V]) Delete(k K) (v V) {
-	m.Lock()
-	v = m.items.Delete(k)
-	m.Unlock()
+	m.mu.Lock()
+	v = m.items.Delete(applyKeyFunc(m.keyF, k))
+	m.unlock()
+	return
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m *SafeMap[K, V]) LoadAndDelete(k K) (v V, loaded bool) {
+	if m.items == nil {
+		return
+	}
+	m.mu.Lock()
+	k = applyKeyFunc(m.keyF, k)
+	v, loaded = m.items[k]
+	if loaded {
+		delete(m.items, k)
+	}
+	m.unlock()
+	return
+}
+
+// GetOrSet returns the value of the key if it exists. Otherwise, it sets the key to v and returns v.
+// The check and the set are done while holding the lock once.
+func (m *SafeMap[K, V]) GetOrSet(k K, v V) V {
+	m.mu.Lock()
+	defer m.unlock()
+	k = applyKeyFunc(m.keyF, k)
+	if m.items == nil {
+		m.items = map[K]V{k: v}
+		return v
+	}
+	if v2, ok := m.items[k]; ok {
+		return v2
+	}
+	m.items[k] = v
+	return v
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+func (m *SafeMap[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	m.mu.Lock()
+	k = applyKeyFunc(m.keyF, k)
+	if m.items == nil {
+		m.items = map[K]V{k: v}
+	} else {
+		previous, loaded = m.items[k]
+		m.items[k] = v
+	}
+	m.unlock()
+	return
+}
+
+// CompareAndSwap sets the key to the new value if the key exists and its current value is equal to old.
+// It returns true if the swap happened. This is the same interface as sync.Map.CompareAndSwap().
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *SafeMap[K, V]) CompareAndSwap(k K, old, new V) (swapped bool) {
+	if m.items == nil {
+		return
+	}
+	m.mu.Lock()
+	k = applyKeyFunc(m.keyF, k)
+	if v, ok := m.items[k]; ok && equalValuesFunc(m.eqF, v, old) {
+		m.items[k] = new
+		swapped = true
+	}
+	m.unlock()
+	return
+}
+
+// CompareAndDelete deletes the key if it exists and its current value is equal to old.
+// It returns true if the key was deleted. This is the same interface as sync.Map.CompareAndDelete().
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *SafeMap[K, V]) CompareAndDelete(k K, old V) (deleted bool) {
+	if m.items == nil {
+		return
+	}
+	m.mu.Lock()
+	k = applyKeyFunc(m.keyF, k)
+	if v, ok := m.items[k]; ok && equalValuesFunc(m.eqF, v, old) {
+		delete(m.items, k)
+		deleted = true
+	}
+	m.unlock()
 	return
 }
 
@@ -94,9 +335,9 @@ func (m *SafeMap[K, V]) Values() (v []V) {
 	if m.items == nil {
 		return
 	}
-	m.RLock()
+	m.mu.RLock()
 	v = m.items.Values()
-	m.RUnlock()
+	m.mu.RUnlock()
 	return
 }
 
@@ -106,21 +347,16 @@ func (m *SafeMap[K, V]) Keys() (keys []K) {
 	if m.items == nil {
 		return nil
 	}
-	m.RLock()
+	m.mu.RLock()
 	keys = m.items.Keys()
-	m.RUnlock()
+	m.mu.RUnlock()
 	return
 }
 
-// Len returns the number of items in the map
-func (m *SafeMap[K, V]) Len() (l int) {
-	if m.items == nil {
-		return
-	}
-	m.RLock()
-	l = m.items.Len()
-	m.RUnlock()
-	return
+// Len returns the number of items in the map.
+// Len does not lock the map, so it will not contend with other go routines that are using the map.
+func (m *SafeMap[K, V]) Len() int {
+	return int(m.count.Load())
 }
 
 // Range will call the given function with every key and value in the map.
@@ -131,11 +367,52 @@ func (m *SafeMap[K, V]) Range(f func(k K, v V) bool) {
 	if m == nil || m.items == nil {
 		return
 	}
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	m.items.Range(f)
 }
 
+// RangeSnapshot calls the given function with every key and value in the map, like Range.
+// However, the keys and values are first copied under a brief lock, and the map is not locked while f is called,
+// so f may call other methods of the SafeMap, including Set and Delete, without causing a deadlock.
+// The iteration is weakly consistent: changes made to the map while ranging will not be seen by f.
+func (m *SafeMap[K, V]) RangeSnapshot(f func(k K, v V) bool) {
+	if m == nil || m.items == nil {
+		return
+	}
+	m.mu.RLock()
+	keys := make([]K, 0, len(m.items))
+	values := make([]V, 0, len(m.items))
+	for k, v := range m.items {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	m.mu.RUnlock()
+
+	for i, k := range keys {
+		if !f(k, values[i]) {
+			break
+		}
+	}
+}
+
+// RangeUnlocked calls the given function with every key and value in the map, like Range, but without
+// holding the lock while f is called. This allows f to call other methods of the SafeMap,
+// including conditionally deleting entries.
+//
+// The keys are copied under a brief lock, and then each value is fetched as it is needed.
+// This gives weaker consistency than Range: keys deleted during the iteration are skipped,
+// keys added during the iteration are not seen, and values are current as of when they are passed to f.
+func (m *SafeMap[K, V]) RangeUnlocked(f func(k K, v V) bool) {
+	for _, k := range m.Keys() {
+		if v, ok := m.Load(k); ok {
+			if !f(k, v) {
+				break
+			}
+		}
+	}
+}
+
 // Merge merges the given  map with the current one. The given one takes precedent on collisions.
 // Deprecated: Use Copy instead.
 func (m *SafeMap[K, V]) Merge(in MapI[K, V]) {
@@ -147,52 +424,70 @@ func (m *SafeMap[K, V]) Copy(in MapI[K, V]) {
 	if m.items == nil {
 		m.items = make(map[K]V, in.Len())
 	}
-	m.Lock()
-	defer m.Unlock()
-	m.items.Copy(in)
+	m.mu.Lock()
+	defer m.unlock()
+	copyWithKeyFunc(m.keyF, m.items, in)
+}
+
+// CopyFunc copies the keys and values of in into this map while holding the lock once.
+// When a key is in both maps, resolve is called with the key, the existing value and the incoming value,
+// and the value it returns is stored. Use it to combine values, like adding counts. If resolve is nil, CopyFunc works like Copy.
+func (m *SafeMap[K, V]) CopyFunc(in MapI[K, V], resolve func(k K, existing, incoming V) V) {
+	m.mu.Lock()
+	defer m.unlock()
+	if m.items == nil {
+		m.items = make(map[K]V, in.Len())
+	}
+	copyFuncWithKeyFunc(m.keyF, m.items, in, resolve)
 }
 
 // Equal returns true if all the keys in the given map exist in this map, and the values are the same
 func (m *SafeMap[K, V]) Equal(m2 MapI[K, V]) bool {
-	m.RLock()
-	defer m.RUnlock()
-	return m.items.Equal(m2)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return equalWithKeyFunc(m.keyF, m.eqF, m.items, m2)
 }
 
 // MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
 func (m *SafeMap[K, V]) MarshalBinary() ([]byte, error) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.items.MarshalBinary()
 }
 
 // UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
 // SafeMap.
 func (m *SafeMap[K, V]) UnmarshalBinary(data []byte) (err error) {
-	m.Lock()
-	defer m.Unlock()
-	return m.items.UnmarshalBinary(data)
+	m.mu.Lock()
+	defer m.unlock()
+	if err = m.items.UnmarshalBinary(data); err == nil {
+		m.items = applyKeyFuncToItems(m.keyF, m.items)
+	}
+	return
 }
 
 // MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
 func (m *SafeMap[K, V]) MarshalJSON() (out []byte, err error) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.items.MarshalJSON()
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a SafeMap.
 // The JSON must start with an object.
 func (m *SafeMap[K, V]) UnmarshalJSON(in []byte) (err error) {
-	m.Lock()
-	defer m.Unlock()
-	return m.items.UnmarshalJSON(in)
+	m.mu.Lock()
+	defer m.unlock()
+	if err = m.items.UnmarshalJSON(in); err == nil {
+		m.items = applyKeyFuncToItems(m.keyF, m.items)
+	}
+	return
 }
 
 // String outputs the map as a string.
 func (m *SafeMap[K, V]) String() string {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.items.String()
 }
 
@@ -213,8 +508,8 @@ func (m *SafeMap[K, V]) KeysIter() iter.Seq[K] {
 		if m.items == nil {
 			return
 		}
-		m.RLock()
-		defer m.RUnlock()
+		m.mu.RLock()
+		defer m.mu.RUnlock()
 		for k, _ := range m.items {
 			if !yield(k) {
 				break
@@ -231,8 +526,8 @@ func (m *SafeMap[K, V]) ValuesIter() iter.Seq[V] {
 		if m.items == nil {
 			return
 		}
-		m.RLock()
-		defer m.RUnlock()
+		m.mu.RLock()
+		defer m.mu.RUnlock()
 		for _, v := range m.items {
 			if !yield(v) {
 				break
@@ -241,13 +536,41 @@ func (m *SafeMap[K, V]) ValuesIter() iter.Seq[V] {
 	}
 }
 
+// KeysSnapshotIter returns an iterator over a copy of the keys in the map.
+// The keys are copied under a brief lock, and the map is not locked while iterating,
+// so it is safe to call other methods of the SafeMap from within the loop.
+// The iteration is weakly consistent: keys added or deleted during the loop will not be reflected.
+func (m *SafeMap[K, V]) KeysSnapshotIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, k := range m.Keys() {
+			if !yield(k) {
+				break
+			}
+		}
+	}
+}
+
+// ValuesSnapshotIter returns an iterator over a copy of the values in the map.
+// The values are copied under a brief lock, and the map is not locked while iterating,
+// so it is safe to call other methods of the SafeMap from within the loop.
+// The iteration is weakly consistent: changes made during the loop will not be reflected.
+func (m *SafeMap[K, V]) ValuesSnapshotIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.Values() {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
 // Insert adds the values from seq to the map.
 // Duplicate keys are overridden.
 func (m *SafeMap[K, V]) Insert(seq iter.Seq2[K, V]) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.unlock()
 	for k, v := range seq {
-		m.items[k] = v
+		m.items[applyKeyFunc(m.keyF, k)] = v
 	}
 }
 
@@ -259,22 +582,72 @@ func CollectSafeMap[K comparable, V any](seq iter.Seq2[K, V]) *SafeMap[K, V] {
 	for k, v := range seq {
 		m.items[k] = v
 	}
+	m.count.Store(int64(len(m.items)))
 	return m
 }
 
-// Clone returns a copy of the SafeMap. This is a shallow clone:
+// AsReadOnly returns a read-only view of the map, whose methods that would change the map panic with ErrReadOnly.
+// Changes made to the map through other references are seen by the view.
+func (m *SafeMap[K, V]) AsReadOnly() *ReadOnlyMap[K, V] {
+	return NewReadOnlyMap[K, V](m)
+}
+
+// Clone returns a copy of the SafeMap, including its key function. This is a shallow clone:
 // the new keys and values are set using ordinary assignment.
 func (m *SafeMap[K, V]) Clone() *SafeMap[K, V] {
 	m1 := new(SafeMap[K, V])
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	m1.items = m.items.Clone()
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	m1.count.Store(int64(len(m1.items)))
 	return m1
 }
 
 // DeleteFunc deletes any key/value pairs for which del returns true.
 func (m *SafeMap[K, V]) DeleteFunc(del func(K, V) bool) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.unlock()
 	m.items.DeleteFunc(del)
 }
+
+// Filter returns a new SafeMap with the items of the map for which keep returns true.
+// The new map has the same key function as the map, and the map is not changed.
+// The map is read locked while keep is called, so keep must not call methods of the SafeMap that change it.
+func (m *SafeMap[K, V]) Filter(keep func(K, V) bool) *SafeMap[K, V] {
+	m1 := new(SafeMap[K, V])
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m1.items = m.items.Filter(keep)
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	m1.count.Store(int64(len(m1.items)))
+	return m1
+}
+
+// Hash returns a hash of the keys and values of the map, made with seed. Maps with the same keys and with values
+// that are equal under == have the same hash. Values are not compared with an Equaler or a function given to
+// SetEqualFunc, so maps that are Equal because of those may have different hashes.
+// Use it to detect changes, or to use the contents of the map as a key, without serializing the map.
+// The values are hashed as interfaces, so Hash panics if a value is not comparable.
+func (m *SafeMap[K, V]) Hash(seed maphash.Seed) uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.items.Hash(seed)
+}
+
+// CloneDeep returns a copy of the map in which each value that implements Cloner is copied by calling its
+// Clone method. Values that are Map types are copied with CloneDeep, so maps of maps are copied at every level.
+// Other values, including slices, are copied using ordinary assignment.
+// The map is read locked while the values are copied.
+func (m *SafeMap[K, V]) CloneDeep() *SafeMap[K, V] {
+	m1 := new(SafeMap[K, V])
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m1.items = m.items.CloneDeep()
+	m1.keyF = m.keyF
+	m1.eqF = m.eqF
+	m1.count.Store(int64(len(m1.items)))
+	return m1
+}
@@ -3,6 +3,8 @@ package maps
 import (
 	"encoding/gob"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +50,271 @@ func TestCollectSafeMap(t *testing.T) {
 	m3 := m2.Clone()
 	assert.True(t, m.Equal(m3))
 }
+
+func TestSafeMap_SnapshotIter(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 1, "b": 2, "c": 3})
+
+	// deleting from within the loop must not deadlock
+	for k := range m.KeysSnapshotIter() {
+		if k != "b" {
+			m.Delete(k)
+		}
+	}
+	assert.Equal(t, []string{"b"}, m.Keys())
+
+	for v := range m.ValuesSnapshotIter() {
+		m.Set("d", v+2)
+	}
+	assert.Equal(t, 4, m.Get("d"))
+
+	var m2 SafeMap[string, int]
+	assert.Empty(t, slices.Collect(m2.KeysSnapshotIter()))
+	assert.Empty(t, slices.Collect(m2.ValuesSnapshotIter()))
+}
+
+func TestSafeMap_RangeSnapshot(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	sum := 0
+	m.RangeSnapshot(func(k string, v int) bool {
+		sum += v
+		m.Delete(k) // must not deadlock
+		m.Set(k+k, v)
+		return true
+	})
+	assert.Equal(t, 6, sum)
+	assert.True(t, m.Equal(StdMap[string, int]{"aa": 1, "bb": 2, "cc": 3}))
+
+	count := 0
+	m.RangeSnapshot(func(k string, v int) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+
+	var m2 SafeMap[string, int]
+	m2.RangeSnapshot(func(k string, v int) bool {
+		t.Error("empty map should not range")
+		return true
+	})
+}
+
+func TestSafeMap_RangeUnlocked(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4})
+	var seen []string
+	m.RangeUnlocked(func(k string, v int) bool {
+		seen = append(seen, k)
+		// delete everything else, which should then be skipped
+		for _, k2 := range []string{"a", "b", "c", "d"} {
+			if k2 != k {
+				m.Delete(k2)
+			}
+		}
+		return true
+	})
+	assert.Len(t, seen, 1)
+	assert.Equal(t, seen, m.Keys())
+}
+
+func TestNewSafeMap(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 1}, map[string]int{"b": 2, "a": 3})
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 3, m.Get("a"))
+}
+
+func TestSafeMap_LoadAndDelete(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 0, "b": 2})
+
+	v, loaded := m.LoadAndDelete("a")
+	assert.Equal(t, 0, v)
+	assert.True(t, loaded)
+	assert.False(t, m.Has("a"))
+
+	v, loaded = m.LoadAndDelete("a")
+	assert.Equal(t, 0, v)
+	assert.False(t, loaded)
+
+	var m2 SafeMap[string, int]
+	_, loaded = m2.LoadAndDelete("a")
+	assert.False(t, loaded)
+}
+
+func TestSafeMap_CompareAndSwap(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 1})
+
+	assert.False(t, m.CompareAndSwap("a", 2, 3))
+	assert.Equal(t, 1, m.Get("a"))
+	assert.True(t, m.CompareAndSwap("a", 1, 3))
+	assert.Equal(t, 3, m.Get("a"))
+	assert.False(t, m.CompareAndSwap("b", 0, 3))
+	assert.False(t, m.Has("b"))
+
+	var m2 SafeMap[string, int]
+	assert.False(t, m2.CompareAndSwap("a", 0, 1))
+}
+
+func TestSafeMap_CompareAndDelete(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 1})
+
+	assert.False(t, m.CompareAndDelete("a", 2))
+	assert.True(t, m.Has("a"))
+	assert.True(t, m.CompareAndDelete("a", 1))
+	assert.False(t, m.Has("a"))
+	assert.False(t, m.CompareAndDelete("a", 1))
+
+	var m2 SafeMap[string, int]
+	assert.False(t, m2.CompareAndDelete("a", 0))
+}
+
+func TestSafeMap_Swap(t *testing.T) {
+	var m SafeMap[string, int]
+
+	v, loaded := m.Swap("a", 1)
+	assert.Equal(t, 0, v)
+	assert.False(t, loaded)
+
+	v, loaded = m.Swap("a", 2)
+	assert.Equal(t, 1, v)
+	assert.True(t, loaded)
+	assert.Equal(t, 2, m.Get("a"))
+
+	v, loaded = m.Swap("b", 3)
+	assert.Equal(t, 0, v)
+	assert.False(t, loaded)
+	assert.Equal(t, 2, m.Len())
+}
+
+func ExampleSafeMap_Update() {
+	m := new(SafeMap[string, int])
+	inc := func(old int, exists bool) (int, bool) {
+		return old + 1, true
+	}
+	m.Update("a", inc)
+	v, _ := m.Update("a", inc)
+	fmt.Print(v)
+	// Output: 2
+}
+
+func TestSafeMap_Update(t *testing.T) {
+	m := new(SafeMap[string, int])
+	del := func(old int, exists bool) (int, bool) {
+		return old, false
+	}
+
+	v, ok := m.Update("a", del)
+	assert.Equal(t, 0, v)
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.Len())
+
+	m.Set("a", 5)
+	v, ok = m.Update("a", func(old int, exists bool) (int, bool) {
+		assert.True(t, exists)
+		assert.Equal(t, 5, old)
+		return old * 2, true
+	})
+	assert.Equal(t, 10, v)
+	assert.True(t, ok)
+
+	v, ok = m.Update("a", del)
+	assert.Equal(t, 0, v)
+	assert.False(t, ok)
+	assert.False(t, m.Has("a"))
+}
+
+func ExampleSafeMap_Do() {
+	m := NewSafeMap(map[string]int{"from": 5})
+	// move a value from one key to another without other go routines seeing the intermediate state
+	m.Do(func(view MapI[string, int]) {
+		view.Set("to", view.Delete("from"))
+	})
+	fmt.Print(m)
+	// Output: {"to":5}
+}
+
+func TestSafeMap_Do(t *testing.T) {
+	var m SafeMap[string, int]
+	m.Do(func(view MapI[string, int]) {
+		assert.Equal(t, 0, view.Len())
+		view.Set("a", 1)
+		view.Set("b", 2)
+	})
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestSafeMap_TryLoadTryStore(t *testing.T) {
+	var m SafeMap[string, int]
+
+	assert.True(t, m.TryStore("a", 1))
+	v, found, ok := m.TryLoad("a")
+	assert.Equal(t, 1, v)
+	assert.True(t, found)
+	assert.True(t, ok)
+	_, found, ok = m.TryLoad("b")
+	assert.False(t, found)
+	assert.True(t, ok)
+
+	m.Do(func(view MapI[string, int]) {
+		// the map is locked here, so these should fail without blocking
+		_, _, ok = m.TryLoad("a")
+		assert.False(t, ok)
+		assert.False(t, m.TryStore("a", 2))
+	})
+	assert.Equal(t, 1, m.Get("a"))
+}
+
+func TestSafeMap_Len(t *testing.T) {
+	var m SafeMap[string, int]
+	assert.Equal(t, 0, m.Len())
+
+	m.Set("a", 1)
+	m.SetMany(Pair[string, int]{"b", 2}, Pair[string, int]{"c", 3})
+	assert.Equal(t, 3, m.Len())
+	m.Delete("a")
+	assert.Equal(t, 2, m.Len())
+	_ = m.Txn(func(tx *Tx[string, int]) error {
+		tx.Set("d", 4)
+		return nil
+	})
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, 3, m.Clone().Len())
+	assert.Equal(t, 2, CollectSafeMap(StdMap[string, int]{"a": 1, "b": 2}.All()).Len())
+
+	m.Do(func(view MapI[string, int]) {
+		// Len does not lock, so it can be called while the map is locked
+		assert.Equal(t, 3, m.Len())
+	})
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestSafeMap_SetKeyFunc(t *testing.T) {
+	m := NewSafeMap(map[string]int{"A": 1})
+	m.SetKeyFunc(strings.ToLower)
+	assert.Equal(t, []string{"a"}, m.Keys(), "existing keys are changed")
+	m.Set("B", 2)
+	assert.Equal(t, 2, m.Get("b"))
+	assert.True(t, m.Has("A"))
+	assert.True(t, m.Equal(mapT{"A": 1, "B": 2}))
+
+	prev, loaded := m.Swap("B", 3)
+	assert.Equal(t, 2, prev)
+	assert.True(t, loaded)
+	assert.True(t, m.CompareAndSwap("B", 3, 4))
+	v, _ := m.Update("A", func(old int, exists bool) (int, bool) {
+		return old + 1, true
+	})
+	assert.Equal(t, 2, v)
+	_ = m.Txn(func(tx *Tx[string, int]) error {
+		tx.Set("C", tx.Get("A"))
+		return nil
+	})
+	assert.Equal(t, 2, m.Get("c"))
+	v, loaded = m.LoadAndDelete("C")
+	assert.Equal(t, 2, v)
+	assert.True(t, loaded)
+	assert.True(t, m.CompareAndDelete("B", 4))
+	assert.Equal(t, 1, m.Len())
+
+	m2 := m.Clone()
+	m2.Set("Z", 1)
+	assert.True(t, m2.Has("z"))
+}
@@ -2,6 +2,7 @@ package maps
 
 import (
 	"fmt"
+	"hash/maphash"
 	"iter"
 	"slices"
 	"strings"
@@ -27,111 +28,318 @@ import (
 // Call SetSortFunc to give the map a function that will keep the keys sorted in a particular order.
 //
 // Do not make a copy of a SafeSliceMap using the equality operator. Use Clone() instead.
+//
+// For ordered maps that are read much more often than they are changed, see StripedSliceMap.
+//
+// The mutex that protects the map is not exported. To do several operations while holding the lock once,
+// use Do or Update.
 type SafeSliceMap[K comparable, V any] struct {
-	sync.RWMutex
+	mu sync.RWMutex
 	sm SliceMap[K, V]
 }
 
 // NewSafeSliceMap creates a new SafeSliceMap.
 // Pass in zero or more standard maps and the contents of those maps will be copied to the new SafeSliceMap.
+// The new map is pre-sized to hold all the items in sources.
 func NewSafeSliceMap[K comparable, V any](sources ...map[K]V) *SafeSliceMap[K, V] {
 	m := new(SafeSliceMap[K, V])
+	if size := sourcesLen(sources); size > 0 {
+		m.sm.items = make(map[K]V, size)
+		m.sm.order = make([]K, 0, size)
+	}
+	// no need to lock here since this is a private variable
 	for _, i := range sources {
-		m.Copy(Cast(i))
+		m.sm.Copy(Cast(i))
 	}
 	return m
 }
 
+// SetEqualFunc sets a function that Equal and EqualOrdered use to compare values, instead of comparing them with == or
+// their Equaler interface. Use it when the values are types you do not own that are not comparable,
+// or when values should be compared loosely. To go back to the default comparison, set the function to nil.
+// The function is not serialized.
+func (m *SafeSliceMap[K, V]) SetEqualFunc(eq func(a, b V) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sm.SetEqualFunc(eq)
+}
+
 // SetSortFunc sets the sort function which will determine the order of the items in the map
 // on an ongoing basis. Normally, items will iterate in the order they were added.
 // The sort function is a Less function, that returns true when item 1 is "less" than item 2.
 // The sort function receives both the keys and values, so it can use either or both to decide how to sort.
 func (m *SafeSliceMap[K, V]) SetSortFunc(f func(key1, key2 K, val1, val2 V) bool) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.sm.SetSortFunc(f)
 }
 
+// SetCapacity limits the map to hold at most capacity items. When a new key is added to a full map,
+// the item at the front of the map is removed to make room for it, and onEvict is called with it if onEvict is not nil.
+// See SliceMap.SetCapacity.
+//
+// Since the map is locked while onEvict is called, onEvict must not call methods of the SafeSliceMap.
+func (m *SafeSliceMap[K, V]) SetCapacity(capacity int, onEvict func(k K, v V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sm.SetCapacity(capacity, onEvict)
+}
+
+// Cap returns the maximum number of items the map can hold, or zero if there is no limit.
+func (m *SafeSliceMap[K, V]) Cap() int {
+	if m == nil {
+		return 0
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sm.capacity
+}
+
 // Set sets the given key to the given value.
 //
 // If the key already exists, the range order will not change. If you want the order
 // to change, call Delete first, and then Set.
 func (m *SafeSliceMap[K, V]) Set(key K, val V) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.sm.Set(key, val)
 }
 
+// Update atomically reads, transforms and writes the value of the given key while holding the lock once.
+//
+// The function f receives the current value and whether the key exists. It returns the new value,
+// and keep, which should be true to store the new value, or false to delete the key from the map.
+// Update returns the value now stored in the map and whether the key exists after the update.
+// A new key is added to the end of the map, or in its sorted position if there is a sort function.
+//
+// Since the map is locked while f is called, f must not call other methods of the SafeSliceMap.
+func (m *SafeSliceMap[K, V]) Update(key K, f func(old V, exists bool) (new V, keep bool)) (val V, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old, exists := m.sm.Load(key)
+	if val, ok = f(old, exists); ok {
+		m.sm.Set(key, val)
+	} else {
+		var zero V
+		val = zero
+		if exists {
+			m.sm.Delete(key)
+		}
+	}
+	return
+}
+
+// Do locks the map once and calls f with an unlocked view of the map, so that several related reads
+// and writes can be done without another go routine seeing the intermediate states.
+// The lock is released when f returns.
+//
+// The view is only valid during the call to f and must not be kept after f returns.
+// Since the map is locked while f is called, f must not call methods of the SafeSliceMap itself.
+func (m *SafeSliceMap[K, V]) Do(f func(view MapI[K, V])) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f(&m.sm)
+}
+
+// SetMany sets all the given key and value pairs, in order, while holding the lock once.
+func (m *SafeSliceMap[K, V]) SetMany(pairs ...Pair[K, V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sm.SetMany(pairs...)
+}
+
+// GetMany returns the values for the given keys, in the same order as the keys, while holding the lock once.
+// The zero value is returned for keys that do not exist.
+func (m *SafeSliceMap[K, V]) GetMany(keys ...K) []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sm.GetMany(keys...)
+}
+
+// GetMultiple returns a new SafeSliceMap with the items of the given keys, in the order of the map,
+// while holding the lock once. Keys that do not exist are skipped.
+// The new map has the same sort function as the map.
+func (m *SafeSliceMap[K, V]) GetMultiple(keys ...K) *SafeSliceMap[K, V] {
+	m2 := new(SafeSliceMap[K, V])
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sm := m.sm.GetMultiple(keys...)
+	m2.sm = *sm
+	return m2
+}
+
+// DeleteMany removes all the given keys from the map while holding the lock once,
+// and returns the number of keys that were in the map.
+func (m *SafeSliceMap[K, V]) DeleteMany(keys ...K) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sm.DeleteMany(keys...)
+}
+
 // SetAt sets the given key to the given value, but also inserts it at the index specified.
 // If the index is bigger than
 // the length, it puts it at the end. Negative indexes are backwards from the end.
 func (m *SafeSliceMap[K, V]) SetAt(index int, key K, val V) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.sm.SetAt(index, key, val)
 }
 
 // Delete removes the item with the given key and returns the value.
 func (m *SafeSliceMap[K, V]) Delete(key K) (val V) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.sm.Delete(key)
 }
 
+// GetOrSet returns the value of the key if it exists. Otherwise, it sets the key to v and returns v.
+// The check and the set are done while holding the lock once.
+func (m *SafeSliceMap[K, V]) GetOrSet(key K, val V) V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sm.GetOrSet(key, val)
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+func (m *SafeSliceMap[K, V]) Swap(key K, val V) (previous V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sm.Swap(key, val)
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m *SafeSliceMap[K, V]) LoadAndDelete(key K) (val V, loaded bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sm.LoadAndDelete(key)
+}
+
 // Get returns the value based on its key. If the key does not exist, an empty value is returned.
 func (m *SafeSliceMap[K, V]) Get(key K) (val V) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.Get(key)
 }
 
 // Load returns the value based on its key, and a boolean indicating whether it exists in the map.
 // This is the same interface as sync.Map.Load()
 func (m *SafeSliceMap[K, V]) Load(key K) (val V, ok bool) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.Load(key)
 }
 
+// TryLoad is like Load, but returns immediately if the map is locked by a writer instead of waiting for the lock.
+// The ok result reports whether the lock was acquired. If ok is false, val and found are not meaningful.
+func (m *SafeSliceMap[K, V]) TryLoad(key K) (val V, found bool, ok bool) {
+	if !m.mu.TryRLock() {
+		return
+	}
+	val, found = m.sm.Load(key)
+	m.mu.RUnlock()
+	return val, found, true
+}
+
+// TryStore is like Set, but returns immediately if the map is locked instead of waiting for the lock.
+// It returns true if the value was stored, and false if the lock was not acquired.
+func (m *SafeSliceMap[K, V]) TryStore(key K, val V) bool {
+	if !m.mu.TryLock() {
+		return false
+	}
+	m.sm.Set(key, val)
+	m.mu.Unlock()
+	return true
+}
+
 // Has returns true if the given key exists in the map.
 func (m *SafeSliceMap[K, V]) Has(key K) (ok bool) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.Has(key)
 }
 
 // GetAt returns the value based on its position. If the position is out of bounds, an empty value is returned.
 func (m *SafeSliceMap[K, V]) GetAt(position int) (val V) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.GetAt(position)
 }
 
 // GetKeyAt returns the key based on its position. If the position is out of bounds, an empty value is returned.
 func (m *SafeSliceMap[K, V]) GetKeyAt(position int) (key K) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.GetKeyAt(position)
 }
 
+// GetPage returns the keys and values of up to limit items, starting at position offset.
+// If offset is out of bounds, nil slices are returned.
+// The page is read under a single lock, so the keys and values will be consistent with each other.
+func (m *SafeSliceMap[K, V]) GetPage(offset, limit int) (keys []K, values []V) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sm.GetPage(offset, limit)
+}
+
+// Head returns a new SafeSliceMap containing the first n items of the map.
+// If n is greater than the length of the map, the entire map is copied.
+func (m *SafeSliceMap[K, V]) Head(n int) *SafeSliceMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &SafeSliceMap[K, V]{sm: *m.sm.Head(n)}
+}
+
+// Tail returns a new SafeSliceMap containing the last n items of the map.
+// If n is greater than the length of the map, the entire map is copied.
+func (m *SafeSliceMap[K, V]) Tail(n int) *SafeSliceMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &SafeSliceMap[K, V]{sm: *m.sm.Tail(n)}
+}
+
+// HeadUntil returns a new SafeSliceMap containing the items that come before the given key.
+// The item with the given key is not included. If the key does not exist, the entire map is copied.
+func (m *SafeSliceMap[K, V]) HeadUntil(key K) *SafeSliceMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &SafeSliceMap[K, V]{sm: *m.sm.HeadUntil(key)}
+}
+
+// TailFrom returns a new SafeSliceMap containing the item with the given key and all the items that come after it.
+// If the key does not exist, the new map will be empty.
+func (m *SafeSliceMap[K, V]) TailFrom(key K) *SafeSliceMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &SafeSliceMap[K, V]{sm: *m.sm.TailFrom(key)}
+}
+
+// indexOf returns the position of the given key, or -1 if the key does not exist.
+func (m *SafeSliceMap[K, V]) indexOf(key K) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sm.indexOf(key)
+}
+
 // Values returns a slice of the values in the order they were added or sorted.
 func (m *SafeSliceMap[K, V]) Values() (values []V) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.Values()
 }
 
 // Keys returns the keys of the map, in the order they were added or sorted.
 func (m *SafeSliceMap[K, V]) Keys() (keys []K) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.Keys()
 }
 
 // Len returns the number of items in the map.
 func (m *SafeSliceMap[K, V]) Len() int {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.Len()
 }
 
@@ -139,23 +347,23 @@ func (m *SafeSliceMap[K, V]) Len() int {
 // If you are using a sort function, you must save and restore the sort function in a separate operation
 // since functions are not serializable.
 func (m *SafeSliceMap[K, V]) MarshalBinary() (data []byte, err error) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.MarshalBinary()
 }
 
 // UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
 // SafeSliceMap.
 func (m *SafeSliceMap[K, V]) UnmarshalBinary(data []byte) (err error) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.sm.UnmarshalBinary(data)
 }
 
 // MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
 func (m *SafeSliceMap[K, V]) MarshalJSON() (data []byte, err error) {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
 	// Json objects are unordered
 	return m.sm.MarshalJSON()
@@ -164,13 +372,13 @@ func (m *SafeSliceMap[K, V]) MarshalJSON() (data []byte, err error) {
 // UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a Map.
 // The JSON must start with an object.
 func (m *SafeSliceMap[K, V]) UnmarshalJSON(data []byte) (err error) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.sm.UnmarshalJSON(data)
 }
 
 // Merge the given map into the current one.
-// Deprecated: Use copy instead.
+// Deprecated: Use Copy instead.
 func (m *SafeSliceMap[K, V]) Merge(in MapI[K, V]) {
 	m.Copy(in)
 }
@@ -183,6 +391,22 @@ func (m *SafeSliceMap[K, V]) Copy(in MapI[K, V]) {
 	})
 }
 
+// CopyFunc copies the keys and values of in into this map.
+// When a key is in both maps, resolve is called with the key, the existing value and the incoming value,
+// and the value it returns is stored. Use it to combine values, like adding counts. If resolve is nil, CopyFunc works like Copy.
+// Each item is resolved and set while holding the lock once, so concurrent calls combine their values correctly.
+func (m *SafeSliceMap[K, V]) CopyFunc(in MapI[K, V], resolve func(k K, existing, incoming V) V) {
+	in.Range(func(k K, v V) bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if existing, ok := m.sm.Load(k); ok && resolve != nil {
+			v = resolve(k, existing, v)
+		}
+		m.sm.Set(k, v)
+		return true
+	})
+}
+
 // Range will call the given function with every key and value in the order
 // they were placed in the map, or in if you sorted the map, in your custom order.
 // If f returns false, it stops the iteration. This pattern is taken from sync.Map.
@@ -194,26 +418,74 @@ func (m *SafeSliceMap[K, V]) Range(f func(key K, value V) bool) {
 	if m == nil || m.sm.items == nil { // prevent unnecessary lock
 		return
 	}
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	m.sm.Range(f)
 }
 
+// RangeSnapshot calls the given function with every key and value in the map, in order, like Range.
+// However, the keys and values are first copied under a brief lock, and the map is not locked while f is called,
+// so f may call other methods of the SafeSliceMap, including Set and Delete, without causing a deadlock.
+// The iteration is weakly consistent: changes made to the map while ranging will not be seen by f.
+func (m *SafeSliceMap[K, V]) RangeSnapshot(f func(key K, value V) bool) {
+	if m == nil || m.sm.items == nil { // prevent unnecessary lock
+		return
+	}
+	m.mu.RLock()
+	keys := m.sm.Keys()
+	values := m.sm.Values()
+	m.mu.RUnlock()
+
+	for i, k := range keys {
+		if !f(k, values[i]) {
+			break
+		}
+	}
+}
+
+// RangeUnlocked calls the given function with every key and value in the map, in order, like Range, but without
+// holding the lock while f is called. This allows f to call other methods of the SafeSliceMap,
+// including conditionally deleting entries.
+//
+// The keys are copied under a brief lock, and then each value is fetched as it is needed.
+// This gives weaker consistency than Range: keys deleted during the iteration are skipped,
+// keys added during the iteration are not seen, and values are current as of when they are passed to f.
+func (m *SafeSliceMap[K, V]) RangeUnlocked(f func(key K, value V) bool) {
+	for _, k := range m.Keys() {
+		if v, ok := m.Load(k); ok {
+			if !f(k, v) {
+				break
+			}
+		}
+	}
+}
+
 // Equal returns true if all the keys and values are equal, regardless of the order.
 //
 // If the values are not comparable, you should implement the Equaler interface on the values.
 // Otherwise, you will get a runtime panic.
 func (m *SafeSliceMap[K, V]) Equal(m2 MapI[K, V]) bool {
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.sm.Equal(m2)
 }
 
+// EqualOrdered returns true if m2 has the same keys and values as the map, in the same order.
+// Use it with other ordered maps, like another SafeSliceMap, to check that items are in an exact sequence.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *SafeSliceMap[K, V]) EqualOrdered(m2 MapReader[K, V]) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sm.EqualOrdered(m2)
+}
+
 // Clear removes all the items in the map.
 func (m *SafeSliceMap[K, V]) Clear() {
-	m.Lock()
+	m.mu.Lock()
 	m.sm.Clear()
-	m.Unlock()
+	m.mu.Unlock()
 }
 
 // String outputs the map as a string.
@@ -247,8 +519,8 @@ func (m *SafeSliceMap[K, V]) KeysIter() iter.Seq[K] {
 		if m == nil || m.sm.items == nil {
 			return
 		}
-		m.RLock()
-		defer m.RUnlock()
+		m.mu.RLock()
+		defer m.mu.RUnlock()
 		m.sm.KeysIter()(yield)
 	}
 }
@@ -261,12 +533,55 @@ func (m *SafeSliceMap[K, V]) ValuesIter() iter.Seq[V] {
 		if m == nil || m.sm.items == nil {
 			return
 		}
-		m.RLock()
-		defer m.RUnlock()
+		m.mu.RLock()
+		defer m.mu.RUnlock()
 		m.sm.ValuesIter()(yield)
 	}
 }
 
+// KeysSnapshotIter returns an iterator over a copy of the keys in the map, in the order they were added or sorted.
+// The keys are copied under a brief lock, and the map is not locked while iterating,
+// so it is safe to call other methods of the SafeSliceMap from within the loop.
+// The iteration is weakly consistent: keys added or deleted during the loop will not be reflected.
+func (m *SafeSliceMap[K, V]) KeysSnapshotIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, k := range m.Keys() {
+			if !yield(k) {
+				break
+			}
+		}
+	}
+}
+
+// ValuesSnapshotIter returns an iterator over a copy of the values in the map, in the order they were added or sorted.
+// The values are copied under a brief lock, and the map is not locked while iterating,
+// so it is safe to call other methods of the SafeSliceMap from within the loop.
+// The iteration is weakly consistent: changes made during the loop will not be reflected.
+func (m *SafeSliceMap[K, V]) ValuesSnapshotIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.Values() {
+			if !yield(v) {
+				break
+			}
+		}
+	}
+}
+
+// Enumerate returns an iterator over all the items in the map in the order they were entered or sorted.
+// Each item is returned with its position in the map.
+// During this process, the map will be locked, so do not pass a function that will take
+// significant amounts of time, nor will call into other methods of the SafeSliceMap which might also need a lock.
+func (m *SafeSliceMap[K, V]) Enumerate() iter.Seq2[int, Pair[K, V]] {
+	return func(yield func(int, Pair[K, V]) bool) {
+		if m == nil || m.sm.items == nil {
+			return
+		}
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+		m.sm.Enumerate()(yield)
+	}
+}
+
 // Insert adds the values from seq to the end of the map.
 // Duplicate keys are overridden but not moved.
 // Will lock and unlock for each item in seq to give time to other go routines.
@@ -288,15 +603,24 @@ func CollectSafeSliceMap[K comparable, V any](seq iter.Seq2[K, V]) *SafeSliceMap
 	return m
 }
 
+// AsReadOnly returns a read-only view of the map, whose methods that would change the map panic with ErrReadOnly.
+// Changes made to the map through other references are seen by the view.
+func (m *SafeSliceMap[K, V]) AsReadOnly() *ReadOnlyMap[K, V] {
+	return NewReadOnlyMap[K, V](m)
+}
+
 // Clone returns a copy of the SafeSliceMap. This is a shallow clone of the keys and values:
-// the new keys and values are set using ordinary assignment. The order is preserved.
+// the new keys and values are set using ordinary assignment. The order and capacity are preserved,
+// but the OnEvict callback given to SetCapacity is not copied.
 func (m *SafeSliceMap[K, V]) Clone() *SafeSliceMap[K, V] {
 	m1 := new(SafeSliceMap[K, V])
-	m.RLock()
-	defer m.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	m1.sm.items = m.sm.items.Clone()
 	m1.sm.order = slices.Clone(m.sm.order)
 	m1.sm.lessF = m.sm.lessF
+	m1.sm.eqF = m.sm.eqF
+	m1.sm.capacity = m.sm.capacity
 	return m1
 }
 
@@ -305,7 +629,42 @@ func (m *SafeSliceMap[K, V]) Clone() *SafeSliceMap[K, V] {
 // This function locks the entire slice structure for the entirety of the call,
 // so be careful to avoid deadlocks when calling this on a very big structure.
 func (m *SafeSliceMap[K, V]) DeleteFunc(del func(K, V) bool) {
-	m.Lock()
-	defer m.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.sm.DeleteFunc(del)
 }
+
+// Filter returns a new SafeSliceMap with the items of the map for which keep returns true, in order.
+// The new map has the same sort function as the map, and the map is not changed.
+// The map is read locked while keep is called, so keep must not call methods of the SafeSliceMap that change it.
+func (m *SafeSliceMap[K, V]) Filter(keep func(K, V) bool) *SafeSliceMap[K, V] {
+	m1 := new(SafeSliceMap[K, V])
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sm := m.sm.Filter(keep)
+	m1.sm = *sm
+	return m1
+}
+
+// Hash returns a hash of the keys and values of the map, in order, made with seed.
+// Unlike Equal, the order of the items is part of the hash, so maps with the same items in different orders
+// will most likely have different hashes. Values are hashed with == semantics, not with an Equaler or a
+// function given to SetEqualFunc.
+// The values are hashed as interfaces, so Hash panics if a value is not comparable.
+func (m *SafeSliceMap[K, V]) Hash(seed maphash.Seed) uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sm.Hash(seed)
+}
+
+// CloneDeep returns a copy of the map in which each value that implements Cloner is copied by calling its
+// Clone method. Values that are Map types are copied with CloneDeep, so maps of maps are copied at every level.
+// Other values, including slices, are copied using ordinary assignment.
+// The map is read locked while the values are copied.
+func (m *SafeSliceMap[K, V]) CloneDeep() *SafeSliceMap[K, V] {
+	m1 := new(SafeSliceMap[K, V])
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	m1.sm = *m.sm.CloneDeep()
+	return m1
+}
@@ -169,3 +169,218 @@ func TestCollectSafeSliceMap(t *testing.T) {
 	expectedKeys := []string{"b", "a", "c"}
 	assert.Equal(t, keys, expectedKeys)
 }
+
+func TestSafeSliceMap_Enumerate(t *testing.T) {
+	m := NewSafeSliceMap[string, int]()
+	for range m.Enumerate() {
+		t.Error("empty map should not iterate")
+	}
+
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+	var keys []string
+	var values []int
+	var positions []int
+	for i, p := range m.Enumerate() {
+		positions = append(positions, i)
+		keys = append(keys, p.Key)
+		values = append(values, p.Value)
+	}
+	assert.Equal(t, []int{0, 1, 2}, positions)
+	assert.Equal(t, []string{"b", "c", "a"}, keys)
+	assert.Equal(t, []int{2, 3, 1}, values)
+}
+
+func TestSafeSliceMap_SnapshotIter(t *testing.T) {
+	m := NewSafeSliceMap[string, int]()
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	var keys []string
+	for k := range m.KeysSnapshotIter() {
+		keys = append(keys, k)
+		m.Delete(k) // must not deadlock
+	}
+	assert.Equal(t, []string{"b", "c", "a"}, keys)
+	assert.Equal(t, 0, m.Len())
+
+	m.Set("b", 2)
+	m.Set("a", 1)
+	var values []int
+	for v := range m.ValuesSnapshotIter() {
+		values = append(values, v)
+		m.Set("c", 3)
+	}
+	assert.Equal(t, []int{2, 1}, values)
+	assert.Equal(t, 3, m.Len())
+}
+
+func TestSafeSliceMap_HeadTail(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	assert.Equal(t, []string{"b", "c"}, m.Head(2).Keys())
+	assert.Equal(t, []string{"c", "a"}, m.Tail(2).Keys())
+	assert.Equal(t, []string{"b"}, m.HeadUntil("c").Keys())
+	assert.Equal(t, []string{"c", "a"}, m.TailFrom("c").Keys())
+
+	h := m.Head(1)
+	h.Set("d", 4)
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, 2, h.Len())
+}
+
+func TestSafeSliceMap_GetPage(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	keys, values := m.GetPage(1, 5)
+	assert.Equal(t, []string{"c", "a"}, keys)
+	assert.Equal(t, []int{3, 1}, values)
+}
+
+func TestSafeSliceMap_RangeSnapshot(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	var keys []string
+	m.RangeSnapshot(func(k string, v int) bool {
+		keys = append(keys, k)
+		if v > 1 {
+			m.Delete(k) // must not deadlock
+		}
+		return true
+	})
+	assert.Equal(t, []string{"b", "c", "a"}, keys)
+	assert.Equal(t, []string{"a"}, m.Keys())
+}
+
+func TestSafeSliceMap_RangeUnlocked(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	var values []int
+	m.RangeUnlocked(func(k string, v int) bool {
+		values = append(values, v)
+		if k == "b" {
+			m.Delete("c")
+			m.Set("a", 5)
+		}
+		return true
+	})
+	assert.Equal(t, []int{2, 5}, values)
+	assert.Equal(t, []string{"b", "a"}, m.Keys())
+
+	count := 0
+	m.RangeUnlocked(func(k string, v int) bool {
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+}
+
+func TestNewSafeSliceMap(t *testing.T) {
+	m := NewSafeSliceMap(map[string]int{"a": 1}, map[string]int{"b": 2, "a": 3})
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 3, m.Get("a"))
+	assert.Equal(t, 3, cap(m.sm.order))
+}
+
+func TestSafeSliceMap_Update(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("a", 1)
+	inc := func(old int, exists bool) (int, bool) {
+		return old + 1, true
+	}
+
+	v, ok := m.Update("b", inc)
+	assert.Equal(t, 3, v)
+	assert.True(t, ok)
+	v, ok = m.Update("c", inc)
+	assert.Equal(t, 1, v)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"b", "a", "c"}, m.Keys())
+	assert.Equal(t, []int{3, 1, 1}, m.Values())
+
+	v, ok = m.Update("b", func(old int, exists bool) (int, bool) {
+		return 0, false
+	})
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a", "c"}, m.Keys())
+}
+
+func TestSafeSliceMap_Do(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+	m.Do(func(view MapI[string, int]) {
+		view.Set("b", 2)
+		view.Set("a", 1)
+		view.Set("c", view.Get("a")+view.Get("b"))
+	})
+	assert.Equal(t, []string{"b", "a", "c"}, m.Keys())
+	assert.Equal(t, 3, m.Get("c"))
+}
+
+func TestSafeSliceMap_TryLoadTryStore(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+
+	assert.True(t, m.TryStore("a", 1))
+	v, found, ok := m.TryLoad("a")
+	assert.Equal(t, 1, v)
+	assert.True(t, found)
+	assert.True(t, ok)
+
+	m.Do(func(view MapI[string, int]) {
+		// the map is locked here, so these should fail without blocking
+		_, _, ok = m.TryLoad("a")
+		assert.False(t, ok)
+		assert.False(t, m.TryStore("b", 2))
+	})
+	assert.Equal(t, []string{"a"}, m.Keys())
+}
+
+func TestSafeSliceMap_SetCapacity(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+	var evicted []string
+	m.SetCapacity(2, func(k string, v int) {
+		evicted = append(evicted, k)
+	})
+	assert.Equal(t, 2, m.Cap())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	assert.Equal(t, []string{"b", "c"}, m.Keys())
+	assert.Equal(t, []string{"a"}, evicted)
+
+	m2 := m.Clone()
+	assert.Equal(t, 2, m2.Cap())
+	m2.Set("d", 4)
+	assert.Equal(t, []string{"c", "d"}, m2.Keys())
+	assert.Equal(t, []string{"a"}, evicted)
+
+	var m3 *SafeSliceMap[string, int]
+	assert.Equal(t, 0, m3.Cap())
+}
+
+func TestSafeSliceMap_EqualOrdered(t *testing.T) {
+	m1 := NewSafeSliceMap[string, int]()
+	m1.Set("a", 1)
+	m1.Set("b", 2)
+	m2 := NewSliceMap[string, int]()
+	m2.Set("a", 1)
+	m2.Set("b", 2)
+	assert.True(t, m1.EqualOrdered(m2))
+	m1.SetAt(0, "b", 2)
+	assert.False(t, m1.EqualOrdered(m2))
+	assert.True(t, m1.EqualOrdered(m1.Clone()))
+}
@@ -0,0 +1,348 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"strings"
+)
+
+// SafeSliceSet is a set that remembers the order its values were added, and that is safe for concurrent use.
+// It is built on a SafeSliceMap.
+//
+// Values are ranged in the order they were first added, so a SafeSliceSet can be used to remove duplicates
+// from values produced by several go routines, while keeping the order they arrived in.
+// Adding a value that is already in the set does not change its position.
+// Call SetSortFunc to keep the values sorted instead.
+//
+// The zero value is ready to use. Do not make a copy of a SafeSliceSet using the equality operator (=).
+// Use Clone instead.
+type SafeSliceSet[K comparable] struct {
+	m SafeSliceMap[K, struct{}]
+}
+
+// NewSafeSliceSet creates a new SafeSliceSet containing the given values, in order.
+func NewSafeSliceSet[K comparable](values ...K) *SafeSliceSet[K] {
+	s := new(SafeSliceSet[K])
+	s.Add(values...)
+	return s
+}
+
+// SetSortFunc sets a function that keeps the values sorted. The function returns true when v1 should come before v2.
+// To return to keeping the values in the order they were added, set the function to nil. Values that were sorted
+// will keep their sorted positions.
+func (s *SafeSliceSet[K]) SetSortFunc(f func(v1, v2 K) bool) {
+	if f == nil {
+		s.m.SetSortFunc(nil)
+		return
+	}
+	s.m.SetSortFunc(func(k1, k2 K, _, _ struct{}) bool {
+		return f(k1, k2)
+	})
+}
+
+// Add adds the values to the end of the set, in order, while holding the lock once.
+// Values that are already in the set do not change their position.
+func (s *SafeSliceSet[K]) Add(k ...K) SetI[K] {
+	if len(k) == 0 {
+		return s
+	}
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	for _, v := range k {
+		if !s.m.sm.Has(v) {
+			s.m.sm.Set(v, struct{}{})
+		}
+	}
+	return s
+}
+
+// AddNew adds the value to the end of the set, and returns true if it was not already in the set.
+// The check and the add are done while holding the lock once, so when several go routines add the same value,
+// only one of them gets true.
+func (s *SafeSliceSet[K]) AddNew(k K) bool {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	if s.m.sm.Has(k) {
+		return false
+	}
+	s.m.sm.Set(k, struct{}{})
+	return true
+}
+
+// Clear removes all the values from the set.
+func (s *SafeSliceSet[K]) Clear() {
+	s.m.Clear()
+}
+
+// Len returns the number of values in the set.
+func (s *SafeSliceSet[K]) Len() int {
+	if s == nil {
+		return 0
+	}
+	return s.m.Len()
+}
+
+// Range calls the given function for each value in the set, in order.
+// The function should return true to continue ranging, or false to stop.
+// The set is locked while ranging, so f must not call methods of the SafeSliceSet that change it.
+func (s *SafeSliceSet[K]) Range(f func(k K) bool) {
+	if s == nil {
+		return
+	}
+	s.m.Range(func(k K, _ struct{}) bool {
+		return f(k)
+	})
+}
+
+// Has returns true if the value exists in the set.
+func (s *SafeSliceSet[K]) Has(k K) bool {
+	if s == nil {
+		return false
+	}
+	return s.m.Has(k)
+}
+
+// First returns the first value of the set, and false if the set is empty.
+func (s *SafeSliceSet[K]) First() (k K, ok bool) {
+	if s == nil {
+		return
+	}
+	s.m.mu.RLock()
+	defer s.m.mu.RUnlock()
+	if len(s.m.sm.order) == 0 {
+		return
+	}
+	return s.m.sm.order[0], true
+}
+
+// Last returns the last value of the set, and false if the set is empty.
+func (s *SafeSliceSet[K]) Last() (k K, ok bool) {
+	if s == nil {
+		return
+	}
+	s.m.mu.RLock()
+	defer s.m.mu.RUnlock()
+	if len(s.m.sm.order) == 0 {
+		return
+	}
+	return s.m.sm.order[len(s.m.sm.order)-1], true
+}
+
+// Delete removes the value from the set. If the value does not exist, nothing happens.
+func (s *SafeSliceSet[K]) Delete(k K) {
+	if s == nil {
+		return
+	}
+	s.m.Delete(k)
+}
+
+// Values returns a new slice containing the values of the set, in order.
+func (s *SafeSliceSet[K]) Values() []K {
+	if s == nil {
+		return nil
+	}
+	return s.m.Keys()
+}
+
+// Merge adds the values from the given set to the set.
+// Deprecated: Call Copy instead.
+func (s *SafeSliceSet[K]) Merge(in SetI[K]) {
+	s.Copy(in)
+}
+
+// Copy adds the values from in to the end of the set, in the order that in ranges over them.
+func (s *SafeSliceSet[K]) Copy(in SetI[K]) {
+	if in == nil || in.Len() == 0 {
+		return
+	}
+	// get the values before locking, since in may be this set
+	s.Add(in.Values()...)
+}
+
+// Equal returns true if the two sets contain the same values, regardless of order.
+func (s *SafeSliceSet[K]) Equal(s2 SetI[K]) bool {
+	if s.Len() != s2.Len() {
+		return false
+	}
+	for _, k := range s2.Values() {
+		if !s.Has(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the set to a byte stream.
+func (s *SafeSliceSet[K]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(s.Values())
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a SafeSliceSet.
+func (s *SafeSliceSet[K]) UnmarshalBinary(data []byte) (err error) {
+	b := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(b)
+	var v []K
+	if err = dec.Decode(&v); err == nil {
+		s.Add(v...)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the set into a JSON list, in order.
+func (s *SafeSliceSet[K]) MarshalJSON() (out []byte, err error) {
+	return json.Marshal(s.Values())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json list to a SafeSliceSet.
+// The JSON must start with a list.
+func (s *SafeSliceSet[K]) UnmarshalJSON(in []byte) (err error) {
+	var v []K
+	if err = json.Unmarshal(in, &v); err == nil {
+		s.Add(v...)
+	}
+	return
+}
+
+// String returns the set as a string, in order.
+func (s *SafeSliceSet[K]) String() string {
+	var vals []string
+	for _, v := range s.Values() {
+		vals = append(vals, fmt.Sprintf("%#v", v))
+	}
+	return "{" + strings.Join(vals, ",") + "}"
+}
+
+// All returns an iterator over all the values in the set, in order.
+// The set is locked while iterating, so the loop must not call methods of the SafeSliceSet that change it.
+func (s *SafeSliceSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		s.Range(yield)
+	}
+}
+
+// Insert adds the values from seq to the end of the set.
+func (s *SafeSliceSet[K]) Insert(seq iter.Seq[K]) {
+	for k := range seq {
+		s.Add(k)
+	}
+}
+
+// CollectSafeSliceSet collects values from seq into a new SafeSliceSet
+// and returns it.
+func CollectSafeSliceSet[K comparable](seq iter.Seq[K]) *SafeSliceSet[K] {
+	s := NewSafeSliceSet[K]()
+	s.Insert(seq)
+	return s
+}
+
+// Union returns a new SafeSliceSet with the values that are in either the set or s2.
+// The values of the set come first, in order, followed by the new values of s2, in order.
+func (s *SafeSliceSet[K]) Union(s2 SetReader[K]) *SafeSliceSet[K] {
+	r := new(SafeSliceSet[K])
+	unionInto[K](r, s, s2)
+	return r
+}
+
+// Intersect returns a new SafeSliceSet with the values that are in both the set and s2, in the order of the set.
+func (s *SafeSliceSet[K]) Intersect(s2 SetReader[K]) *SafeSliceSet[K] {
+	r := new(SafeSliceSet[K])
+	intersectInto[K](r, s, s2)
+	return r
+}
+
+// Difference returns a new SafeSliceSet with the values that are in the set, but not in s2.
+func (s *SafeSliceSet[K]) Difference(s2 SetReader[K]) *SafeSliceSet[K] {
+	r := new(SafeSliceSet[K])
+	differenceInto[K](r, s, s2)
+	return r
+}
+
+// SymmetricDifference returns a new SafeSliceSet with the values that are in either the set or s2, but not in both.
+func (s *SafeSliceSet[K]) SymmetricDifference(s2 SetReader[K]) *SafeSliceSet[K] {
+	r := new(SafeSliceSet[K])
+	symmetricDifferenceInto[K](r, s, s2)
+	return r
+}
+
+// IsSubsetOf returns true if every value of the set is also in s2.
+func (s *SafeSliceSet[K]) IsSubsetOf(s2 SetReader[K]) bool {
+	if s2 == SetReader[K](s) {
+		return true
+	}
+	return IsSubset[K](s, s2)
+}
+
+// IsSupersetOf returns true if every value of s2 is also in the set.
+func (s *SafeSliceSet[K]) IsSupersetOf(s2 SetReader[K]) bool {
+	if s2 == SetReader[K](s) {
+		return true
+	}
+	return IsSubset[K](s2, s)
+}
+
+// IsDisjoint returns true if the set and s2 have no values in common.
+func (s *SafeSliceSet[K]) IsDisjoint(s2 SetReader[K]) bool {
+	if s2 == SetReader[K](s) {
+		return s.Len() == 0
+	}
+	return IsDisjoint[K](s, s2)
+}
+
+// AsReadOnly returns a read-only view of the set, whose methods that would change the set panic with ErrReadOnly.
+// Changes made to the set through other references are seen by the view.
+func (s *SafeSliceSet[K]) AsReadOnly() *ReadOnlySet[K] {
+	return NewReadOnlySet[K](s)
+}
+
+// Clone returns a copy of the SafeSliceSet, including its order and sort function.
+func (s *SafeSliceSet[K]) Clone() *SafeSliceSet[K] {
+	s2 := new(SafeSliceSet[K])
+	if s == nil {
+		return s2
+	}
+	c := s.m.Clone()
+	s2.m.sm = c.sm
+	return s2
+}
+
+// DeleteFunc deletes any values for which del returns true. Values are ranged in order.
+// The set is locked while del is called, so del must not call methods of the SafeSliceSet.
+func (s *SafeSliceSet[K]) DeleteFunc(del func(K) bool) {
+	s.m.DeleteFunc(func(k K, _ struct{}) bool {
+		return del(k)
+	})
+}
+
+// Filter returns a new SafeSliceSet with the values of the set for which keep returns true, in order.
+// The new set has the same sort function as the set, and the set is not changed.
+// The values are copied before keep is called, so keep may call methods of the SafeSliceSet.
+func (s *SafeSliceSet[K]) Filter(keep func(K) bool) *SafeSliceSet[K] {
+	s2 := new(SafeSliceSet[K])
+	if s == nil {
+		return s2
+	}
+	s.m.mu.RLock()
+	s2.m.sm.lessF = s.m.sm.lessF
+	s.m.mu.RUnlock()
+	for _, k := range s.Values() {
+		if keep(k) {
+			s2.Add(k)
+		}
+	}
+	return s2
+}
+
+// Hash returns a hash of the values of the set, in order, made with seed.
+// Unlike Equal, the order of the values is part of the hash, so sets with the same values in different orders
+// will most likely have different hashes.
+func (s *SafeSliceSet[K]) Hash(seed maphash.Seed) uint64 {
+	return hashOrderedValues(seed, s.Range)
+}
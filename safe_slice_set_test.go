@@ -0,0 +1,152 @@
+package maps
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeSliceSet_SetI(t *testing.T) {
+	runSetITests[SafeSliceSet[string]](t, makeSetI[SafeSliceSet[string]])
+}
+
+func init() {
+	gob.Register(new(SafeSliceSet[string]))
+}
+
+func ExampleSafeSliceSet() {
+	s := NewSafeSliceSet("c", "a")
+	s.Add("b", "a", "c")
+	fmt.Println(s)
+	// Output: {"c","a","b"}
+}
+
+func TestSafeSliceSet(t *testing.T) {
+	s := NewSafeSliceSet("b", "c", "a")
+	assert.Equal(t, []string{"b", "c", "a"}, s.Values())
+	s.Copy(NewSafeSliceSet("d", "a", "e"))
+	assert.Equal(t, []string{"b", "c", "a", "d", "e"}, s.Values())
+	s.Copy(s)
+	assert.Equal(t, 5, s.Len())
+
+	s2 := s.Clone()
+	s.Delete("c")
+	assert.Equal(t, `{"b","a","d","e"}`, s.String())
+	assert.Equal(t, `{"b","c","a","d","e"}`, s2.String(), "the clone is not changed")
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["b","a","d","e"]`, string(b))
+	s3 := new(SafeSliceSet[string])
+	assert.NoError(t, json.Unmarshal(b, s3))
+	assert.Equal(t, s.Values(), s3.Values())
+
+	s.SetSortFunc(func(v1, v2 string) bool {
+		return v1 < v2
+	})
+	s.Add("c")
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, s.Values())
+	s4 := CollectSafeSliceSet(s.All())
+	assert.True(t, s4.Equal(s))
+	assert.True(t, s.AsReadOnly().Has("a"))
+
+	var s5 *SafeSliceSet[string]
+	assert.Equal(t, 0, s5.Len())
+	assert.False(t, s5.Has("a"))
+	assert.Nil(t, s5.Values())
+	assert.Equal(t, 0, s5.Clone().Len())
+}
+
+func TestSafeSliceSet_Concurrent(t *testing.T) {
+	s := new(SafeSliceSet[string])
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				// each producer adds its own values in order, and every producer adds the shared values
+				s.Add(fmt.Sprint("p", i, "-", j), fmt.Sprint("shared", j))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 900, s.Len())
+
+	// the values of each producer keep their relative order
+	last := make(map[string]int)
+	for n, v := range s.Values() {
+		producer, _, _ := strings.Cut(v, "-")
+		if prev, ok := last[producer]; ok {
+			assert.Less(t, prev, n)
+		}
+		last[producer] = n
+	}
+}
+
+func TestSafeSliceSet_Filter(t *testing.T) {
+	s := NewSafeSliceSet(5, 1, 4, 2, 3)
+	s2 := s.Filter(func(k int) bool {
+		return k%2 == 1 && s.Has(k) // keep may use the set
+	})
+	assert.Equal(t, []int{5, 1, 3}, s2.Values())
+	assert.Equal(t, 5, s.Len())
+
+	s.SetSortFunc(func(v1, v2 int) bool {
+		return v1 < v2
+	})
+	s3 := s.Filter(func(k int) bool {
+		return k > 2
+	})
+	s3.Add(0)
+	assert.Equal(t, []int{0, 3, 4, 5}, s3.Values(), "the sort function is kept")
+
+	var s4 *SafeSliceSet[int]
+	assert.Equal(t, 0, s4.Filter(func(int) bool { return true }).Len())
+}
+
+func TestSafeSliceSet_AddNew(t *testing.T) {
+	s := new(SafeSliceSet[int])
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	added := 0
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				if s.AddNew(i) {
+					mu.Lock()
+					added++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 100, added, "each value is reported as new only once")
+	assert.Equal(t, 100, s.Len())
+}
+
+func TestSafeSliceSet_FirstLast(t *testing.T) {
+	s := NewSafeSliceSet("b", "c", "a")
+	k, ok := s.First()
+	assert.Equal(t, "b", k)
+	assert.True(t, ok)
+	k, _ = s.Last()
+	assert.Equal(t, "a", k)
+
+	s.Clear()
+	_, ok = s.First()
+	assert.False(t, ok)
+	_, ok = s.Last()
+	assert.False(t, ok)
+	var s2 *SafeSliceSet[string]
+	_, ok = s2.Last()
+	assert.False(t, ok)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"fmt"
+	"hash/maphash"
 	"iter"
 	"slices"
 )
@@ -61,6 +62,15 @@ func (m *Set[K]) Has(k K) bool {
 	return m.items.Has(k)
 }
 
+// Any returns a value from the set without copying the set, and false if the set is empty.
+// Which value is returned is not determinate.
+func (m *Set[K]) Any() (k K, ok bool) {
+	for k = range m.items {
+		return k, true
+	}
+	return
+}
+
 // Delete removes the value from the set. If the value does not exist, nothing happens.
 func (m *Set[K]) Delete(k K) {
 	m.items.Delete(k)
@@ -83,6 +93,16 @@ func (m *Set[K]) Add(k ...K) SetI[K] {
 	return m
 }
 
+// AddNew adds the value to the set, and returns true if it was not already in the set.
+// Use it to do something only the first time a value is seen.
+func (m *Set[K]) AddNew(k K) bool {
+	if m.items.Has(k) {
+		return false
+	}
+	m.Add(k)
+	return true
+}
+
 // Merge adds the values from the given set to the set.
 // Deprecated: Call Copy instead.
 func (m *Set[K]) Merge(in SetI[K]) {
@@ -202,6 +222,55 @@ func CollectSet[K comparable](seq iter.Seq[K]) *Set[K] {
 	return m
 }
 
+// Union returns a new Set with the values that are in either the set or s.
+func (m *Set[K]) Union(s SetReader[K]) *Set[K] {
+	r := new(Set[K])
+	unionInto[K](r, m, s)
+	return r
+}
+
+// Intersect returns a new Set with the values that are in both the set and s.
+func (m *Set[K]) Intersect(s SetReader[K]) *Set[K] {
+	r := new(Set[K])
+	intersectInto[K](r, m, s)
+	return r
+}
+
+// Difference returns a new Set with the values that are in the set, but not in s.
+func (m *Set[K]) Difference(s SetReader[K]) *Set[K] {
+	r := new(Set[K])
+	differenceInto[K](r, m, s)
+	return r
+}
+
+// SymmetricDifference returns a new Set with the values that are in either the set or s, but not in both.
+func (m *Set[K]) SymmetricDifference(s SetReader[K]) *Set[K] {
+	r := new(Set[K])
+	symmetricDifferenceInto[K](r, m, s)
+	return r
+}
+
+// IsSubsetOf returns true if every value of the set is also in s.
+func (m *Set[K]) IsSubsetOf(s SetReader[K]) bool {
+	return IsSubset[K](m, s)
+}
+
+// IsSupersetOf returns true if every value of s is also in the set.
+func (m *Set[K]) IsSupersetOf(s SetReader[K]) bool {
+	return IsSubset[K](s, m)
+}
+
+// IsDisjoint returns true if the set and s have no values in common.
+func (m *Set[K]) IsDisjoint(s SetReader[K]) bool {
+	return IsDisjoint[K](m, s)
+}
+
+// AsReadOnly returns a read-only view of the set, whose methods that would change the set panic with ErrReadOnly.
+// Changes made to the set through other references are seen by the view.
+func (m *Set[K]) AsReadOnly() *ReadOnlySet[K] {
+	return NewReadOnlySet[K](m)
+}
+
 // Clone returns a copy of the Set. This is a shallow clone:
 // the new keys and values are set using ordinary assignment.
 func (m *Set[K]) Clone() *Set[K] {
@@ -217,3 +286,22 @@ func (m *Set[K]) DeleteFunc(del func(K) bool) {
 	}
 	m.items.DeleteFunc(del2)
 }
+
+// Filter returns a new Set with the values of the set for which keep returns true.
+// The set is not changed.
+func (m *Set[K]) Filter(keep func(K) bool) *Set[K] {
+	m1 := new(Set[K])
+	m.Range(func(k K) bool {
+		if keep(k) {
+			m1.Add(k)
+		}
+		return true
+	})
+	return m1
+}
+
+// Hash returns a hash of the values of the set, made with seed. Sets that are Equal have the same hash.
+// Use it to detect changes, or to use the contents of the set as a key, without serializing the set.
+func (m *Set[K]) Hash(seed maphash.Seed) uint64 {
+	return hashValues(seed, m.Range)
+}
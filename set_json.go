@@ -0,0 +1,60 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSONObjectSet wraps a set so that it marshals to and from JSON as an object whose keys are the values
+// of the set, like {"a":true,"b":true}, rather than as a list. This is how JavaScript code and many
+// web APIs represent sets.
+//
+// All the other methods are passed on to the wrapped set, so a JSONObjectSet can be used anywhere a SetI is.
+// Since JSON object keys are strings, the values of the set must be strings, integers,
+// or types that implement encoding.TextMarshaler.
+type JSONObjectSet[K comparable] struct {
+	SetI[K]
+}
+
+// NewJSONObjectSet returns s wrapped in a JSONObjectSet. If s is nil, a new Set is wrapped.
+func NewJSONObjectSet[K comparable](s SetI[K]) *JSONObjectSet[K] {
+	if s == nil {
+		s = NewSet[K]()
+	}
+	return &JSONObjectSet[K]{s}
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the set into a JSON object,
+// with every value of the set as a key set to true.
+func (s *JSONObjectSet[K]) MarshalJSON() ([]byte, error) {
+	o := make(map[K]bool)
+	if s.SetI != nil {
+		s.Range(func(k K) bool {
+			o[k] = true
+			return true
+		})
+	}
+	return json.Marshal(o)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to add the keys of a JSON object to the set.
+// Keys whose values are false are not added. A JSON list is passed on to the wrapped set,
+// so either form can be read.
+func (s *JSONObjectSet[K]) UnmarshalJSON(in []byte) error {
+	if s.SetI == nil {
+		s.SetI = NewSet[K]()
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(in), []byte("{")) {
+		return s.SetI.UnmarshalJSON(in)
+	}
+	var o map[K]bool
+	if err := json.Unmarshal(in, &o); err != nil {
+		return err
+	}
+	for k, v := range o {
+		if v {
+			s.Add(k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func ExampleJSONObjectSet() {
+	s := NewJSONObjectSet[string](NewSafeSliceSet("a", "b"))
+	j, _ := json.Marshal(s)
+	fmt.Println(string(j))
+
+	var s2 JSONObjectSet[string]
+	_ = json.Unmarshal([]byte(`{"c":true,"d":false}`), &s2)
+	fmt.Println(s2.String())
+	// Output: {"a":true,"b":true}
+	// {"c"}
+}
+
+func TestJSONObjectSet(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		s := NewJSONObjectSet[int](NewBitSet(1, 5, 9))
+		j, err := json.Marshal(s)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"1":true,"5":true,"9":true}`, string(j))
+
+		s2 := NewJSONObjectSet[int](NewSparseSet())
+		assert.NoError(t, json.Unmarshal(j, s2))
+		assert.True(t, s2.Equal(NewSet(1, 5, 9)))
+	})
+	t.Run("list", func(t *testing.T) {
+		var s JSONObjectSet[string]
+		assert.NoError(t, json.Unmarshal([]byte(` ["a","b"]`), &s))
+		assert.True(t, s.Equal(NewSet("a", "b")))
+	})
+	t.Run("in a struct", func(t *testing.T) {
+		var v struct {
+			Tags JSONObjectSet[string] `json:"tags"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(`{"tags":{"x":true}}`), &v))
+		assert.True(t, v.Tags.Has("x"))
+		j, err := json.Marshal(&v)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"tags":{"x":true}}`, string(j))
+	})
+	t.Run("empty", func(t *testing.T) {
+		var s JSONObjectSet[string]
+		j, err := json.Marshal(&s)
+		assert.NoError(t, err)
+		assert.Equal(t, `{}`, string(j))
+	})
+	t.Run("errors", func(t *testing.T) {
+		s := NewJSONObjectSet[int](nil)
+		assert.Error(t, json.Unmarshal([]byte(`{"a":true}`), s))
+		assert.Error(t, json.Unmarshal([]byte(`{"1":5}`), s))
+	})
+}
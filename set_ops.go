@@ -0,0 +1,156 @@
+package maps
+
+// Union returns a new Set with the values that are in either a or b.
+func Union[K comparable](a, b SetReader[K]) *Set[K] {
+	s := new(Set[K])
+	unionInto(s, a, b)
+	return s
+}
+
+// Intersect returns a new Set with the values that are in both a and b.
+func Intersect[K comparable](a, b SetReader[K]) *Set[K] {
+	s := new(Set[K])
+	intersectInto(s, a, b)
+	return s
+}
+
+// Difference returns a new Set with the values that are in a, but not in b.
+func Difference[K comparable](a, b SetReader[K]) *Set[K] {
+	s := new(Set[K])
+	differenceInto(s, a, b)
+	return s
+}
+
+// SymmetricDifference returns a new Set with the values that are in either a or b, but not in both.
+func SymmetricDifference[K comparable](a, b SetReader[K]) *Set[K] {
+	s := new(Set[K])
+	symmetricDifferenceInto(s, a, b)
+	return s
+}
+
+// The functions below add the result of an operation to dst. The values of a are added before the values of b,
+// and in the order returned by Values, so ordered sets keep their order. Values is used rather than Range so that
+// no lock is held on a while b is read, which would deadlock a Safe set that is operated on with itself.
+
+// unionInto adds the values that are in either a or b to dst.
+func unionInto[K comparable](dst SetI[K], a, b SetReader[K]) {
+	if a != nil {
+		dst.Add(a.Values()...)
+	}
+	if b != nil {
+		dst.Add(b.Values()...)
+	}
+}
+
+// intersectInto adds the values that are in both a and b to dst.
+func intersectInto[K comparable](dst SetI[K], a, b SetReader[K]) {
+	if a == nil || b == nil {
+		return
+	}
+	for _, k := range a.Values() {
+		if b.Has(k) {
+			dst.Add(k)
+		}
+	}
+}
+
+// differenceInto adds the values that are in a, but not in b, to dst.
+func differenceInto[K comparable](dst SetI[K], a, b SetReader[K]) {
+	if a == nil {
+		return
+	}
+	for _, k := range a.Values() {
+		if b == nil || !b.Has(k) {
+			dst.Add(k)
+		}
+	}
+}
+
+// symmetricDifferenceInto adds the values that are in either a or b, but not in both, to dst.
+func symmetricDifferenceInto[K comparable](dst SetI[K], a, b SetReader[K]) {
+	differenceInto(dst, a, b)
+	differenceInto(dst, b, a)
+}
+
+// IsSubset returns true if every value of a is also in b. An empty set is a subset of every set.
+// The check stops at the first value of a that is not in b.
+func IsSubset[K comparable](a, b SetReader[K]) bool {
+	if a == nil || a.Len() == 0 {
+		return true
+	}
+	if b == nil || a.Len() > b.Len() {
+		return false
+	}
+	for _, k := range a.Values() {
+		if !b.Has(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns true if every value of b is also in a.
+func IsSuperset[K comparable](a, b SetReader[K]) bool {
+	return IsSubset(b, a)
+}
+
+// IsDisjoint returns true if a and b have no values in common.
+// The values of the smaller set are checked, and the check stops at the first value that is in both sets.
+func IsDisjoint[K comparable](a, b SetReader[K]) bool {
+	if a == nil || b == nil {
+		return true
+	}
+	if a.Len() > b.Len() {
+		a, b = b, a
+	}
+	for _, k := range a.Values() {
+		if b.Has(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToMap returns a new StdMap whose keys are the values of s, and whose values are made by calling f with each key.
+// Use it to expand a set of IDs into a map of the objects they refer to.
+func ToMap[K comparable, V any](s SetReader[K], f func(K) V) StdMap[K, V] {
+	if s == nil {
+		return StdMap[K, V]{}
+	}
+	keys := s.Values()
+	m := make(StdMap[K, V], len(keys))
+	for _, k := range keys {
+		m[k] = f(k)
+	}
+	return m
+}
+
+// ToSliceMap is like ToMap, but returns a SliceMap with the keys in the order that s returns its values.
+// For ordered sets, like SafeSliceSet, BitSet and SparseSet, the map keeps the order of the set.
+func ToSliceMap[K comparable, V any](s SetReader[K], f func(K) V) *SliceMap[K, V] {
+	m := new(SliceMap[K, V])
+	if s == nil {
+		return m
+	}
+	keys := s.Values()
+	m.items = make(StdMap[K, V], len(keys))
+	m.order = make([]K, 0, len(keys))
+	for _, k := range keys {
+		m.Set(k, f(k))
+	}
+	return m
+}
+
+// ValuesSet adds the values of m to dst, and returns dst. Choose the kind of set to return by passing it as dst, as in:
+//
+//	statuses := ValuesSet(NewSet[string](), orders)
+//
+// Use it with the set operations to ask questions about the values of a map, like which of a group of
+// statuses are present.
+func ValuesSet[K, V comparable, S SetI[V]](dst S, m MapReader[K, V]) S {
+	m.Range(func(_ K, v V) bool {
+		dst.Add(v)
+		return true
+	})
+	return dst
+}
@@ -0,0 +1,230 @@
+package maps
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleUnion() {
+	a := NewSet("a", "b", "c")
+	b := NewSafeSliceSet("b", "c", "d")
+	fmt.Println(slices.Sorted(Union[string](a, b).All()))
+	fmt.Println(slices.Sorted(Intersect[string](a, b).All()))
+	fmt.Println(slices.Sorted(Difference[string](a, b).All()))
+	fmt.Println(slices.Sorted(SymmetricDifference[string](a, b).All()))
+	// Output: [a b c d]
+	// [b c]
+	// [a]
+	// [a d]
+}
+
+func TestSetOps(t *testing.T) {
+	a := NewSet("a", "b", "c")
+	b := NewSet("b", "c", "d")
+	assert.True(t, Union[string](a, b).Equal(NewSet("a", "b", "c", "d")))
+	assert.True(t, Intersect[string](a, b).Equal(NewSet("b", "c")))
+	assert.True(t, Difference[string](a, b).Equal(NewSet("a")))
+	assert.True(t, SymmetricDifference[string](a, b).Equal(NewSet("a", "d")))
+	assert.Equal(t, 3, a.Len(), "the sets are not changed")
+	assert.Equal(t, 3, b.Len())
+
+	assert.True(t, Union[string](a, nil).Equal(a))
+	assert.Equal(t, 0, Intersect[string](a, nil).Len())
+	assert.True(t, Difference[string](a, nil).Equal(a))
+	assert.True(t, SymmetricDifference[string](nil, b).Equal(b))
+
+	// a set with itself
+	s := NewSafeSliceSet("a", "b")
+	assert.True(t, Union[string](s, s).Equal(s))
+	assert.Equal(t, 0, Difference[string](s, s).Len())
+}
+
+func TestSet_SetOps(t *testing.T) {
+	a := NewSet("a", "b", "c")
+	b := NewSafeSliceSet("b", "c", "d")
+	assert.True(t, a.Union(b).Equal(NewSet("a", "b", "c", "d")))
+	assert.True(t, a.Intersect(b).Equal(NewSet("b", "c")))
+	assert.True(t, a.Difference(b).Equal(NewSet("a")))
+	assert.True(t, a.SymmetricDifference(b).Equal(NewSet("a", "d")))
+	assert.Equal(t, 3, a.Len())
+}
+
+func TestSafeSliceSet_SetOps(t *testing.T) {
+	a := NewSafeSliceSet("c", "b", "a")
+	b := NewSafeSliceSet("d", "b", "e", "c")
+	assert.Equal(t, []string{"c", "b", "a", "d", "e"}, a.Union(b).Values())
+	assert.Equal(t, []string{"c", "b"}, a.Intersect(b).Values())
+	assert.Equal(t, []string{"a"}, a.Difference(b).Values())
+	assert.Equal(t, []string{"a", "d", "e"}, a.SymmetricDifference(b).Values())
+	assert.Equal(t, []string{"c", "b", "a"}, a.Intersect(a).Values())
+	assert.Equal(t, 3, a.Len())
+}
+
+func TestBitSet_SetOps(t *testing.T) {
+	for _, b := range []SetReader[int]{NewBitSet(2, 3, 200), NewSet(2, 3, 200)} {
+		a := NewBitSet(1, 2, 3, 100)
+		assert.Equal(t, []int{1, 2, 3, 100, 200}, a.Union(b).Values())
+		assert.Equal(t, []int{2, 3}, a.Intersect(b).Values())
+		assert.Equal(t, []int{1, 100}, a.Difference(b).Values())
+		assert.Equal(t, []int{1, 100, 200}, a.SymmetricDifference(b).Values())
+		assert.Equal(t, []int{1, 2, 3, 100}, a.Values())
+	}
+}
+
+func TestSparseSet_SetOps(t *testing.T) {
+	for _, b := range []SetReader[int]{NewSparseSet(2, 3, -200), NewSet(2, 3, -200)} {
+		a := NewSparseSet(1, 2, 3, 100000)
+		assert.Equal(t, []int{-200, 1, 2, 3, 100000}, a.Union(b).Values())
+		assert.Equal(t, []int{2, 3}, a.Intersect(b).Values())
+		assert.Equal(t, []int{1, 100000}, a.Difference(b).Values())
+		values := a.SymmetricDifference(b).Values()
+		assert.True(t, slices.IsSorted(values))
+		assert.Equal(t, []int{-200, 1, 100000}, values)
+		assert.Equal(t, 4, a.Len())
+	}
+}
+
+func ExampleIsSubset() {
+	granted := NewSet("read", "write", "delete")
+	needed := NewSet("read", "write")
+	fmt.Println(IsSubset[string](needed, granted))
+	fmt.Println(granted.IsSupersetOf(needed))
+	fmt.Println(needed.IsDisjoint(NewSet("admin")))
+	// Output: true
+	// true
+	// true
+}
+
+func TestSetPredicates(t *testing.T) {
+	type pair struct {
+		a, b SetReader[int]
+	}
+	tests := []struct {
+		name                   string
+		a, b                   []int
+		subset, superset, disj bool
+	}{
+		{"empty", nil, nil, true, true, true},
+		{"empty subset", nil, []int{1}, true, false, true},
+		{"subset", []int{1, 200}, []int{1, 2, 200}, true, false, false},
+		{"equal", []int{1, 2}, []int{2, 1}, true, true, false},
+		{"superset", []int{1, 2, 5000}, []int{5000}, false, true, false},
+		{"overlap", []int{1, 2}, []int{2, 3}, false, false, false},
+		{"disjoint", []int{1, 5000}, []int{2, 3, 70}, false, false, true},
+	}
+	for _, tt := range tests {
+		sets := []pair{
+			{NewSet(tt.a...), NewSet(tt.b...)},
+			{NewSafeSliceSet(tt.a...), NewSafeSliceSet(tt.b...)},
+			{NewBitSet(tt.a...), NewBitSet(tt.b...)},
+			{NewBitSet(tt.a...), NewSet(tt.b...)},
+			{NewSparseSet(tt.a...), NewSparseSet(tt.b...)},
+			{NewSparseSet(tt.a...), NewSet(tt.b...)},
+		}
+		for _, p := range sets {
+			name := fmt.Sprintf("%s %T %T", tt.name, p.a, p.b)
+			assert.Equal(t, tt.subset, IsSubset(p.a, p.b), name)
+			assert.Equal(t, tt.superset, IsSuperset(p.a, p.b), name)
+			assert.Equal(t, tt.disj, IsDisjoint(p.a, p.b), name)
+			m := p.a.(interface {
+				IsSubsetOf(SetReader[int]) bool
+				IsSupersetOf(SetReader[int]) bool
+				IsDisjoint(SetReader[int]) bool
+			})
+			assert.Equal(t, tt.subset, m.IsSubsetOf(p.b), name)
+			assert.Equal(t, tt.superset, m.IsSupersetOf(p.b), name)
+			assert.Equal(t, tt.disj, m.IsDisjoint(p.b), name)
+		}
+	}
+
+	s := NewSafeSliceSet(1, 2)
+	assert.True(t, s.IsSubsetOf(s))
+	assert.True(t, s.IsSupersetOf(s))
+	assert.False(t, s.IsDisjoint(s))
+	assert.True(t, IsSubset[int](nil, s))
+	assert.False(t, IsSubset[int](s, nil))
+	assert.True(t, IsDisjoint[int](s, nil))
+}
+
+func TestSetPredicates_Concurrent(t *testing.T) {
+	// Checking a SafeSliceSet against itself, or two sets in both orders, must not deadlock with waiting writers.
+	a := new(SafeSliceSet[int])
+	b := new(SafeSliceSet[int])
+	for i := range 200 {
+		a.Add(i)
+		b.Add(i)
+	}
+	b.Add(-1)
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 2000 {
+				a.Add(i + 1000)
+				a.Delete(i + 1000)
+				b.Add(i + 1000)
+				b.Delete(i + 1000)
+			}
+		}()
+	}
+	for _, p := range [][2]*SafeSliceSet[int]{{a, b}, {b, a}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 2000 {
+				IsSubset[int](p[0], p[0])
+				IsSubset[int](p[0], p[1])
+				IsDisjoint[int](p[0], p[1])
+			}
+		}()
+	}
+	wg.Wait()
+	assert.True(t, IsSubset[int](a, b))
+	assert.False(t, IsSubset[int](b, a))
+}
+
+func ExampleToSliceMap() {
+	ids := NewSafeSliceSet(3, 1, 2)
+	names := ToSliceMap[int](ids, func(id int) string {
+		return fmt.Sprint("user", id)
+	})
+	fmt.Println(names)
+	// Output: {3:"user3",1:"user1",2:"user2"}
+}
+
+func TestToMap(t *testing.T) {
+	m := ToMap[string](NewSet("a", "bb"), func(k string) int {
+		return len(k)
+	})
+	assert.Equal(t, StdMap[string, int]{"a": 1, "bb": 2}, m)
+	assert.Equal(t, 0, ToMap[string, int](nil, nil).Len())
+
+	m2 := ToSliceMap[int](NewSparseSet(5, -1, 3), func(k int) int {
+		return k * k
+	})
+	assert.Equal(t, []int{-1, 3, 5}, m2.Keys())
+	assert.Equal(t, []int{1, 9, 25}, m2.Values())
+	assert.Equal(t, 0, ToSliceMap[int, int](nil, nil).Len())
+}
+
+func TestValuesSet(t *testing.T) {
+	m := NewSliceMap[string, string]()
+	m.Set("x", "open")
+	m.Set("y", "closed")
+	m.Set("z", "open")
+
+	s := ValuesSet(NewSet[string](), m)
+	assert.True(t, s.Equal(NewSet("open", "closed")))
+	assert.True(t, IsSubset[string](NewSet("open"), s))
+
+	ordered := ValuesSet(NewSafeSliceSet[string](), m)
+	assert.Equal(t, []string{"open", "closed"}, ordered.Values())
+
+	b := ValuesSet(NewBitSet(), NewStdMap(map[string]int{"a": 3, "b": 3, "c": 1}))
+	assert.Equal(t, []int{1, 3}, b.Values())
+}
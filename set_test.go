@@ -43,3 +43,33 @@ func TestSet_Clone(t *testing.T) {
 	m3 := m2.Clone()
 	assert.True(t, m1.Equal(m3))
 }
+
+func TestSet_Filter(t *testing.T) {
+	m := NewSet("a", "bb", "cc")
+	m2 := m.Filter(func(k string) bool {
+		return len(k) == 2
+	})
+	assert.True(t, m2.Equal(NewSet("bb", "cc")))
+	assert.Equal(t, 3, m.Len())
+}
+
+func ExampleSet_AddNew() {
+	seen := new(Set[string])
+	for _, s := range []string{"a", "b", "a", "c", "b"} {
+		if seen.AddNew(s) {
+			fmt.Print(s)
+		}
+	}
+	// Output: abc
+}
+
+func TestSet_Any(t *testing.T) {
+	m := NewSet("a", "b")
+	k, ok := m.Any()
+	assert.True(t, ok)
+	assert.True(t, m.Has(k))
+
+	m = new(Set[string])
+	_, ok = m.Any()
+	assert.False(t, ok)
+}
@@ -1,20 +1,77 @@
 package maps
 
-import "iter"
+import (
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"iter"
+	"slices"
+)
 
 // SetI is the interface used by all the Set types.
 type SetI[K comparable] interface {
+	SetReader[K]
 	Add(k ...K) SetI[K]
 	Clear()
+	Merge(SetI[K])
+	Delete(k K)
+	Insert(seq iter.Seq[K])
+	DeleteFunc(del func(K) bool)
+	json.Unmarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// SetReader is the part of SetI that reads a set without changing it.
+type SetReader[K comparable] interface {
 	Len() int
 	Range(func(k K) bool)
 	Has(k K) bool
 	Values() []K
-	Merge(SetI[K])
 	Equal(SetI[K]) bool
-	Delete(k K)
 	All() iter.Seq[K]
-	Insert(seq iter.Seq[K])
-	Clone() *Set[K]
-	DeleteFunc(del func(K) bool)
+	String() string
+	json.Marshaler
+	encoding.BinaryMarshaler
+}
+
+// SetEqualFunc returns true if s1 and s2 have the same number of values, every value of s1 is equal
+// to some value of s2, and every value of s2 is equal to some value of s1.
+//
+// The function eq is called to compare the values, which lets you compare sets of different types,
+// or compare values loosely, like strings without regard to case.
+// Since eq can not be used to look up values, this takes time proportional to the product of the sizes of the sets.
+func SetEqualFunc[A, B comparable](s1 SetReader[A], s2 SetReader[B], eq func(A, B) bool) bool {
+	if s1.Len() != s2.Len() {
+		return false
+	}
+	v1 := s1.Values()
+	v2 := s2.Values()
+	for _, a := range v1 {
+		if !slices.ContainsFunc(v2, func(b B) bool { return eq(a, b) }) {
+			return false
+		}
+	}
+	for _, b := range v2 {
+		if !slices.ContainsFunc(v1, func(a A) bool { return eq(a, b) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetReduce calls f with an accumulator and each value of s, and returns the final accumulator.
+// The accumulator starts as init, and is replaced by the value f returns. Values are ranged in the order of s.
+func SetReduce[K comparable, A any](s SetReader[K], init A, f func(A, K) A) A {
+	acc := init
+	s.Range(func(k K) bool {
+		acc = f(acc, k)
+		return true
+	})
+	return acc
+}
+
+// SetCompare compares the values of s1 and s2 in order, like slices.Compare.
+// Use it with ordered sets, like SafeSliceSet, BitSet and SparseSet, to sort them.
+func SetCompare[K cmp.Ordered](s1, s2 SetReader[K]) int {
+	return slices.Compare(s1.Values(), s2.Values())
 }
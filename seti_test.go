@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"encoding/gob"
 	"encoding/json"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -262,3 +265,59 @@ func testSetDeleteFunc(t *testing.T, f makeSetF) {
 		assert.Equal(t, 1, m1.Len())
 	})
 }
+
+func TestSetI_Marshal(t *testing.T) {
+	sets := []SetI[int]{NewSet(1, 2), NewSafeSliceSet(1, 2), NewBitSet(1, 2), NewSparseSet(1, 2), NewSet(1, 2).AsReadOnly()}
+	for _, s := range sets {
+		assert.Contains(t, []string{"{1,2}", "{2,1}"}, s.String())
+
+		j, err := s.MarshalJSON()
+		assert.NoError(t, err)
+		var values []int
+		assert.NoError(t, json.Unmarshal(j, &values))
+		assert.ElementsMatch(t, []int{1, 2}, values)
+
+		b, err := s.MarshalBinary()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, b)
+	}
+}
+
+func ExampleSetEqualFunc() {
+	names := NewSet("Alice", "BOB")
+	ids := NewSet("alice", "bob")
+	fmt.Println(SetEqualFunc[string, string](names, ids, strings.EqualFold))
+	// Output: true
+}
+
+func TestSetEqualFunc(t *testing.T) {
+	ints := NewBitSet(1, 2, 3)
+	strs := NewSafeSliceSet("3", "1", "2")
+	eq := func(i int, s string) bool {
+		return strconv.Itoa(i) == s
+	}
+	assert.True(t, SetEqualFunc[int, string](ints, strs, eq))
+	strs.Add("4")
+	assert.False(t, SetEqualFunc[int, string](ints, strs, eq))
+	ints.Add(5)
+	assert.False(t, SetEqualFunc[int, string](ints, strs, eq))
+	assert.True(t, SetEqualFunc[int, string](NewBitSet(), NewSet[string](), eq))
+
+	// every value of s2 must be matched too
+	always := func(a, b string) bool { return a == "x" || b == "x" }
+	assert.False(t, SetEqualFunc[string, string](NewSet("x", "y"), NewSet("z", "w"), always))
+}
+
+func TestSetReduce(t *testing.T) {
+	s := NewSafeSliceSet("a", "b", "c")
+	assert.Equal(t, "abc", SetReduce(s, "", func(acc string, k string) string { return acc + k }))
+	assert.Equal(t, 10, SetReduce(NewBitSet(1, 2, 3, 4), 0, func(acc int, k int) int { return acc + k }))
+	assert.Equal(t, 7, SetReduce(NewSet[string](), 7, func(acc int, _ string) int { return acc + 1 }))
+}
+
+func TestSetCompare(t *testing.T) {
+	assert.Equal(t, 0, SetCompare(NewBitSet(1, 2), NewSparseSet(2, 1)))
+	assert.Equal(t, -1, SetCompare(NewBitSet(1, 2), NewBitSet(1, 3)))
+	assert.Equal(t, 1, SetCompare(NewSafeSliceSet("b"), NewSafeSliceSet("a", "c")))
+	assert.Equal(t, -1, SetCompare(NewSafeSliceSet[string](), NewSafeSliceSet("a")))
+}
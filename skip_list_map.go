@@ -0,0 +1,446 @@
+package maps
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// skipListMaxLevel is the number of levels in a skip list, which is enough for well over a billion items.
+const skipListMaxLevel = 24
+
+// SkipListMap is a sorted map that is safe for concurrent use. It is a skip list whose keys are kept in
+// ascending order, so it can be ranged in order and scanned by key range with Between.
+//
+// Reads never lock, even while another go routine is writing. Writes are serialized by a mutex, but they
+// do not block readers, which makes a SkipListMap a good fit for sorted data that is read much more often
+// than it is written. Compare with SafeSliceMap, where a single RWMutex makes readers wait for writers.
+//
+// Ranging over a SkipListMap does not lock, so the range function may call any method of the map.
+// Like sync.Map, a range reflects the map at some point during the range, and may or may not see
+// changes made while it runs.
+//
+// The zero value is ready to use. Do not make a copy of a SkipListMap using the equality operator (=). Use Clone instead.
+type SkipListMap[K cmp.Ordered, V any] struct {
+	mu    sync.Mutex // serializes writers
+	head  atomic.Pointer[skipNode[K, V]]
+	count atomic.Int64
+}
+
+// skipNode is an item in a SkipListMap. The head of the list is a node with no key.
+type skipNode[K cmp.Ordered, V any] struct {
+	key     K
+	value   atomic.Pointer[V]
+	next    []atomic.Pointer[skipNode[K, V]]
+	deleted atomic.Bool
+}
+
+// NewSkipListMap creates a new SkipListMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new SkipListMap.
+func NewSkipListMap[K cmp.Ordered, V any](sources ...map[K]V) *SkipListMap[K, V] {
+	m := new(SkipListMap[K, V])
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// first returns the first node of level 0, or nil if the map is empty.
+func (m *SkipListMap[K, V]) first() *skipNode[K, V] {
+	if m == nil {
+		return nil
+	}
+	if h := m.head.Load(); h != nil {
+		return h.next[0].Load()
+	}
+	return nil
+}
+
+// find returns the first node with a key that is not less than k, without locking.
+// The node may have been deleted.
+func (m *SkipListMap[K, V]) find(k K) *skipNode[K, V] {
+	if m == nil {
+		return nil
+	}
+	x := m.head.Load()
+	if x == nil {
+		return nil
+	}
+	var next *skipNode[K, V]
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		for next = x.next[i].Load(); next != nil && next.key < k; next = x.next[i].Load() {
+			x = next
+		}
+	}
+	return next
+}
+
+// findForWrite returns the node before k at each level. The caller must hold the lock.
+func (m *SkipListMap[K, V]) findForWrite(k K) (preds [skipListMaxLevel]*skipNode[K, V]) {
+	x := m.head.Load()
+	if x == nil {
+		x = &skipNode[K, V]{next: make([]atomic.Pointer[skipNode[K, V]], skipListMaxLevel)}
+		m.head.Store(x)
+	}
+	for i := skipListMaxLevel - 1; i >= 0; i-- {
+		for next := x.next[i].Load(); next != nil && next.key < k; next = x.next[i].Load() {
+			x = next
+		}
+		preds[i] = x
+	}
+	return
+}
+
+// randomLevel returns the number of levels for a new node.
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.IntN(4) == 0 {
+		level++
+	}
+	return level
+}
+
+// setLocked sets the key to the value. The caller must hold the lock.
+func (m *SkipListMap[K, V]) setLocked(k K, v V) {
+	preds := m.findForWrite(k)
+	if n := preds[0].next[0].Load(); n != nil && n.key == k {
+		n.value.Store(&v)
+		return
+	}
+	n := &skipNode[K, V]{key: k, next: make([]atomic.Pointer[skipNode[K, V]], randomLevel())}
+	n.value.Store(&v)
+	for i := range n.next {
+		n.next[i].Store(preds[i].next[i].Load())
+	}
+	// Link from the bottom up, so readers that find the node at a higher level can always continue below it.
+	for i := range n.next {
+		preds[i].next[i].Store(n)
+	}
+	m.count.Add(1)
+}
+
+// deleteLocked removes the key and returns its value. The caller must hold the lock.
+func (m *SkipListMap[K, V]) deleteLocked(k K) (v V, ok bool) {
+	if m.head.Load() == nil {
+		return
+	}
+	preds := m.findForWrite(k)
+	n := preds[0].next[0].Load()
+	if n == nil || n.key != k {
+		return
+	}
+	n.deleted.Store(true)
+	// The node keeps its own links, so readers that are on it can continue past it.
+	for i := len(n.next) - 1; i >= 0; i-- {
+		preds[i].next[i].Store(n.next[i].Load())
+	}
+	m.count.Add(-1)
+	return *n.value.Load(), true
+}
+
+// Set sets the key to the given value.
+func (m *SkipListMap[K, V]) Set(k K, v V) {
+	m.mu.Lock()
+	m.setLocked(k, v)
+	m.mu.Unlock()
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load(). Load does not lock.
+func (m *SkipListMap[K, V]) Load(k K) (v V, ok bool) {
+	if n := m.find(k); n != nil && n.key == k && !n.deleted.Load() {
+		return *n.value.Load(), true
+	}
+	return
+}
+
+// Get returns the value based on its key. If the key does not exist, the zero value will be returned.
+// Get does not lock.
+func (m *SkipListMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the given key exists in the map. Has does not lock.
+func (m *SkipListMap[K, V]) Has(k K) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *SkipListMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	v, _ = m.deleteLocked(k)
+	m.mu.Unlock()
+	return
+}
+
+// Clear removes all the items in the map.
+func (m *SkipListMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.head.Store(nil)
+	m.count.Store(0)
+	m.mu.Unlock()
+}
+
+// Len returns the number of items in the map. Len does not lock.
+func (m *SkipListMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return int(m.count.Load())
+}
+
+// Range calls the given function for each key,value pair in the map, in ascending key order.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+// The map is not locked, so f may call any method of the map.
+func (m *SkipListMap[K, V]) Range(f func(k K, v V) bool) {
+	for n := m.first(); n != nil; n = n.next[0].Load() {
+		if !n.deleted.Load() && !f(n.key, *n.value.Load()) {
+			return
+		}
+	}
+}
+
+// Between returns an iterator over the items with keys from lo up to, but not including, hi, in ascending order.
+// The map is not locked during the iteration.
+func (m *SkipListMap[K, V]) Between(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := m.find(lo); n != nil && n.key < hi; n = n.next[0].Load() {
+			if !n.deleted.Load() && !yield(n.key, *n.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map, in ascending order.
+func (m *SkipListMap[K, V]) Keys() (keys []K) {
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return
+}
+
+// Values returns a new slice containing the values of the map, in the order of their keys.
+func (m *SkipListMap[K, V]) Values() (values []V) {
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *SkipListMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the items from in to the map, overwriting any conflicting keys.
+func (m *SkipListMap[K, V]) Copy(in MapI[K, V]) {
+	if in == nil || in.Len() == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	in.Range(func(k K, v V) bool {
+		m.setLocked(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *SkipListMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m2 == nil {
+		return m.Len() == 0
+	}
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k K, v V) bool {
+		if v2, ok := m.Load(k); !ok || !equalValues(v, v2) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// String outputs the map as a string, in ascending key order.
+func (m *SkipListMap[K, V]) String() string {
+	var s string
+
+	if m == nil {
+		return s
+	}
+
+	s = "{"
+	m.Range(func(k K, v V) bool {
+		s += fmt.Sprintf(`%#v:%#v,`, k, v)
+		return true
+	})
+	s = strings.TrimRight(s, ",")
+	s += "}"
+	return s
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *SkipListMap[K, V]) MarshalBinary() (data []byte, err error) {
+	buf := new(bytes.Buffer)
+	encoder := gob.NewEncoder(buf)
+
+	var keys []K
+	var values []V
+	m.Range(func(k K, v V) bool {
+		keys = append(keys, k)
+		values = append(values, v)
+		return true
+	})
+	err = encoder.Encode(keys)
+	if err == nil {
+		err = encoder.Encode(values)
+	}
+	data = buf.Bytes()
+	return
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// SkipListMap.
+func (m *SkipListMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var keys []K
+	var values []V
+
+	buf := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buf)
+	if err = dec.Decode(&keys); err == nil {
+		err = dec.Decode(&values)
+	}
+	if err == nil && len(keys) != len(values) {
+		err = fmt.Errorf("maps: cannot unmarshal %d keys with %d values", len(keys), len(values))
+	}
+	if err == nil {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.head.Store(nil)
+		m.count.Store(0)
+		for i, k := range keys {
+			m.setLocked(k, values[i])
+		}
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *SkipListMap[K, V]) MarshalJSON() (out []byte, err error) {
+	items := make(map[K]V, m.Len())
+	m.Range(func(k K, v V) bool {
+		items[k] = v
+		return true
+	})
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a SkipListMap.
+// The JSON must start with an object.
+func (m *SkipListMap[K, V]) UnmarshalJSON(in []byte) (err error) {
+	var items map[K]V
+	if err = json.Unmarshal(in, &items); err == nil {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.head.Store(nil)
+		m.count.Store(0)
+		for k, v := range items {
+			m.setLocked(k, v)
+		}
+	}
+	return
+}
+
+// All returns an iterator over all the items in the map, in ascending key order.
+// The map is not locked during the iteration.
+func (m *SkipListMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map, in ascending order.
+// The map is not locked during the iteration.
+func (m *SkipListMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map, in the order of their keys.
+// The map is not locked during the iteration.
+func (m *SkipListMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *SkipListMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, v := range seq {
+		m.setLocked(k, v)
+	}
+}
+
+// CollectSkipListMap collects key-value pairs from seq into a new SkipListMap
+// and returns it.
+func CollectSkipListMap[K cmp.Ordered, V any](seq iter.Seq2[K, V]) *SkipListMap[K, V] {
+	m := new(SkipListMap[K, V])
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the SkipListMap. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *SkipListMap[K, V]) Clone() *SkipListMap[K, V] {
+	m1 := new(SkipListMap[K, V])
+	m1.Insert(m.All())
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+// The map is locked while del is called, so del must not call methods of the map that change it.
+func (m *SkipListMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for n := m.first(); n != nil; n = n.next[0].Load() {
+		if !n.deleted.Load() && del(n.key, *n.value.Load()) {
+			m.deleteLocked(n.key)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkipListMap_Mapi(t *testing.T) {
+	runMapiTests[SkipListMap[string, int]](t, makeMapi[SkipListMap[string, int]])
+}
+
+func init() {
+	gob.Register(new(SkipListMap[string, int]))
+}
+
+func ExampleSkipListMap() {
+	m := new(SkipListMap[int, string])
+	m.Set(30, "c")
+	m.Set(10, "a")
+	m.Set(20, "b")
+	m.Set(40, "d")
+	fmt.Println(m)
+	for k, v := range m.Between(15, 40) {
+		fmt.Println(k, v)
+	}
+	// Output: {10:"a",20:"b",30:"c",40:"d"}
+	// 20 b
+	// 30 c
+}
+
+func TestSkipListMap(t *testing.T) {
+	var m SkipListMap[int, int]
+	assert.Equal(t, 0, m.Get(1))
+	assert.Equal(t, 0, m.Delete(1))
+	assert.Nil(t, m.Keys())
+
+	for _, i := range []int{5, 3, 9, 1, 7} {
+		m.Set(i, i*10)
+	}
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, m.Keys())
+	assert.Equal(t, []int{10, 30, 50, 70, 90}, m.Values())
+	m.Set(3, 33)
+	assert.Equal(t, 33, m.Get(3))
+	assert.Equal(t, 5, m.Len())
+
+	assert.Equal(t, 33, m.Delete(3))
+	assert.False(t, m.Has(3))
+	assert.Equal(t, 4, m.Len())
+	assert.Equal(t, []int{1, 5, 7, 9}, m.Keys())
+
+	var between []int
+	for k := range m.Between(2, 9) {
+		between = append(between, k)
+	}
+	assert.Equal(t, []int{5, 7}, between)
+
+	// Range can change the map
+	m.Range(func(k, v int) bool {
+		if k == 5 {
+			m.Delete(7)
+			m.Set(6, 60)
+		}
+		return true
+	})
+	assert.Equal(t, []int{1, 5, 6, 9}, m.Keys())
+
+	m.DeleteFunc(func(k, _ int) bool {
+		return k%2 == 1
+	})
+	assert.Equal(t, []int{6}, m.Keys())
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	m2.Set(1, 1)
+	assert.False(t, m.Has(1))
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	m.Set(2, 2)
+	assert.Equal(t, []int{2}, m.Keys())
+
+	assert.Error(t, m.UnmarshalBinary(gobEncode(t, []int{1, 2}, []int{1})))
+	assert.Equal(t, []int{2}, m.Keys())
+}
+
+func TestSkipListMap_Concurrent(t *testing.T) {
+	m := new(SkipListMap[string, int])
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				k := strconv.Itoa(w*1000 + i)
+				m.Set(k, i)
+				if i%3 == 0 {
+					m.Delete(k)
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				keys := m.Keys()
+				assert.True(t, slices.IsSorted(keys))
+				m.Get("10")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 4*133, m.Len())
+	assert.Len(t, m.Keys(), 4*133)
+}
+
+func TestSkipListMap_Nil(t *testing.T) {
+	var m *SkipListMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Keys())
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "", m.String())
+	assert.NotPanics(t, func() {
+		m.Clear()
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"fmt"
+	"hash/maphash"
 	"iter"
 	"slices"
 	"sort"
@@ -26,22 +27,44 @@ import (
 // This will allow you to swap in a different kind of Map just by changing the type.
 //
 // Call SetSortFunc to give the map a function that will keep the keys sorted in a particular order.
+//
+// Call SetCapacity to limit the number of items in the map. When a new key is added to a full map,
+// the item at the front of the map is removed to make room for it.
 type SliceMap[K comparable, V any] struct {
-	items StdMap[K, V]
-	order []K
-	lessF func(key1, key2 K, val1, val2 V) bool
+	items    StdMap[K, V]
+	order    []K
+	lessF    func(key1, key2 K, val1, val2 V) bool
+	capacity int
+	onEvict  func(k K, v V)
+	eqF      func(a, b V) bool
 }
 
 // NewSliceMap creates a new SliceMap.
-// Pass in zero or more standard maps and the contents of those maps will be copied to the new SafeMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new SliceMap.
+// The new map is pre-sized to hold all the items in sources.
 func NewSliceMap[K comparable, V any](sources ...map[K]V) *SliceMap[K, V] {
 	m := new(SliceMap[K, V])
+	if size := sourcesLen(sources); size > 0 {
+		m.items = make(map[K]V, size)
+		m.order = make([]K, 0, size)
+	}
 	for _, i := range sources {
 		m.Copy(Cast(i))
 	}
 	return m
 }
 
+// SetEqualFunc sets a function that Equal and EqualOrdered use to compare values, instead of comparing them with == or
+// their Equaler interface. Use it when the values are types you do not own that are not comparable,
+// or when values should be compared loosely. To go back to the default comparison, set the function to nil.
+// The function is not serialized.
+func (m *SliceMap[K, V]) SetEqualFunc(eq func(a, b V) bool) {
+	if m == nil {
+		panic("cannot set an equal function on a nil SliceMap")
+	}
+	m.eqF = eq
+}
+
 // SetSortFunc sets the sort function which will determine the order of the items in the map
 // on an ongoing basis. Normally, items will iterate in the order they were added.
 //
@@ -61,10 +84,62 @@ func (m *SliceMap[K, V]) SetSortFunc(f func(key1, key2 K, val1, val2 V) bool) {
 	}
 }
 
+// SetCapacity limits the map to hold at most capacity items. When a new key is added to a full map,
+// the item at the front of the map, which is the oldest item unless there is a sort function, is removed
+// to make room for it. If the map already holds more than capacity items, items are removed from the front
+// until it fits.
+//
+// If onEvict is not nil, it is called with each item that is removed to make room.
+// It is not called for items removed by Delete, DeleteFunc or Clear.
+//
+// A capacity of zero or less removes the limit.
+func (m *SliceMap[K, V]) SetCapacity(capacity int, onEvict func(k K, v V)) {
+	if m == nil {
+		panic("cannot set the capacity of a nil SliceMap")
+	}
+	m.capacity = max(capacity, 0)
+	m.onEvict = onEvict
+	m.trim()
+}
+
+// Cap returns the maximum number of items the map can hold, or zero if there is no limit.
+func (m *SliceMap[K, V]) Cap() int {
+	if m == nil {
+		return 0
+	}
+	return m.capacity
+}
+
+// trim removes items from the front of the map until it is within its capacity.
+func (m *SliceMap[K, V]) trim() {
+	for m.capacity > 0 && len(m.order) > m.capacity {
+		k, v, _ := m.popFront()
+		if m.onEvict != nil {
+			m.onEvict(k, v)
+		}
+	}
+}
+
+// popFront removes and returns the item at the front of the map.
+func (m *SliceMap[K, V]) popFront() (k K, v V, ok bool) {
+	if len(m.order) == 0 {
+		return
+	}
+	k = m.order[0]
+	v = m.items[k]
+	var zero K
+	m.order[0] = zero // do not hold on to the key in the unused part of the slice
+	m.order = m.order[1:]
+	delete(m.items, k)
+	return k, v, true
+}
+
 // Set sets the given key to the given value.
 //
 // If the key already exists, the range order will not change. If you want the order
 // to change, call Delete first, and then Set.
+//
+// If the map has a capacity and the key is new, the item at the front of the map may be removed to make room.
 func (m *SliceMap[K, V]) Set(key K, val V) {
 	var ok bool
 	var oldVal V
@@ -100,6 +175,76 @@ func (m *SliceMap[K, V]) Set(key K, val V) {
 		}
 	}
 	m.items[key] = val
+	if !ok {
+		m.trim()
+	}
+}
+
+// SetMany sets all the given key and value pairs, in order.
+// Space for the new items is allocated once before they are added.
+func (m *SliceMap[K, V]) SetMany(pairs ...Pair[K, V]) {
+	if m == nil {
+		panic("cannot set a value on a nil SliceMap")
+	}
+	if m.items == nil {
+		m.items = make(map[K]V, len(pairs))
+	}
+	m.order = slices.Grow(m.order, len(pairs))
+	for _, p := range pairs {
+		m.Set(p.Key, p.Value)
+	}
+}
+
+// GetMany returns the values for the given keys, in the same order as the keys.
+// The zero value is returned for keys that do not exist.
+func (m *SliceMap[K, V]) GetMany(keys ...K) []V {
+	var items StdMap[K, V]
+	if m != nil {
+		items = m.items
+	}
+	return items.GetMany(keys...)
+}
+
+// GetMultiple returns a new SliceMap with the items of the given keys, in the order of the map.
+// Keys that do not exist are skipped. The new map has the same sort function as the map.
+func (m *SliceMap[K, V]) GetMultiple(keys ...K) *SliceMap[K, V] {
+	m2 := new(SliceMap[K, V])
+	if m == nil {
+		return m2
+	}
+	m2.lessF = m.lessF
+	m2.eqF = m.eqF
+	m2.items = m.items.GetMultiple(keys...)
+	if len(m2.items) == 0 {
+		return m2
+	}
+	m2.order = make([]K, 0, len(m2.items))
+	for _, k := range m.order {
+		if m2.items.Has(k) {
+			m2.order = append(m2.order, k)
+		}
+	}
+	return m2
+}
+
+// DeleteMany removes all the given keys from the map, and returns the number of keys that were in the map.
+// The order of the map is updated in one pass, rather than once for each key.
+func (m *SliceMap[K, V]) DeleteMany(keys ...K) (deleted int) {
+	if m == nil {
+		return
+	}
+	if len(keys) == 1 {
+		if _, ok := m.LoadAndDelete(keys[0]); ok {
+			deleted = 1
+		}
+		return
+	}
+	if deleted = m.items.DeleteMany(keys...); deleted > 0 {
+		m.order = slices.DeleteFunc(m.order, func(k K) bool {
+			return !m.items.Has(k)
+		})
+	}
+	return
 }
 
 // SetAt sets the given key to the given value, but also inserts it at the index specified.
@@ -134,16 +279,42 @@ func (m *SliceMap[K, V]) SetAt(index int, key K, val V) {
 	m.order[index] = key
 
 	m.items[key] = val
+	m.trim()
 }
 
 // Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
 func (m *SliceMap[K, V]) Delete(key K) (val V) {
+	val, _ = m.LoadAndDelete(key)
+	return
+}
+
+// GetOrSet returns the value of the key if it exists. Otherwise, it sets the key to v and returns v.
+// A new key is added like Set adds it.
+func (m *SliceMap[K, V]) GetOrSet(key K, val V) V {
+	if v, ok := m.Load(key); ok {
+		return v
+	}
+	m.Set(key, val)
+	return val
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+// If the key is new, it is added like Set adds it.
+func (m *SliceMap[K, V]) Swap(key K, val V) (previous V, loaded bool) {
+	previous, loaded = m.Load(key)
+	m.Set(key, val)
+	return
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m *SliceMap[K, V]) LoadAndDelete(key K) (val V, loaded bool) {
 	if m == nil {
 		return
 	}
 
-	if _, ok := m.items[key]; ok {
-		val = m.items[key]
+	if val, loaded = m.items[key]; loaded {
 		if m.lessF != nil {
 			loc := sort.Search(len(m.items), func(n int) bool {
 				return !m.lessF(m.order[n], key, m.items[m.order[n]], val)
@@ -209,6 +380,84 @@ func (m *SliceMap[K, V]) GetKeyAt(position int) (key K) {
 	return
 }
 
+// GetPage returns the keys and values of up to limit items, starting at position offset.
+// If offset is out of bounds, nil slices are returned.
+func (m *SliceMap[K, V]) GetPage(offset, limit int) (keys []K, values []V) {
+	if m == nil || offset < 0 || offset >= len(m.order) || limit <= 0 {
+		return
+	}
+	end := min(offset+limit, len(m.order))
+	keys = slices.Clone(m.order[offset:end])
+	values = make([]V, len(keys))
+	for i, k := range keys {
+		values[i] = m.items[k]
+	}
+	return
+}
+
+// Head returns a new SliceMap containing the first n items of the map.
+// If n is greater than the length of the map, the entire map is copied.
+// The new map will use the same sort function as the current map.
+func (m *SliceMap[K, V]) Head(n int) *SliceMap[K, V] {
+	return m.subMap(0, n)
+}
+
+// Tail returns a new SliceMap containing the last n items of the map.
+// If n is greater than the length of the map, the entire map is copied.
+// The new map will use the same sort function as the current map.
+func (m *SliceMap[K, V]) Tail(n int) *SliceMap[K, V] {
+	l := m.Len()
+	return m.subMap(l-min(max(n, 0), l), l)
+}
+
+// HeadUntil returns a new SliceMap containing the items that come before the given key.
+// The item with the given key is not included. If the key does not exist, the entire map is copied.
+func (m *SliceMap[K, V]) HeadUntil(key K) *SliceMap[K, V] {
+	i := m.indexOf(key)
+	if i < 0 {
+		i = m.Len()
+	}
+	return m.subMap(0, i)
+}
+
+// TailFrom returns a new SliceMap containing the item with the given key and all the items that come after it.
+// If the key does not exist, the new map will be empty.
+func (m *SliceMap[K, V]) TailFrom(key K) *SliceMap[K, V] {
+	i := m.indexOf(key)
+	if i < 0 {
+		i = m.Len()
+	}
+	return m.subMap(i, m.Len())
+}
+
+// indexOf returns the position of the given key, or -1 if the key does not exist.
+func (m *SliceMap[K, V]) indexOf(key K) int {
+	if m == nil {
+		return -1
+	}
+	return slices.Index(m.order, key)
+}
+
+// subMap returns a new SliceMap with the items from position start up to but not including end.
+func (m *SliceMap[K, V]) subMap(start, end int) *SliceMap[K, V] {
+	m1 := new(SliceMap[K, V])
+	if m == nil {
+		return m1
+	}
+	m1.lessF = m.lessF
+	m1.eqF = m.eqF
+	end = min(end, len(m.order))
+	if start >= end {
+		return m1
+	}
+	m1.order = slices.Clone(m.order[start:end])
+	m1.items = make(map[K]V, len(m1.order))
+	for _, k := range m1.order {
+		m1.items[k] = m.items[k]
+	}
+	return m1
+}
+
 // Values returns a slice of the values in the order they were added or sorted.
 func (m *SliceMap[K, V]) Values() (values []V) {
 	if m == nil {
@@ -273,6 +522,7 @@ func (m *SliceMap[K, V]) UnmarshalBinary(data []byte) (err error) {
 	if err == nil {
 		m.items = items
 		m.order = order
+		m.trim()
 	}
 	return err
 }
@@ -303,6 +553,7 @@ func (m *SliceMap[K, V]) UnmarshalJSON(data []byte) (err error) {
 			m.order[i] = k
 			i++
 		}
+		m.trim()
 	}
 	return
 }
@@ -310,16 +561,26 @@ func (m *SliceMap[K, V]) UnmarshalJSON(data []byte) (err error) {
 // Merge the given map into the current one.
 // Deprecated: use Copy instead.
 func (m *SliceMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the keys and values of in into the current one.
+// Duplicate keys will have the values replaced, but not the order.
+func (m *SliceMap[K, V]) Copy(in MapI[K, V]) {
 	in.Range(func(k K, v V) bool {
 		m.Set(k, v)
 		return true
 	})
 }
 
-// Copy copies the keys and values of in into the current one.
-// Duplicate keys will have the values replaced, but not the order.
-func (m *SliceMap[K, V]) Copy(in MapI[K, V]) {
+// CopyFunc copies the keys and values of in into this map. New keys are added like Set adds them.
+// When a key is in both maps, resolve is called with the key, the existing value and the incoming value,
+// and the value it returns is stored. Use it to combine values, like adding counts. If resolve is nil, CopyFunc works like Copy.
+func (m *SliceMap[K, V]) CopyFunc(in MapI[K, V], resolve func(k K, existing, incoming V) V) {
 	in.Range(func(k K, v V) bool {
+		if existing, ok := m.Load(k); ok && resolve != nil {
+			v = resolve(k, existing, v)
+		}
 		m.Set(k, v)
 		return true
 	})
@@ -346,7 +607,29 @@ func (m *SliceMap[K, V]) Equal(m2 MapI[K, V]) bool {
 	if m == nil {
 		return m2 == nil || m2.Len() == 0
 	}
-	return m.items.Equal(m2)
+	return equalWithKeyFunc(nil, m.eqF, m.items, m2)
+}
+
+// EqualOrdered returns true if m2 has the same keys and values as the map, in the same order.
+// Use it with other ordered maps, like another SliceMap, to check that items are in an exact sequence.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *SliceMap[K, V]) EqualOrdered(m2 MapReader[K, V]) bool {
+	if m.Len() != m2.Len() {
+		return false
+	}
+	i := 0
+	ret := true
+	m2.Range(func(k K, v V) bool {
+		if i >= len(m.order) || m.order[i] != k || !equalValuesFunc(m.eqF, m.items[k], v) {
+			ret = false
+			return false
+		}
+		i++
+		return true
+	})
+	return ret
 }
 
 // Clear removes all the items in the map.
@@ -411,6 +694,21 @@ func (m *SliceMap[K, V]) ValuesIter() iter.Seq[V] {
 	}
 }
 
+// Enumerate returns an iterator over all the items in the map in the order they were entered or sorted.
+// Each item is returned with its position in the map.
+func (m *SliceMap[K, V]) Enumerate() iter.Seq2[int, Pair[K, V]] {
+	return func(yield func(int, Pair[K, V]) bool) {
+		if m == nil || m.items == nil {
+			return
+		}
+		for i, k := range m.order {
+			if !yield(i, Pair[K, V]{k, m.items[k]}) {
+				break
+			}
+		}
+	}
+}
+
 // Insert adds the values from seq to the end of the map.
 // Duplicate keys are overridden but not moved.
 func (m *SliceMap[K, V]) Insert(seq iter.Seq2[K, V]) {
@@ -427,13 +725,56 @@ func CollectSliceMap[K comparable, V any](seq iter.Seq2[K, V]) *SliceMap[K, V] {
 	return m
 }
 
+// ConcatSliceMaps returns a new SliceMap containing the items of all the given maps, in the order given.
+// If a key appears in more than one map, the value from the later map is used,
+// but the key keeps the position where it first appeared. Nil maps are skipped.
+// The new map does not have a sort function.
+func ConcatSliceMaps[K comparable, V any](ms ...*SliceMap[K, V]) *SliceMap[K, V] {
+	return ConcatSliceMapsFunc(nil, ms...)
+}
+
+// ConcatSliceMapsFunc is like ConcatSliceMaps, but calls resolve when a key appears in more than one map.
+// The value returned by resolve is stored in the new map. If resolve is nil, the incoming value is used.
+func ConcatSliceMapsFunc[K comparable, V any](resolve func(k K, existing, incoming V) V, ms ...*SliceMap[K, V]) *SliceMap[K, V] {
+	var size int
+	for _, m := range ms {
+		size += m.Len()
+	}
+	m1 := new(SliceMap[K, V])
+	m1.items = make(map[K]V, size)
+	m1.order = make([]K, 0, size)
+	for _, m := range ms {
+		m.Range(func(k K, v V) bool {
+			if existing, ok := m1.items[k]; ok {
+				if resolve != nil {
+					v = resolve(k, existing, v)
+				}
+			} else {
+				m1.order = append(m1.order, k)
+			}
+			m1.items[k] = v
+			return true
+		})
+	}
+	return m1
+}
+
+// AsReadOnly returns a read-only view of the map, whose methods that would change the map panic with ErrReadOnly.
+// Changes made to the map through other references are seen by the view.
+func (m *SliceMap[K, V]) AsReadOnly() *ReadOnlyMap[K, V] {
+	return NewReadOnlyMap[K, V](m)
+}
+
 // Clone returns a copy of the SliceMap. This is a shallow clone of the keys and values:
-// the new keys and values are set using ordinary assignment. The order is preserved.
+// the new keys and values are set using ordinary assignment. The order and capacity are preserved,
+// but the OnEvict callback given to SetCapacity is not copied.
 func (m *SliceMap[K, V]) Clone() *SliceMap[K, V] {
 	m1 := new(SliceMap[K, V])
 	m1.items = m.items.Clone()
 	m1.order = slices.Clone(m.order)
 	m1.lessF = m.lessF
+	m1.eqF = m.eqF
+	m1.capacity = m.capacity
 	return m1
 }
 
@@ -447,3 +788,49 @@ func (m *SliceMap[K, V]) DeleteFunc(del func(K, V) bool) {
 		}
 	}
 }
+
+// Filter returns a new SliceMap with the items of the map for which keep returns true, in order.
+// The new map has the same sort function as the map, and the map is not changed.
+func (m *SliceMap[K, V]) Filter(keep func(K, V) bool) *SliceMap[K, V] {
+	m1 := new(SliceMap[K, V])
+	if m == nil {
+		return m1
+	}
+	m1.lessF = m.lessF
+	m1.eqF = m.eqF
+	for _, k := range m.order {
+		if v := m.items[k]; keep(k, v) {
+			if m1.items == nil {
+				m1.items = make(StdMap[K, V])
+			}
+			m1.items[k] = v
+			m1.order = append(m1.order, k)
+		}
+	}
+	return m1
+}
+
+// Hash returns a hash of the keys and values of the map, in order, made with seed.
+// Unlike Equal, the order of the items is part of the hash, so maps with the same items in different orders
+// will most likely have different hashes. Values are hashed with == semantics, not with an Equaler or a
+// function given to SetEqualFunc.
+// The values are hashed as interfaces, so Hash panics if a value is not comparable.
+func (m *SliceMap[K, V]) Hash(seed maphash.Seed) uint64 {
+	return hashOrderedItems(seed, m.Range)
+}
+
+// CloneDeep returns a copy of the map in which each value that implements Cloner is copied by calling its
+// Clone method. Values that are Map types are copied with CloneDeep, so maps of maps are copied at every level.
+// Other values, including slices, are copied using ordinary assignment.
+func (m *SliceMap[K, V]) CloneDeep() *SliceMap[K, V] {
+	m1 := new(SliceMap[K, V])
+	if m == nil {
+		return m1
+	}
+	m1.items = m.items.CloneDeep()
+	m1.order = slices.Clone(m.order)
+	m1.lessF = m.lessF
+	m1.eqF = m.eqF
+	m1.capacity = m.capacity
+	return m1
+}
@@ -0,0 +1,87 @@
+package maps
+
+// Cursor is a stateful position within a SliceMap or SafeSliceMap that can be moved forwards and backwards,
+// similar to a database cursor. Cursors are useful when you need to pull items from
+// a map one at a time, like when merging two ordered maps.
+//
+// A new Cursor is positioned before the first item, so call Next to move to the first item.
+//
+// The Cursor tracks a position, not a key. If the map is changed while the Cursor is in use,
+// the Cursor will continue from the same position in the changed map.
+type Cursor[K comparable, V any] struct {
+	m   cursorMap[K, V]
+	pos int
+}
+
+// cursorMap is the interface to the maps that a Cursor can move through.
+type cursorMap[K comparable, V any] interface {
+	Len() int
+	GetAt(position int) V
+	GetKeyAt(position int) K
+	indexOf(key K) int
+}
+
+// Cursor returns a new Cursor over the map that is positioned before the first item.
+func (m *SliceMap[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{m: m, pos: -1}
+}
+
+// Cursor returns a new Cursor over the map that is positioned before the first item.
+// Each movement of the Cursor and each call to Key or Value briefly locks the map, so the Cursor
+// will not prevent other go routines from changing the map while it is in use.
+func (m *SafeSliceMap[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{m: m, pos: -1}
+}
+
+// Next moves the cursor to the next item and returns true if that item exists.
+// If there are no more items, the cursor is positioned after the last item and false is returned.
+func (c *Cursor[K, V]) Next() bool {
+	if c.pos < c.m.Len() {
+		c.pos++
+	}
+	return c.Valid()
+}
+
+// Prev moves the cursor to the previous item and returns true if that item exists.
+// If there are no previous items, the cursor is positioned before the first item and false is returned.
+func (c *Cursor[K, V]) Prev() bool {
+	if c.pos > c.m.Len() {
+		c.pos = c.m.Len()
+	}
+	if c.pos >= 0 {
+		c.pos--
+	}
+	return c.Valid()
+}
+
+// Seek moves the cursor to the given key and returns true if the key was found.
+// If the key is not found, the cursor is not moved.
+func (c *Cursor[K, V]) Seek(key K) bool {
+	i := c.m.indexOf(key)
+	if i < 0 {
+		return false
+	}
+	c.pos = i
+	return true
+}
+
+// Valid returns true if the cursor is positioned on an item.
+func (c *Cursor[K, V]) Valid() bool {
+	return c.pos >= 0 && c.pos < c.m.Len()
+}
+
+// Position returns the position of the cursor in the map.
+// Before the first item it is -1, and after the last item it is the length of the map.
+func (c *Cursor[K, V]) Position() int {
+	return c.pos
+}
+
+// Key returns the key at the current position. If the cursor is not on an item, the zero value is returned.
+func (c *Cursor[K, V]) Key() K {
+	return c.m.GetKeyAt(c.pos)
+}
+
+// Value returns the value at the current position. If the cursor is not on an item, the zero value is returned.
+func (c *Cursor[K, V]) Value() V {
+	return c.m.GetAt(c.pos)
+}
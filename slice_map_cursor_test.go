@@ -0,0 +1,110 @@
+package maps
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleCursor() {
+	m1 := new(SliceMap[int, string])
+	m1.Set(1, "a")
+	m1.Set(4, "d")
+	m2 := new(SliceMap[int, string])
+	m2.Set(2, "b")
+	m2.Set(3, "c")
+	m2.Set(5, "e")
+
+	// merge two ordered maps
+	c1 := m1.Cursor()
+	c2 := m2.Cursor()
+	ok1 := c1.Next()
+	ok2 := c2.Next()
+	for ok1 || ok2 {
+		if !ok2 || (ok1 && c1.Key() < c2.Key()) {
+			fmt.Print(c1.Value())
+			ok1 = c1.Next()
+		} else {
+			fmt.Print(c2.Value())
+			ok2 = c2.Next()
+		}
+	}
+	// Output: abcde
+}
+
+func TestCursor(t *testing.T) {
+	m := new(SliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	c := m.Cursor()
+	assert.False(t, c.Valid())
+	assert.Equal(t, -1, c.Position())
+	assert.Equal(t, "", c.Key())
+	assert.Equal(t, 0, c.Value())
+
+	assert.True(t, c.Next())
+	assert.Equal(t, "b", c.Key())
+	assert.Equal(t, 2, c.Value())
+	assert.True(t, c.Next())
+	assert.True(t, c.Next())
+	assert.Equal(t, "a", c.Key())
+	assert.False(t, c.Next())
+	assert.False(t, c.Next())
+	assert.Equal(t, 3, c.Position())
+
+	assert.True(t, c.Prev())
+	assert.Equal(t, "a", c.Key())
+	assert.True(t, c.Prev())
+	assert.True(t, c.Prev())
+	assert.Equal(t, "b", c.Key())
+	assert.False(t, c.Prev())
+	assert.False(t, c.Prev())
+	assert.Equal(t, -1, c.Position())
+
+	assert.True(t, c.Seek("c"))
+	assert.Equal(t, 3, c.Value())
+	assert.False(t, c.Seek("z"))
+	assert.Equal(t, "c", c.Key())
+	assert.True(t, c.Next())
+	assert.Equal(t, "a", c.Key())
+
+	// shrinking the map past the cursor
+	m.Delete("a")
+	m.Delete("b")
+	assert.False(t, c.Valid())
+	assert.True(t, c.Prev())
+	assert.Equal(t, "c", c.Key())
+}
+
+func TestCursor_Nil(t *testing.T) {
+	var m *SliceMap[string, int]
+	c := m.Cursor()
+	assert.False(t, c.Next())
+	assert.False(t, c.Prev())
+	assert.False(t, c.Seek("a"))
+	assert.Equal(t, "", c.Key())
+}
+
+func TestSafeSliceMap_Cursor(t *testing.T) {
+	m := new(SafeSliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	c := m.Cursor()
+	var keys []string
+	for c.Next() {
+		keys = append(keys, c.Key())
+		m.Set(c.Key(), c.Value()*10) // must not deadlock
+	}
+	assert.Equal(t, []string{"b", "c", "a"}, keys)
+	assert.Equal(t, []int{20, 30, 10}, m.Values())
+
+	assert.True(t, c.Seek("c"))
+	assert.True(t, c.Prev())
+	assert.Equal(t, "b", c.Key())
+	assert.False(t, c.Seek("z"))
+}
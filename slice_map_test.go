@@ -217,3 +217,268 @@ func TestCollectSliceMap(t *testing.T) {
 	expectedKeys := []string{"b", "a", "c"}
 	assert.Equal(t, keys, expectedKeys)
 }
+
+func ExampleSliceMap_Enumerate() {
+	m := new(SliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+	for i, p := range m.Enumerate() {
+		fmt.Println(i, p.Key, p.Value)
+	}
+	// Output: 0 b 2
+	// 1 c 3
+	// 2 a 1
+}
+
+func TestSliceMap_Enumerate(t *testing.T) {
+	var m *SliceMap[string, int]
+	for range m.Enumerate() {
+		t.Error("nil map should not iterate")
+	}
+
+	m = NewSliceMap[string, int]()
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+	var positions []int
+	for i := range m.Enumerate() {
+		positions = append(positions, i)
+		if i == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1}, positions)
+}
+
+func ExampleSliceMap_Head() {
+	m := new(SliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+	fmt.Println(m.Head(2))
+	fmt.Println(m.Tail(2))
+	// Output: {"b":2,"c":3}
+	// {"c":3,"a":1}
+}
+
+func TestSliceMap_HeadTail(t *testing.T) {
+	m := new(SliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	assert.Equal(t, 0, m.Head(0).Len())
+	assert.Equal(t, 0, m.Head(-1).Len())
+	assert.Equal(t, []string{"b", "c", "a"}, m.Head(5).Keys())
+	assert.Equal(t, 0, m.Tail(0).Len())
+	assert.Equal(t, 0, m.Tail(-1).Len())
+	assert.Equal(t, []string{"b", "c", "a"}, m.Tail(5).Keys())
+
+	assert.Equal(t, []string{"b"}, m.HeadUntil("c").Keys())
+	assert.Equal(t, 0, m.HeadUntil("b").Len())
+	assert.Equal(t, []string{"b", "c", "a"}, m.HeadUntil("z").Keys())
+	assert.Equal(t, []string{"c", "a"}, m.TailFrom("c").Keys())
+	assert.Equal(t, 0, m.TailFrom("z").Len())
+
+	// new maps are independent
+	h := m.Head(1)
+	h.Set("b", 5)
+	assert.Equal(t, 2, m.Get("b"))
+
+	// sort function is kept
+	m.SetSortFunc(func(k1, k2 string, v1, v2 int) bool {
+		return k1 < k2
+	})
+	h = m.Head(2)
+	h.Set("aa", 0)
+	assert.Equal(t, []string{"a", "aa", "b"}, h.Keys())
+
+	var m2 *SliceMap[string, int]
+	assert.Equal(t, 0, m2.Head(1).Len())
+	assert.Equal(t, 0, m2.Tail(1).Len())
+	assert.Equal(t, 0, m2.HeadUntil("a").Len())
+	assert.Equal(t, 0, m2.TailFrom("a").Len())
+}
+
+func ExampleConcatSliceMaps() {
+	m1 := new(SliceMap[string, int])
+	m1.Set("b", 2)
+	m1.Set("a", 1)
+	m2 := new(SliceMap[string, int])
+	m2.Set("c", 3)
+	m2.Set("b", 4)
+	fmt.Println(ConcatSliceMaps(m1, m2))
+	// Output: {"b":4,"a":1,"c":3}
+}
+
+func TestConcatSliceMapsFunc(t *testing.T) {
+	m1 := new(SliceMap[string, int])
+	m1.Set("b", 2)
+	m1.Set("a", 1)
+	m2 := new(SliceMap[string, int])
+	m2.Set("c", 3)
+	m2.Set("b", 4)
+
+	m := ConcatSliceMapsFunc(func(k string, existing, incoming int) int {
+		return existing + incoming
+	}, m1, nil, m2, m1)
+	assert.Equal(t, []string{"b", "a", "c"}, m.Keys())
+	assert.Equal(t, []int{8, 2, 3}, m.Values())
+
+	assert.Equal(t, 0, ConcatSliceMaps[string, int]().Len())
+}
+
+func TestSliceMap_GetPage(t *testing.T) {
+	m := new(SliceMap[string, int])
+	m.Set("b", 2)
+	m.Set("c", 3)
+	m.Set("a", 1)
+
+	tests := []struct {
+		name   string
+		offset int
+		limit  int
+		keys   []string
+		values []int
+	}{
+		{"first", 0, 2, []string{"b", "c"}, []int{2, 3}},
+		{"last", 2, 2, []string{"a"}, []int{1}},
+		{"all", 0, 10, []string{"b", "c", "a"}, []int{2, 3, 1}},
+		{"past end", 3, 2, nil, nil},
+		{"negative offset", -1, 2, nil, nil},
+		{"zero limit", 0, 0, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, values := m.GetPage(tt.offset, tt.limit)
+			assert.Equal(t, tt.keys, keys)
+			assert.Equal(t, tt.values, values)
+		})
+	}
+
+	var m2 *SliceMap[string, int]
+	keys, values := m2.GetPage(0, 1)
+	assert.Nil(t, keys)
+	assert.Nil(t, values)
+}
+
+func TestNewSliceMap(t *testing.T) {
+	m := NewSliceMap(map[string]int{"a": 1}, map[string]int{"b": 2, "a": 3})
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 3, m.Get("a"))
+	assert.Equal(t, "a", m.GetKeyAt(0))
+	assert.Equal(t, 3, cap(m.order))
+
+	m = NewSliceMap[string, int]()
+	assert.Equal(t, 0, m.Len())
+	m.Set("a", 1)
+	assert.Equal(t, 1, m.Get("a"))
+}
+
+func ExampleSliceMap_SetCapacity() {
+	m := new(SliceMap[int, string])
+	m.SetCapacity(3, func(k int, v string) {
+		fmt.Println("evicted", k)
+	})
+	for i := 1; i <= 5; i++ {
+		m.Set(i, fmt.Sprint("item ", i))
+	}
+	fmt.Println(m.Keys())
+	// Output: evicted 1
+	// evicted 2
+	// [3 4 5]
+}
+
+func TestSliceMap_SetCapacity(t *testing.T) {
+	m := NewSliceMap(map[string]int{"a": 1})
+	m.Set("b", 2)
+	m.Set("c", 3)
+	var evicted []string
+	m.SetCapacity(2, func(k string, v int) {
+		evicted = append(evicted, k)
+	})
+	assert.Equal(t, 2, m.Cap())
+	assert.Equal(t, []string{"b", "c"}, m.Keys(), "setting the capacity trims the map")
+
+	m.Set("b", 20)
+	assert.Equal(t, []string{"a"}, evicted, "changing a value does not evict")
+	m.Set("d", 4)
+	assert.Equal(t, []string{"a", "b"}, evicted)
+	assert.Equal(t, []string{"c", "d"}, m.Keys())
+	m.SetAt(1, "e", 5)
+	assert.Equal(t, []string{"e", "d"}, m.Keys())
+	m.Delete("e")
+	assert.Equal(t, []string{"a", "b", "c"}, evicted, "deleting does not call the callback")
+
+	m2 := m.Clone()
+	assert.Equal(t, 2, m2.Cap())
+	m2.Set("f", 6)
+	m2.Set("g", 7)
+	assert.Equal(t, []string{"f", "g"}, m2.Keys())
+	assert.Equal(t, []string{"a", "b", "c"}, evicted, "the callback is not cloned")
+
+	m.SetSortFunc(func(k1, k2 string, v1, v2 int) bool {
+		return k1 < k2
+	})
+	m.Set("c", 3)
+	m.Set("a", 1)
+	assert.Equal(t, []string{"c", "d"}, m.Keys(), "the front of a sorted map is evicted")
+
+	m.SetCapacity(0, nil)
+	m.Set("z", 26)
+	m.Set("y", 25)
+	assert.Equal(t, 4, m.Len())
+
+	b, err := m.MarshalBinary()
+	assert.NoError(t, err)
+	m3 := new(SliceMap[string, int])
+	m3.SetCapacity(1, nil)
+	assert.NoError(t, m3.UnmarshalBinary(b))
+	assert.Equal(t, 1, m3.Len())
+
+	var m4 *SliceMap[string, int]
+	assert.Equal(t, 0, m4.Cap())
+	assert.Panics(t, func() {
+		m4.SetCapacity(1, nil)
+	})
+}
+
+func TestSliceMap_DeleteMany(t *testing.T) {
+	m := NewSliceMap[string, int]()
+	for i, k := range []string{"a", "b", "c", "d", "e"} {
+		m.Set(k, i)
+	}
+	assert.Equal(t, 3, m.DeleteMany("d", "a", "z", "b"))
+	assert.Equal(t, []string{"c", "e"}, m.Keys())
+	assert.Equal(t, []int{2, 4}, m.Values())
+
+	m.SetSortFunc(func(k1, k2 string, _, _ int) bool { return k1 > k2 })
+	m.Set("f", 5)
+	assert.Equal(t, 2, m.DeleteMany("e", "f"))
+	assert.Equal(t, []string{"c"}, m.Keys())
+	assert.Equal(t, 0, (*SliceMap[string, int])(nil).DeleteMany("a"))
+}
+
+func TestSliceMap_EqualOrdered(t *testing.T) {
+	m1 := NewSliceMap[string, int]()
+	m1.Set("a", 1)
+	m1.Set("b", 2)
+	m2 := NewSliceMap[string, int]()
+	m2.Set("b", 2)
+	m2.Set("a", 1)
+
+	assert.True(t, m1.Equal(m2))
+	assert.False(t, m1.EqualOrdered(m2))
+	m2.Delete("b")
+	m2.Set("b", 2)
+	assert.True(t, m1.EqualOrdered(m2))
+	m2.Set("b", 3)
+	assert.False(t, m1.EqualOrdered(m2))
+	m2.Set("c", 3)
+	assert.False(t, m1.EqualOrdered(m2))
+
+	var nilMap *SliceMap[string, int]
+	assert.True(t, nilMap.EqualOrdered(NewSliceMap[string, int]()))
+	assert.False(t, nilMap.EqualOrdered(m1))
+}
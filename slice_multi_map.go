@@ -0,0 +1,378 @@
+package maps
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// SliceMultiMap is a MultiMap that remembers the order of its keys, like a SliceMap.
+// By default, the keys are ranged in the order they were first added. Call SetSortFunc to keep the keys sorted.
+// The values of each key are always kept in the order they were added.
+//
+// See MultiMap for how the MapI methods work with more than one value per key.
+//
+// The zero value is ready to use. Do not make a copy of a SliceMultiMap using the equality operator (=). Use Clone instead.
+type SliceMultiMap[K comparable, V any] struct {
+	items SliceMap[K, []V]
+}
+
+// NewSliceMultiMap creates a new SliceMultiMap.
+// Pass in zero or more standard maps and the values of those maps will be added to the new SliceMultiMap.
+func NewSliceMultiMap[K comparable, V any](sources ...map[K]V) *SliceMultiMap[K, V] {
+	m := new(SliceMultiMap[K, V])
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// SetSortFunc sets the sort function which will determine the order of the keys in the map
+// on an ongoing basis. Normally, keys will iterate in the order they were first added.
+// To turn off sorting, set the sort function to nil.
+//
+// The sort function is a Less function, that returns true when key1 is "less" than key2.
+func (m *SliceMultiMap[K, V]) SetSortFunc(f func(key1, key2 K) bool) {
+	if f == nil {
+		m.items.SetSortFunc(nil)
+		return
+	}
+	m.items.SetSortFunc(func(key1, key2 K, _, _ []V) bool {
+		return f(key1, key2)
+	})
+}
+
+// Set adds v to the values of the key k. A new key is added to the end of the map, or at its sorted position.
+func (m *SliceMultiMap[K, V]) Set(k K, v V) {
+	if values, ok := m.items.items[k]; ok {
+		// The sort function only uses keys, so the position of the key does not change.
+		m.items.items[k] = append(values, v)
+		return
+	}
+	m.items.Set(k, []V{v})
+}
+
+// SetAll replaces all the values of the key k with values.
+// If values is empty, the key is removed.
+func (m *SliceMultiMap[K, V]) SetAll(k K, values ...V) {
+	if len(values) == 0 {
+		m.Delete(k)
+		return
+	}
+	if m.items.Has(k) {
+		m.items.items[k] = slices.Clone(values)
+		return
+	}
+	m.items.Set(k, slices.Clone(values))
+}
+
+// Get returns the first value of the key. If the key does not exist, the zero value will be returned.
+func (m *SliceMultiMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Load returns the first value of the key, and a boolean indicating whether the key exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *SliceMultiMap[K, V]) Load(k K) (v V, ok bool) {
+	if m == nil {
+		return
+	}
+	var values []V
+	if values, ok = m.items.Load(k); ok {
+		v = values[0]
+	}
+	return
+}
+
+// GetAll returns a new slice with all the values of the key, in the order they were added.
+// If the key does not exist, nil is returned.
+func (m *SliceMultiMap[K, V]) GetAll(k K) []V {
+	if m == nil {
+		return nil
+	}
+	return slices.Clone(m.items.Get(k))
+}
+
+// CountOf returns the number of values of the key.
+func (m *SliceMultiMap[K, V]) CountOf(k K) int {
+	if m == nil {
+		return 0
+	}
+	return len(m.items.Get(k))
+}
+
+// GetKeyAt returns the key at the given position. If the position is out of range, the zero value is returned.
+func (m *SliceMultiMap[K, V]) GetKeyAt(position int) (key K) {
+	if m == nil {
+		return
+	}
+	return m.items.GetKeyAt(position)
+}
+
+// Has returns true if the key exists.
+func (m *SliceMultiMap[K, V]) Has(k K) bool {
+	if m == nil {
+		return false
+	}
+	return m.items.Has(k)
+}
+
+// Delete removes the key and all its values from the map, and returns the first value.
+// If the key does not exist, the zero value will be returned.
+func (m *SliceMultiMap[K, V]) Delete(k K) (v V) {
+	if m == nil {
+		return
+	}
+	if values := m.items.Delete(k); len(values) > 0 {
+		v = values[0]
+	}
+	return
+}
+
+// DeleteValue removes every value of the key k that is equal to v, and returns true if any were removed.
+// If no values are left, the key is removed.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *SliceMultiMap[K, V]) DeleteValue(k K, v V) bool {
+	if m == nil {
+		return false
+	}
+	values, ok := m.items.Load(k)
+	if !ok {
+		return false
+	}
+	values2 := slices.DeleteFunc(values, func(v2 V) bool {
+		return equalValues(v, v2)
+	})
+	if len(values2) == len(values) {
+		return false
+	}
+	if len(values2) == 0 {
+		m.items.Delete(k)
+	} else {
+		m.items.items[k] = values2
+	}
+	return true
+}
+
+// Clear removes all the items in the map.
+func (m *SliceMultiMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.items.Clear()
+}
+
+// Len returns the number of keys in the map.
+func (m *SliceMultiMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.items.Len()
+}
+
+// Range calls the given function for each value of each key in the map, in key order.
+// The values of each key are visited in the order they were added.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+func (m *SliceMultiMap[K, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	for k, values := range m.items.All() {
+		for _, v := range values {
+			if !f(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a new slice containing the keys of the map, in order. Each key is listed once.
+func (m *SliceMultiMap[K, V]) Keys() []K {
+	if m == nil {
+		return nil
+	}
+	return m.items.Keys()
+}
+
+// Values returns a new slice containing all the values of all the keys, in order.
+func (m *SliceMultiMap[K, V]) Values() (values []V) {
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Merge adds the items from in to the map.
+// Deprecated: Call Copy instead.
+func (m *SliceMultiMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy adds every key and value of in to this map. Values are added to the values of existing keys.
+func (m *SliceMultiMap[K, V]) Copy(in MapI[K, V]) {
+	if in == nil {
+		return
+	}
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if the maps have the same keys, and each key has the same values in the same order.
+// The order of the keys is not compared. See MultiMap.Equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *SliceMultiMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m == nil {
+		return equalMulti(nil, m2)
+	}
+	return equalMulti(m.items.items, m2)
+}
+
+// String outputs the map as a string, in order, with the values of each key in a list.
+func (m *SliceMultiMap[K, V]) String() string {
+	var s string
+
+	if m == nil {
+		return s
+	}
+
+	s = "{"
+	m.items.Range(func(k K, values []V) bool {
+		s += fmt.Sprintf(`%#v:%#v,`, k, values)
+		return true
+	})
+	s = strings.TrimRight(s, ",")
+	s += "}"
+	return s
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+// If you are using a sort function, you must save and restore the sort function in a separate operation
+// since functions are not serializable.
+func (m *SliceMultiMap[K, V]) MarshalBinary() (data []byte, err error) {
+	if m == nil {
+		return
+	}
+	return m.items.MarshalBinary()
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// SliceMultiMap. Keys with no values are not added.
+func (m *SliceMultiMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	if err = m.items.UnmarshalBinary(data); err == nil {
+		m.items.DeleteFunc(func(_ K, values []V) bool {
+			return len(values) == 0
+		})
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object,
+// where the value of each key is an array of its values.
+func (m *SliceMultiMap[K, V]) MarshalJSON() (data []byte, err error) {
+	// Json objects are unordered
+	if m == nil {
+		return
+	}
+	return m.items.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a SliceMultiMap.
+// The JSON must start with an object, and the value of each key must be an array of values.
+// Since JSON objects are unordered, the order of the keys is not determinate. Keys with empty arrays are not added.
+func (m *SliceMultiMap[K, V]) UnmarshalJSON(data []byte) (err error) {
+	if err = m.items.UnmarshalJSON(data); err == nil {
+		m.items.DeleteFunc(func(_ K, values []V) bool {
+			return len(values) == 0
+		})
+	}
+	return
+}
+
+// All returns an iterator over every value of every key in the map, in order.
+func (m *SliceMultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over the keys in the map, in order. Each key is visited once.
+func (m *SliceMultiMap[K, V]) KeysIter() iter.Seq[K] {
+	if m == nil {
+		return func(yield func(K) bool) {}
+	}
+	return m.items.KeysIter()
+}
+
+// ValuesIter returns an iterator over all the values of all the keys in the map, in order.
+func (m *SliceMultiMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Values are added to the values of existing keys.
+func (m *SliceMultiMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// CollectSliceMultiMap collects key-value pairs from seq into a new SliceMultiMap
+// and returns it. Repeated keys keep all their values.
+func CollectSliceMultiMap[K comparable, V any](seq iter.Seq2[K, V]) *SliceMultiMap[K, V] {
+	m := new(SliceMultiMap[K, V])
+	m.Insert(seq)
+	return m
+}
+
+// GroupBy returns a new SliceMultiMap with the values of seq grouped by the key that keyFn returns for each value.
+// Keys are in the order they were first seen, and the values of each key are in the order of seq.
+func GroupBy[T any, K comparable](seq iter.Seq[T], keyFn func(T) K) *SliceMultiMap[K, T] {
+	m := new(SliceMultiMap[K, T])
+	for v := range seq {
+		m.Set(keyFn(v), v)
+	}
+	return m
+}
+
+// Clone returns a copy of the SliceMultiMap, including its sort function. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *SliceMultiMap[K, V]) Clone() *SliceMultiMap[K, V] {
+	m1 := new(SliceMultiMap[K, V])
+	if m == nil {
+		return m1
+	}
+	m1.items = *m.items.Clone()
+	for k, values := range m1.items.items {
+		m1.items.items[k] = slices.Clone(values)
+	}
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+// Only the matching values are removed. A key is removed when it has no values left.
+func (m *SliceMultiMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	if m == nil {
+		return
+	}
+	for k, values := range m.items.items {
+		m.items.items[k] = slices.DeleteFunc(values, func(v V) bool {
+			return del(k, v)
+		})
+	}
+	m.items.DeleteFunc(func(_ K, values []V) bool {
+		return len(values) == 0
+	})
+}
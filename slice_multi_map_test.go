@@ -0,0 +1,167 @@
+package maps
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gob.Register(new(SliceMultiMap[string, int]))
+}
+
+func ExampleSliceMultiMap() {
+	m := new(SliceMultiMap[string, string])
+	m.Set("Host", "example.com")
+	m.Set("Accept", "text/html")
+	m.Set("Accept", "application/json")
+	for k, v := range m.All() {
+		fmt.Printf("%s: %s\n", k, v)
+	}
+	// Output: Host: example.com
+	// Accept: text/html
+	// Accept: application/json
+}
+
+func TestSliceMultiMap(t *testing.T) {
+	var m SliceMultiMap[string, int]
+	assert.Equal(t, 0, m.Get("a"))
+
+	m.Set("b", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+	m.Set("c", 4)
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, []string{"b", "a", "c"}, m.Keys())
+	assert.Equal(t, []int{1, 3, 2, 4}, m.Values())
+	assert.Equal(t, []int{1, 3}, m.GetAll("b"))
+	assert.Equal(t, 2, m.CountOf("b"))
+	assert.Equal(t, 1, m.Get("b"))
+	assert.Equal(t, "a", m.GetKeyAt(1))
+	assert.Equal(t, `{"b":[]int{1, 3},"a":[]int{2},"c":[]int{4}}`, m.String())
+	assert.Equal(t, []string{"b", "a", "c"}, slices.Collect(m.KeysIter()))
+	assert.Equal(t, []int{1, 3, 2, 4}, slices.Collect(m.ValuesIter()))
+
+	m.SetSortFunc(func(k1, k2 string) bool {
+		return k1 < k2
+	})
+	assert.Equal(t, []string{"a", "b", "c"}, m.Keys())
+	m.Set("b", 5)
+	m.Set("aa", 6)
+	assert.Equal(t, []string{"a", "aa", "b", "c"}, m.Keys())
+	assert.Equal(t, []int{2, 6, 1, 3, 5, 4}, m.Values())
+	m.SetAll("aa", 7, 8)
+	assert.Equal(t, []int{7, 8}, m.GetAll("aa"))
+	m.SetAll("0", 9)
+	assert.Equal(t, "0", m.GetKeyAt(0))
+	m.SetAll("0")
+	assert.False(t, m.Has("0"))
+	m.SetSortFunc(nil)
+
+	assert.True(t, m.DeleteValue("b", 3))
+	assert.Equal(t, []int{1, 5}, m.GetAll("b"))
+	assert.False(t, m.DeleteValue("b", 3))
+	assert.True(t, m.DeleteValue("c", 4))
+	assert.False(t, m.Has("c"))
+
+	m.DeleteFunc(func(k string, v int) bool {
+		return v == 1 || k == "aa"
+	})
+	assert.Equal(t, []string{"a", "b"}, m.Keys())
+	assert.Equal(t, []int{2, 5}, m.Values())
+
+	assert.Equal(t, 5, m.Delete("b"))
+	assert.Equal(t, 0, m.Delete("b"))
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestSliceMultiMap_Equal(t *testing.T) {
+	m := NewSliceMultiMap(map[string]int{"a": 1, "b": 2})
+	assert.True(t, m.Equal(mapT{"a": 1, "b": 2}))
+	m.Set("a", 3)
+	assert.False(t, m.Equal(mapT{"a": 1, "b": 2}))
+
+	m2 := new(MultiMap[string, int])
+	m2.Set("b", 2)
+	m2.Set("a", 1)
+	m2.Set("a", 3)
+	assert.True(t, m.Equal(m2))
+	assert.True(t, m2.Equal(m))
+
+	m3 := m.Clone()
+	assert.True(t, m.Equal(m3))
+	m3.Set("a", 4)
+	assert.False(t, m.Equal(m3))
+	assert.Equal(t, 2, m.CountOf("a"))
+
+	m4 := CollectSliceMultiMap(m2.All())
+	assert.True(t, m.Equal(m4))
+}
+
+func TestSliceMultiMap_Marshal(t *testing.T) {
+	m := new(SliceMultiMap[string, int])
+	m.Set("b", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+
+	data, err := m.MarshalBinary()
+	assert.NoError(t, err)
+	var m2 SliceMultiMap[string, int]
+	assert.NoError(t, m2.UnmarshalBinary(data))
+	assert.Equal(t, []string{"b", "a"}, m2.Keys())
+	assert.Equal(t, []int{1, 3, 2}, m2.Values())
+	assert.NoError(t, m2.UnmarshalBinary(gobEncode(t, map[string][]int{"a": {1}, "c": {}}, []string{"c", "a"})))
+	assert.False(t, m2.Has("c"))
+	assert.Equal(t, 0, m2.Get("c"))
+	assert.Equal(t, []string{"a"}, m2.Keys())
+
+	s, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":[2],"b":[1,3]}`, string(s))
+	var m3 SliceMultiMap[string, int]
+	assert.NoError(t, json.Unmarshal([]byte(`{"a":[2],"b":[1,3],"c":[]}`), &m3))
+	assert.True(t, m.Equal(&m3))
+}
+
+func TestSliceMultiMap_Nil(t *testing.T) {
+	var m *SliceMultiMap[string, int]
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.Get("a"))
+	assert.Nil(t, m.GetAll("a"))
+	assert.False(t, m.Has("a"))
+	assert.Nil(t, m.Keys())
+	assert.Nil(t, m.Values())
+	assert.Equal(t, 0, m.Delete("a"))
+	assert.True(t, m.Equal(nil))
+	assert.Equal(t, "", m.String())
+	assert.Equal(t, 0, m.Clone().Len())
+	for range m.KeysIter() {
+		t.Fail()
+	}
+}
+
+func ExampleGroupBy() {
+	words := []string{"apple", "bean", "avocado", "carrot", "beet"}
+	m := GroupBy(slices.Values(words), func(w string) byte { return w[0] })
+	for _, k := range m.Keys() {
+		fmt.Println(string(k), m.GetAll(k))
+	}
+	// Output: a [apple avocado]
+	// b [bean beet]
+	// c [carrot]
+}
+
+func TestGroupBy(t *testing.T) {
+	m := GroupBy(slices.Values([]int{}), func(i int) bool { return i%2 == 0 })
+	assert.Equal(t, 0, m.Len())
+
+	m = GroupBy(slices.Values([]int{3, 2, 5, 4}), func(i int) bool { return i%2 == 0 })
+	assert.Equal(t, []bool{false, true}, m.Keys())
+	assert.Equal(t, []int{3, 5}, m.GetAll(false))
+	assert.Equal(t, []int{2, 4}, m.GetAll(true))
+}
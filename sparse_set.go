@@ -0,0 +1,543 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+const (
+	sparsePageBits  = 12
+	sparsePageSize  = 1 << sparsePageBits // the number of values in a page
+	sparsePageWords = sparsePageSize / 64
+)
+
+// sparsePage is a bitmap of sparsePageSize values.
+type sparsePage struct {
+	words [sparsePageWords]uint64
+	count int
+}
+
+// SparseSet is a set of integers for values spread over a huge range, like IDs that are
+// 64-bit hashes or timestamps. The values are split into pages of 4096 values, and only the pages
+// that have values use memory. Each page is a bitmap, like a BitSet.
+//
+// Unlike a BitSet, a SparseSet can hold negative values.
+// Values are ranged in increasing order.
+//
+// The zero value is ready to use. Do not make a copy of a SparseSet using the equality operator (=). Use Clone instead.
+type SparseSet struct {
+	pages map[int]*sparsePage
+	order []int // the page numbers, sorted
+	count int
+}
+
+// NewSparseSet creates a new SparseSet with the given values.
+func NewSparseSet(values ...int) *SparseSet {
+	m := new(SparseSet)
+	m.Add(values...)
+	return m
+}
+
+// sparseLocation returns the page number of k, and the word and bit of k in the page.
+func sparseLocation(k int) (page, word, bit int) {
+	page = k >> sparsePageBits
+	offset := k & (sparsePageSize - 1)
+	return page, offset / 64, offset % 64
+}
+
+// page returns the page with the given number, creating it if create is true.
+func (m *SparseSet) page(n int, create bool) *sparsePage {
+	if p, ok := m.pages[n]; ok || !create {
+		return p
+	}
+	if m.pages == nil {
+		m.pages = make(map[int]*sparsePage)
+	}
+	p := new(sparsePage)
+	m.pages[n] = p
+	i, _ := slices.BinarySearch(m.order, n)
+	m.order = slices.Insert(m.order, i, n)
+	return p
+}
+
+// deletePage removes the page with the given number.
+func (m *SparseSet) deletePage(n int) {
+	delete(m.pages, n)
+	if i, ok := slices.BinarySearch(m.order, n); ok {
+		m.order = slices.Delete(m.order, i, i+1)
+	}
+}
+
+// Clear removes all the values in the set.
+func (m *SparseSet) Clear() {
+	if m == nil {
+		return
+	}
+	m.pages = nil
+	m.order = nil
+	m.count = 0
+}
+
+// Len returns the number of values in the set.
+func (m *SparseSet) Len() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+// Range calls the given function for each value in the set, in increasing order.
+// The function should return true to continue ranging, or false to stop.
+func (m *SparseSet) Range(f func(k int) bool) {
+	if m == nil {
+		return
+	}
+	for _, n := range slices.Clone(m.order) {
+		p := m.pages[n]
+		if p == nil {
+			continue // deleted while ranging
+		}
+		for i := range p.words {
+			for w := p.words[i]; w != 0; w &= w - 1 {
+				if !f(n<<sparsePageBits + i*64 + bits.TrailingZeros64(w)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// NextSet returns the smallest value in the set that is greater than or equal to k,
+// and false if there is no such value. See BitSet.NextSet.
+func (m *SparseSet) NextSet(k int) (int, bool) {
+	if m.Len() == 0 {
+		return 0, false
+	}
+	page, word, bit := sparseLocation(k)
+	i, _ := slices.BinarySearch(m.order, page)
+	for ; i < len(m.order); i++ {
+		n := m.order[i]
+		p := m.pages[n]
+		if n > page {
+			word, bit = 0, 0
+		}
+		for ; word < sparsePageWords; word, bit = word+1, 0 {
+			if w := p.words[word] >> bit; w != 0 {
+				return n<<sparsePageBits + word*64 + bit + bits.TrailingZeros64(w), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// First returns the smallest value in the set, and false if the set is empty.
+func (m *SparseSet) First() (int, bool) {
+	if m.Len() == 0 {
+		return 0, false
+	}
+	return m.NextSet(m.order[0] << sparsePageBits)
+}
+
+// Last returns the largest value in the set, and false if the set is empty.
+func (m *SparseSet) Last() (int, bool) {
+	if m.Len() == 0 {
+		return 0, false
+	}
+	n := m.order[len(m.order)-1]
+	p := m.pages[n]
+	for i := sparsePageWords - 1; i >= 0; i-- {
+		if w := p.words[i]; w != 0 {
+			return n<<sparsePageBits + i*64 + 63 - bits.LeadingZeros64(w), true
+		}
+	}
+	return 0, false // not reached, since empty pages are deleted
+}
+
+// Has returns true if the value exists in the set.
+func (m *SparseSet) Has(k int) bool {
+	if m == nil {
+		return false
+	}
+	page, word, bit := sparseLocation(k)
+	p := m.pages[page]
+	return p != nil && p.words[word]&(1<<bit) != 0
+}
+
+// Delete removes the value from the set. If the value does not exist, nothing happens.
+func (m *SparseSet) Delete(k int) {
+	if m == nil {
+		return
+	}
+	page, word, bit := sparseLocation(k)
+	p := m.pages[page]
+	if p == nil || p.words[word]&(1<<bit) == 0 {
+		return
+	}
+	p.words[word] &^= 1 << bit
+	p.count--
+	m.count--
+	if p.count == 0 {
+		m.deletePage(page)
+	}
+}
+
+// Values returns a new slice containing the values of the set, in increasing order.
+func (m *SparseSet) Values() (values []int) {
+	if m.Len() == 0 {
+		return
+	}
+	values = make([]int, 0, m.count)
+	m.Range(func(k int) bool {
+		values = append(values, k)
+		return true
+	})
+	return
+}
+
+// Add adds the values to the set.
+// If a value already exists, nothing changes.
+func (m *SparseSet) Add(k ...int) SetI[int] {
+	for _, v := range k {
+		page, word, bit := sparseLocation(v)
+		p := m.page(page, true)
+		if p.words[word]&(1<<bit) == 0 {
+			p.words[word] |= 1 << bit
+			p.count++
+			m.count++
+		}
+	}
+	return m
+}
+
+// AddNew adds the value to the set, and returns true if it was not already in the set.
+func (m *SparseSet) AddNew(k int) bool {
+	count := m.count
+	m.Add(k)
+	return m.count > count
+}
+
+// Merge adds the values from the given set to the set.
+// Deprecated: Call Copy instead.
+func (m *SparseSet) Merge(in SetI[int]) {
+	m.Copy(in)
+}
+
+// Copy adds the values from in to the set.
+func (m *SparseSet) Copy(in SetI[int]) {
+	if in == nil {
+		return
+	}
+	if s, ok := in.(*SparseSet); ok {
+		m.Or(s)
+		return
+	}
+	in.Range(func(k int) bool {
+		m.Add(k)
+		return true
+	})
+}
+
+// combine sets each page of m that op changes to op(page of m, page of s), where a missing page has no values.
+// If all is false, only the pages of s are combined.
+func (m *SparseSet) combine(s *SparseSet, all bool, op func(w1, w2 uint64) uint64) {
+	var pages []int
+	if s != nil {
+		pages = slices.Clone(s.order)
+	}
+	if all {
+		pages = slices.Clone(m.order)
+		if s != nil {
+			for _, n := range s.order {
+				if _, ok := m.pages[n]; !ok {
+					pages = append(pages, n)
+				}
+			}
+		}
+	}
+	var empty sparsePage
+	for _, n := range pages {
+		p2 := &empty
+		if s != nil && s.pages[n] != nil {
+			p2 = s.pages[n]
+		}
+		p := m.page(n, true)
+		m.count -= p.count
+		p.count = 0
+		for i := range p.words {
+			p.words[i] = op(p.words[i], p2.words[i])
+			p.count += bits.OnesCount64(p.words[i])
+		}
+		m.count += p.count
+		if p.count == 0 {
+			m.deletePage(n)
+		}
+	}
+}
+
+// Or adds the values of s to the set, so the set becomes the union of the two sets.
+func (m *SparseSet) Or(s *SparseSet) {
+	m.combine(s, false, func(w1, w2 uint64) uint64 { return w1 | w2 })
+}
+
+// And removes the values that are not in s, so the set becomes the intersection of the two sets.
+func (m *SparseSet) And(s *SparseSet) {
+	m.combine(s, true, func(w1, w2 uint64) uint64 { return w1 & w2 })
+}
+
+// AndNot removes the values that are in s, so the set becomes the difference of the two sets.
+func (m *SparseSet) AndNot(s *SparseSet) {
+	m.combine(s, false, func(w1, w2 uint64) uint64 { return w1 &^ w2 })
+}
+
+// Xor changes the set to have the values that are in either set, but not in both.
+func (m *SparseSet) Xor(s *SparseSet) {
+	m.combine(s, false, func(w1, w2 uint64) uint64 { return w1 ^ w2 })
+}
+
+// Union returns a new SparseSet with the values that are in either the set or s.
+func (m *SparseSet) Union(s SetReader[int]) *SparseSet {
+	r := m.Clone()
+	if s2, ok := s.(*SparseSet); ok {
+		r.Or(s2)
+	} else {
+		unionInto[int](r, nil, s)
+	}
+	return r
+}
+
+// Intersect returns a new SparseSet with the values that are in both the set and s.
+func (m *SparseSet) Intersect(s SetReader[int]) *SparseSet {
+	if s2, ok := s.(*SparseSet); ok {
+		r := m.Clone()
+		r.And(s2)
+		return r
+	}
+	r := new(SparseSet)
+	intersectInto[int](r, m, s)
+	return r
+}
+
+// Difference returns a new SparseSet with the values that are in the set, but not in s.
+func (m *SparseSet) Difference(s SetReader[int]) *SparseSet {
+	if s2, ok := s.(*SparseSet); ok {
+		r := m.Clone()
+		r.AndNot(s2)
+		return r
+	}
+	r := new(SparseSet)
+	differenceInto[int](r, m, s)
+	return r
+}
+
+// SymmetricDifference returns a new SparseSet with the values that are in either the set or s, but not in both.
+func (m *SparseSet) SymmetricDifference(s SetReader[int]) *SparseSet {
+	if s2, ok := s.(*SparseSet); ok {
+		r := m.Clone()
+		r.Xor(s2)
+		return r
+	}
+	r := new(SparseSet)
+	symmetricDifferenceInto[int](r, m, s)
+	return r
+}
+
+// IsSubsetOf returns true if every value of the set is also in s.
+func (m *SparseSet) IsSubsetOf(s SetReader[int]) bool {
+	if s2, ok := s.(*SparseSet); ok {
+		if m.Len() == 0 {
+			return true
+		}
+		if m.Len() > s2.Len() {
+			return false
+		}
+		for n, p := range m.pages {
+			p2 := s2.pages[n]
+			if p2 == nil || p.count > p2.count {
+				return false
+			}
+			for i, w := range p.words {
+				if w&^p2.words[i] != 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return IsSubset[int](m, s)
+}
+
+// IsSupersetOf returns true if every value of s is also in the set.
+func (m *SparseSet) IsSupersetOf(s SetReader[int]) bool {
+	if s2, ok := s.(*SparseSet); ok {
+		return s2.IsSubsetOf(m)
+	}
+	return IsSubset[int](s, m)
+}
+
+// IsDisjoint returns true if the set and s have no values in common.
+func (m *SparseSet) IsDisjoint(s SetReader[int]) bool {
+	if s2, ok := s.(*SparseSet); ok {
+		if m.Len() == 0 || s2.Len() == 0 {
+			return true
+		}
+		for n, p := range m.pages {
+			if p2 := s2.pages[n]; p2 != nil {
+				for i, w := range p.words {
+					if w&p2.words[i] != 0 {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+	return IsDisjoint[int](m, s)
+}
+
+// Equal returns true if the two sets are the same length and contain the same values.
+func (m *SparseSet) Equal(m2 SetI[int]) bool {
+	if m2 == nil {
+		return m.Len() == 0
+	}
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k int) bool {
+		if !m.Has(k) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the set to a byte stream.
+func (m *SparseSet) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(m.Values())
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a SparseSet.
+// The values are added to the set.
+func (m *SparseSet) UnmarshalBinary(data []byte) (err error) {
+	var values []int
+
+	b := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(b)
+	if err = dec.Decode(&values); err == nil {
+		m.Add(values...)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the set into a JSON array of its values.
+func (m *SparseSet) MarshalJSON() (out []byte, err error) {
+	values := m.Values()
+	if values == nil {
+		values = []int{}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a JSON array of numbers to a SparseSet.
+// The values are added to the set.
+func (m *SparseSet) UnmarshalJSON(in []byte) (err error) {
+	var values []int
+	if err = json.Unmarshal(in, &values); err == nil {
+		m.Add(values...)
+	}
+	return
+}
+
+// String returns the set as a string, in increasing order.
+func (m *SparseSet) String() string {
+	var s strings.Builder
+	s.WriteString("{")
+	m.Range(func(k int) bool {
+		if s.Len() > 1 {
+			s.WriteString(",")
+		}
+		fmt.Fprint(&s, k)
+		return true
+	})
+	s.WriteString("}")
+	return s.String()
+}
+
+// All returns an iterator over all the values in the set, in increasing order.
+func (m *SparseSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		m.Range(yield)
+	}
+}
+
+// Insert adds the values from seq to the set.
+func (m *SparseSet) Insert(seq iter.Seq[int]) {
+	for k := range seq {
+		m.Add(k)
+	}
+}
+
+// CollectSparseSet collects values from seq into a new SparseSet
+// and returns it.
+func CollectSparseSet(seq iter.Seq[int]) *SparseSet {
+	m := new(SparseSet)
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the SparseSet.
+func (m *SparseSet) Clone() *SparseSet {
+	m1 := new(SparseSet)
+	if m == nil || m.count == 0 {
+		return m1
+	}
+	m1.pages = make(map[int]*sparsePage, len(m.pages))
+	for n, p := range m.pages {
+		p1 := *p
+		m1.pages[n] = &p1
+	}
+	m1.order = slices.Clone(m.order)
+	m1.count = m.count
+	return m1
+}
+
+// DeleteFunc deletes any values for which del returns true.
+func (m *SparseSet) DeleteFunc(del func(int) bool) {
+	m.Range(func(k int) bool {
+		if del(k) {
+			m.Delete(k)
+		}
+		return true
+	})
+}
+
+// Filter returns a new SparseSet with the values of the set for which keep returns true.
+// The set is not changed.
+func (m *SparseSet) Filter(keep func(int) bool) *SparseSet {
+	m1 := new(SparseSet)
+	m.Range(func(k int) bool {
+		if keep(k) {
+			m1.Add(k)
+		}
+		return true
+	})
+	return m1
+}
+
+// Hash returns a hash of the values of the set, made with seed. Sets that are Equal have the same hash.
+func (m *SparseSet) Hash(seed maphash.Seed) uint64 {
+	return hashOrderedValues(seed, m.Range)
+}
@@ -0,0 +1,165 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSparseSet() {
+	s := NewSparseSet(1<<40, -5, 3)
+	fmt.Println(s, s.Len())
+	// Output: {-5,3,1099511627776} 3
+}
+
+func TestSparseSet(t *testing.T) {
+	var s SparseSet
+	s.Add(0, 4095, 4096, -1, 1<<50)
+	assert.Equal(t, 5, s.Len())
+	assert.True(t, s.Has(4095))
+	assert.True(t, s.Has(-1))
+	assert.False(t, s.Has(1))
+	assert.Equal(t, []int{-1, 0, 4095, 4096, 1 << 50}, s.Values())
+	assert.Equal(t, []int{-1, 0, 1, 1 << (50 - 12)}, s.order)
+
+	s.Delete(4096)
+	s.Delete(4096)
+	assert.Equal(t, 4, s.Len())
+	assert.Len(t, s.pages, 3, "empty pages are removed")
+
+	k, ok := s.NextSet(1)
+	assert.Equal(t, 4095, k)
+	assert.True(t, ok)
+	k, _ = s.NextSet(4096)
+	assert.Equal(t, 1<<50, k)
+	k, _ = s.NextSet(-100)
+	assert.Equal(t, -1, k)
+	_, ok = s.NextSet(1<<50 + 1)
+	assert.False(t, ok)
+
+	s2 := s.Clone()
+	s2.DeleteFunc(func(k int) bool {
+		return k > 0
+	})
+	assert.Equal(t, []int{-1, 0}, s2.Values())
+	assert.Equal(t, 4, s.Len())
+	assert.True(t, s2.Equal(NewSet(0, -1)))
+	assert.False(t, s2.Equal(NewSet(0, 1)))
+
+	s2.Copy(NewSet(7))
+	s2.Merge(NewSparseSet(9))
+	assert.Equal(t, []int{-1, 0, 7, 9}, CollectSparseSet(s2.All()).Values())
+}
+
+func TestSparseSet_Operations(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	randomSet := func() (*SparseSet, *Set[int]) {
+		s1, s2 := new(SparseSet), new(Set[int])
+		for range 500 {
+			k := r.IntN(100000) - 50000
+			s1.Add(k)
+			s2.Add(k)
+		}
+		return s1, s2
+	}
+	a, aSet := randomSet()
+	b, bSet := randomSet()
+
+	or := a.Clone()
+	or.Or(b)
+	expected := aSet.Clone()
+	expected.Copy(bSet)
+	assert.True(t, or.Equal(expected))
+
+	and := a.Clone()
+	and.And(b)
+	expected = aSet.Clone()
+	expected.DeleteFunc(func(k int) bool { return !bSet.Has(k) })
+	assert.True(t, and.Equal(expected))
+
+	andNot := a.Clone()
+	andNot.AndNot(b)
+	expected = aSet.Clone()
+	expected.DeleteFunc(bSet.Has)
+	assert.True(t, andNot.Equal(expected))
+
+	xor := a.Clone()
+	xor.Xor(b)
+	assert.Equal(t, or.Len()-and.Len(), xor.Len())
+	for k := range xor.All() {
+		assert.True(t, aSet.Has(k) != bSet.Has(k))
+	}
+
+	assert.True(t, slices.IsSorted(xor.Values()))
+	xor.Xor(xor)
+	assert.Equal(t, 0, xor.Len())
+	assert.Empty(t, xor.pages)
+
+	a.And(nil)
+	assert.Equal(t, 0, a.Len())
+}
+
+func TestSparseSet_Marshal(t *testing.T) {
+	s := NewSparseSet(-1, 2, 1<<40)
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "[-1,2,1099511627776]", string(b))
+	var s2 SparseSet
+	assert.NoError(t, json.Unmarshal(b, &s2))
+	assert.True(t, s.Equal(&s2))
+
+	b, err = s.MarshalBinary()
+	assert.NoError(t, err)
+	var s3 SparseSet
+	assert.NoError(t, s3.UnmarshalBinary(b))
+	assert.True(t, s.Equal(&s3))
+}
+
+func TestSparseSet_Nil(t *testing.T) {
+	var s *SparseSet
+	assert.Equal(t, 0, s.Len())
+	assert.False(t, s.Has(1))
+	assert.Nil(t, s.Values())
+	assert.True(t, s.Equal(nil))
+	assert.Equal(t, "{}", s.String())
+	assert.Equal(t, 0, s.Clone().Len())
+	_, ok := s.NextSet(0)
+	assert.False(t, ok)
+}
+
+func TestSparseSet_Filter(t *testing.T) {
+	m := NewSparseSet(-5, 1, 2, 1<<40)
+	m2 := m.Filter(func(k int) bool {
+		return k%2 == 0
+	})
+	assert.Equal(t, []int{2, 1 << 40}, m2.Values())
+	assert.Equal(t, 4, m.Len())
+}
+
+func TestSparseSet_AddNew(t *testing.T) {
+	m := new(SparseSet)
+	assert.True(t, m.AddNew(-70))
+	assert.False(t, m.AddNew(-70))
+	assert.True(t, m.AddNew(1<<50))
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestSparseSet_FirstLast(t *testing.T) {
+	m := NewSparseSet(300, -5000, 1<<40, 64)
+	k, ok := m.First()
+	assert.Equal(t, -5000, k)
+	assert.True(t, ok)
+	k, _ = m.Last()
+	assert.Equal(t, 1<<40, k)
+
+	m = new(SparseSet)
+	_, ok = m.First()
+	assert.False(t, ok)
+	_, ok = m.Last()
+	assert.False(t, ok)
+}
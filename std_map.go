@@ -5,6 +5,7 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"fmt"
+	"hash/maphash"
 	"iter"
 	"maps"
 	"strings"
@@ -35,6 +36,28 @@ func NewStdMap[K comparable, V any](sources ...map[K]V) StdMap[K, V] {
 	return m
 }
 
+// sourcesLen returns the total number of items in sources, which is the most
+// items that a map made from the sources will hold.
+func sourcesLen[K comparable, V any](sources []map[K]V) (size int) {
+	for _, s := range sources {
+		size += len(s)
+	}
+	return
+}
+
+// maxCapacity is the largest capacity that the UnmarshalBinary functions of the maps with a fixed capacity
+// will accept, so that bad data cannot cause a panic or a huge allocation.
+const maxCapacity = 1 << 30
+
+// checkCapacity returns an error if a capacity that was unmarshaled cannot hold n items, or is larger
+// than maxCapacity. A capacity of zero is allowed with no items, since it is the capacity of the zero value.
+func checkCapacity(capacity, n int) error {
+	if capacity < 0 || capacity > maxCapacity || n > capacity {
+		return fmt.Errorf("maps: cannot unmarshal %d items into a map with a capacity of %d", n, capacity)
+	}
+	return nil
+}
+
 // Cast is a convenience method for casting a standard Go map to a StdMap type.
 // Note that this is a cast, so the return value is the equivalent map of what
 // was past in. Use this primarily to make a standard map into a MapI object.
@@ -71,6 +94,16 @@ func (m StdMap[K, V]) Copy(in MapI[K, V]) {
 	})
 }
 
+// CopyFunc copies the keys and values of in into this map.
+// When a key is in both maps, resolve is called with the key, the existing value and the incoming value,
+// and the value it returns is stored. Use it to combine values, like adding counts. If resolve is nil, CopyFunc works like Copy.
+func (m StdMap[K, V]) CopyFunc(in MapI[K, V], resolve func(k K, existing, incoming V) V) {
+	if m == nil {
+		panic("cannot copy into a nil map")
+	}
+	copyFuncWithKeyFunc(nil, m, in, resolve)
+}
+
 // Range calls the given function for each key,value pair in the map.
 // This is the same interface as sync.Map.Range().
 // While its safe to call methods of the map from within the Range function, its discouraged.
@@ -115,6 +148,51 @@ func (m StdMap[K, V]) Set(k K, v V) {
 	m[k] = v
 }
 
+// SetMany sets all the given key and value pairs.
+func (m StdMap[K, V]) SetMany(pairs ...Pair[K, V]) {
+	if m == nil {
+		panic("cannot call SetMany() on a nil map")
+	}
+	for _, p := range pairs {
+		m[p.Key] = p.Value
+	}
+}
+
+// GetMany returns the values for the given keys, in the same order as the keys.
+// The zero value is returned for keys that do not exist.
+func (m StdMap[K, V]) GetMany(keys ...K) (values []V) {
+	if len(keys) == 0 {
+		return
+	}
+	values = make([]V, len(keys))
+	for i, k := range keys {
+		values[i] = m[k]
+	}
+	return
+}
+
+// GetMultiple returns a new StdMap with the items of the given keys. Keys that do not exist are skipped.
+func (m StdMap[K, V]) GetMultiple(keys ...K) StdMap[K, V] {
+	m2 := make(StdMap[K, V], len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			m2[k] = v
+		}
+	}
+	return m2
+}
+
+// DeleteMany removes all the given keys from the map, and returns the number of keys that were in the map.
+func (m StdMap[K, V]) DeleteMany(keys ...K) (deleted int) {
+	for _, k := range keys {
+		if _, ok := m[k]; ok {
+			delete(m, k)
+			deleted++
+		}
+	}
+	return
+}
+
 // Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
 func (m StdMap[K, V]) Delete(k K) (v V) {
 	v, _ = m.Load(k)
@@ -122,6 +200,35 @@ func (m StdMap[K, V]) Delete(k K) (v V) {
 	return
 }
 
+// GetOrSet returns the value of the key if it exists. Otherwise, it sets the key to v and returns v.
+func (m StdMap[K, V]) GetOrSet(k K, v V) V {
+	if v2, ok := m[k]; ok {
+		return v2
+	}
+	m.Set(k, v)
+	return v
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+func (m StdMap[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	if m == nil {
+		panic("cannot call Swap() on a nil map")
+	}
+	previous, loaded = m[k]
+	m[k] = v
+	return
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m StdMap[K, V]) LoadAndDelete(k K) (v V, loaded bool) {
+	if v, loaded = m[k]; loaded {
+		delete(m, k)
+	}
+	return
+}
+
 // Keys returns a new slice containing the keys of the map.
 func (m StdMap[K, V]) Keys() (keys []K) {
 	if m.Len() == 0 {
@@ -248,6 +355,12 @@ func CollectStdMap[K comparable, V any](seq iter.Seq2[K, V]) StdMap[K, V] {
 	return m
 }
 
+// AsReadOnly returns a read-only view of the map, whose methods that would change the map panic with ErrReadOnly.
+// Changes made to the map through other references are seen by the view.
+func (m StdMap[K, V]) AsReadOnly() *ReadOnlyMap[K, V] {
+	return NewReadOnlyMap[K, V](m)
+}
+
 // Clone returns a copy of the StdMap. This is a shallow clone:
 // the new keys and values are set using ordinary assignment.
 func (m StdMap[K, V]) Clone() StdMap[K, V] {
@@ -258,3 +371,38 @@ func (m StdMap[K, V]) Clone() StdMap[K, V] {
 func (m StdMap[K, V]) DeleteFunc(del func(K, V) bool) {
 	maps.DeleteFunc(m, del)
 }
+
+// Filter returns a new StdMap with the items of the map for which keep returns true.
+// The map is not changed.
+func (m StdMap[K, V]) Filter(keep func(K, V) bool) StdMap[K, V] {
+	m2 := make(StdMap[K, V])
+	for k, v := range m {
+		if keep(k, v) {
+			m2[k] = v
+		}
+	}
+	return m2
+}
+
+// Hash returns a hash of the keys and values of the map, made with seed. Maps with the same keys and with values
+// that are equal under == have the same hash. Values are not compared with an Equaler, so maps that are Equal
+// because of one may have different hashes.
+// Use it to detect changes, or to use the contents of the map as a key, without serializing the map.
+// The values are hashed as interfaces, so Hash panics if a value is not comparable.
+func (m StdMap[K, V]) Hash(seed maphash.Seed) uint64 {
+	return hashItems(seed, m.Range)
+}
+
+// CloneDeep returns a copy of the map in which each value that implements Cloner is copied by calling its
+// Clone method. Values that are Map types are copied with CloneDeep, so maps of maps are copied at every level.
+// Other values, including slices, are copied using ordinary assignment.
+func (m StdMap[K, V]) CloneDeep() StdMap[K, V] {
+	if m == nil {
+		return nil
+	}
+	m2 := make(StdMap[K, V], len(m))
+	for k, v := range m {
+		m2[k] = cloneValue(v)
+	}
+	return m2
+}
@@ -239,6 +239,22 @@ func TestEqualValues(t *testing.T) {
 	assert.Panics(t, func() { equalValues(e, f) })
 }
 
+func TestEqualDeep(t *testing.T) {
+	m1 := NewStdMap(map[string][]int{"a": {1, 2}, "b": nil})
+	m2 := NewSafeMap(map[string][]int{"a": {1, 2}, "b": nil})
+	assert.Panics(t, func() { m1.Equal(m2) })
+	assert.True(t, EqualDeep[string, []int](m1, m2))
+	m2.Set("a", []int{1})
+	assert.False(t, EqualDeep[string, []int](m1, m2))
+	m2.Set("c", nil)
+	assert.False(t, EqualDeep[string, []int](m1, m2))
+
+	// Equaler is still used when it is implemented
+	m3 := NewMap(map[string]mySlice{"a": {1}})
+	m4 := NewMap(map[string]mySlice{"a": {1}})
+	assert.True(t, EqualDeep[string, mySlice](m3, m4))
+}
+
 func TestMarshalBinary(t *testing.T) {
 	m := StdMap[string, int]{"a": 1, "b": 2}
 
@@ -0,0 +1,433 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// defaultStripes is the number of stripes used by a StripedSliceMap that was not created with NewStripedSliceMap.
+const defaultStripes = 16
+
+// StripedSliceMap is an ordered map that is safe for concurrent use, like SafeSliceMap,
+// but that spreads its values across a number of separately locked stripes.
+//
+// Getting a value, or setting the value of a key that already exists, only locks the stripe that holds the key,
+// so those operations do not block each other when they are on different stripes.
+// Only operations that change the order of the map, like adding a new key or deleting a key, take the global lock.
+// This makes StripedSliceMap a good choice for ordered caches that are read often and changed occasionally.
+//
+// Unlike SafeSliceMap, a StripedSliceMap always ranges in the order items were inserted and cannot be sorted.
+//
+// The zero value is ready to use. Do not make a copy of a StripedSliceMap using the equality operator. Use Clone() instead.
+type StripedSliceMap[K comparable, V any] struct {
+	mu      sync.RWMutex // guards order and the creation of the stripes
+	once    sync.Once
+	seed    maphash.Seed
+	stripes []stripe[K, V]
+	order   []K
+}
+
+// stripe is one separately locked part of a StripedSliceMap.
+type stripe[K comparable, V any] struct {
+	sync.RWMutex
+	items map[K]V
+}
+
+// NewStripedSliceMap creates a new StripedSliceMap that uses the given number of stripes.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new map.
+func NewStripedSliceMap[K comparable, V any](stripes int, sources ...map[K]V) *StripedSliceMap[K, V] {
+	if stripes <= 0 {
+		panic("the number of stripes must be greater than zero")
+	}
+	m := new(StripedSliceMap[K, V])
+	m.once.Do(func() {
+		m.makeStripes(stripes)
+	})
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// init makes sure the stripes exist.
+func (m *StripedSliceMap[K, V]) init() {
+	m.once.Do(func() {
+		m.makeStripes(defaultStripes)
+	})
+}
+
+// makeStripes creates n empty stripes.
+func (m *StripedSliceMap[K, V]) makeStripes(n int) {
+	m.seed = maphash.MakeSeed()
+	m.stripes = make([]stripe[K, V], n)
+	for i := range m.stripes {
+		m.stripes[i].items = make(map[K]V)
+	}
+}
+
+// stripe returns the stripe that holds the given key.
+func (m *StripedSliceMap[K, V]) stripe(k K) *stripe[K, V] {
+	m.init()
+	return &m.stripes[maphash.Comparable(m.seed, k)%uint64(len(m.stripes))]
+}
+
+// Set sets the given key to the given value.
+//
+// If the key already exists, only the key's stripe is locked, and the range order will not change.
+// Otherwise, the key is added to the end of the map.
+func (m *StripedSliceMap[K, V]) Set(k K, v V) {
+	s := m.stripe(k)
+	s.Lock()
+	if _, ok := s.items[k]; ok {
+		s.items[k] = v
+		s.Unlock()
+		return
+	}
+	s.Unlock()
+
+	// new key, so the order will change
+	m.mu.Lock()
+	s.Lock()
+	if _, ok := s.items[k]; !ok {
+		m.order = append(m.order, k)
+	}
+	s.items[k] = v
+	s.Unlock()
+	m.mu.Unlock()
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load()
+func (m *StripedSliceMap[K, V]) Load(k K) (v V, ok bool) {
+	s := m.stripe(k)
+	s.RLock()
+	v, ok = s.items[k]
+	s.RUnlock()
+	return
+}
+
+// Get returns the value based on its key. If the key does not exist, an empty value is returned.
+func (m *StripedSliceMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the given key exists in the map.
+func (m *StripedSliceMap[K, V]) Has(k K) (ok bool) {
+	_, ok = m.Load(k)
+	return
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *StripedSliceMap[K, V]) Delete(k K) (v V) {
+	v, _ = m.LoadAndDelete(k)
+	return
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+// Like Set, only the key's stripe is locked if the key already exists.
+func (m *StripedSliceMap[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	s := m.stripe(k)
+	s.Lock()
+	if previous, loaded = s.items[k]; loaded {
+		s.items[k] = v
+		s.Unlock()
+		return
+	}
+	s.Unlock()
+
+	m.mu.Lock()
+	s.Lock()
+	if previous, loaded = s.items[k]; !loaded {
+		m.order = append(m.order, k)
+	}
+	s.items[k] = v
+	s.Unlock()
+	m.mu.Unlock()
+	return
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m *StripedSliceMap[K, V]) LoadAndDelete(k K) (v V, loaded bool) {
+	s := m.stripe(k)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s.Lock()
+	defer s.Unlock()
+	if v, loaded = s.items[k]; loaded {
+		delete(s.items, k)
+		i := slices.Index(m.order, k)
+		m.order = slices.Delete(m.order, i, i+1)
+	}
+	return
+}
+
+// GetAt returns the value based on its position. If the position is out of bounds, an empty value is returned.
+func (m *StripedSliceMap[K, V]) GetAt(position int) (v V) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if position < len(m.order) && position >= 0 {
+		v = m.Get(m.order[position])
+	}
+	return
+}
+
+// GetKeyAt returns the key based on its position. If the position is out of bounds, an empty value is returned.
+func (m *StripedSliceMap[K, V]) GetKeyAt(position int) (k K) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if position < len(m.order) && position >= 0 {
+		k = m.order[position]
+	}
+	return
+}
+
+// Clear removes all the items in the map.
+func (m *StripedSliceMap[K, V]) Clear() {
+	m.init()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.stripes {
+		s := &m.stripes[i]
+		s.Lock()
+		clear(s.items)
+		s.Unlock()
+	}
+	m.order = nil
+}
+
+// Len returns the number of items in the map.
+func (m *StripedSliceMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.order)
+}
+
+// Range will call the given function with every key and value in the order they were placed in the map.
+// If f returns false, it stops the iteration. This pattern is taken from sync.Map.
+// During this process, the order of the map will be locked, so do not pass a function that will take
+// significant amounts of time, nor will call into other methods of the StripedSliceMap which might also need a lock.
+func (m *StripedSliceMap[K, V]) Range(f func(k K, v V) bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, k := range m.order {
+		if !f(k, m.Get(k)) {
+			break
+		}
+	}
+}
+
+// Keys returns a new slice of the keys of the map, in the order they were added.
+func (m *StripedSliceMap[K, V]) Keys() (keys []K) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.order)
+}
+
+// Values returns a new slice of the values of the map, in the order they were added.
+func (m *StripedSliceMap[K, V]) Values() (values []V) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.order) == 0 {
+		return
+	}
+	values = make([]V, len(m.order))
+	for i, k := range m.order {
+		values[i] = m.Get(k)
+	}
+	return
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Call Copy instead.
+func (m *StripedSliceMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the keys and values of in into the current one.
+// Duplicate keys will have the values replaced, but not the order.
+func (m *StripedSliceMap[K, V]) Copy(in MapI[K, V]) {
+	in.Range(func(k K, v V) bool {
+		m.Set(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal, regardless of the order.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *StripedSliceMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k K, v V) bool {
+		if v2, ok := m.Load(k); !ok || !equalValues(v, v2) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// String outputs the map as a string.
+func (m *StripedSliceMap[K, V]) String() string {
+	s := "{"
+	m.Range(func(k K, v V) bool {
+		s += fmt.Sprintf(`%#v:%#v,`, k, v)
+		return true
+	})
+	s = strings.TrimRight(s, ",")
+	s += "}"
+	return s
+}
+
+// All returns an iterator over all the items in the map in the order they were entered.
+func (m *StripedSliceMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+// During this process, the order of the map will be locked, so do not call into other methods
+// of the StripedSliceMap which might also need a lock.
+func (m *StripedSliceMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+// During this process, the order of the map will be locked, so do not call into other methods
+// of the StripedSliceMap which might also need a lock.
+func (m *StripedSliceMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the end of the map.
+// Duplicate keys are overridden but not moved.
+func (m *StripedSliceMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.Set(k, v)
+	}
+}
+
+// Clone returns a copy of the StripedSliceMap with the same number of stripes. This is a shallow clone
+// of the keys and values: the new keys and values are set using ordinary assignment. The order is preserved.
+func (m *StripedSliceMap[K, V]) Clone() *StripedSliceMap[K, V] {
+	m.init()
+	m1 := NewStripedSliceMap[K, V](len(m.stripes))
+	m1.Copy(m)
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+// Items are ranged in order.
+// This function locks the order of the map for the entirety of the call,
+// so be careful to avoid deadlocks when calling this on a very big structure.
+func (m *StripedSliceMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, k := range slices.Backward(m.order) {
+		s := m.stripe(k)
+		s.Lock()
+		if del(k, s.items[k]) {
+			delete(s.items, k)
+			m.order = slices.Delete(m.order, i, i+1)
+		}
+		s.Unlock()
+	}
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *StripedSliceMap[K, V]) MarshalBinary() (data []byte, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	buf := new(bytes.Buffer)
+	encoder := gob.NewEncoder(buf)
+
+	values := make([]V, len(m.order))
+	for i, k := range m.order {
+		values[i] = m.Get(k)
+	}
+	err = encoder.Encode(m.order)
+	if err == nil {
+		err = encoder.Encode(values)
+	}
+	data = buf.Bytes()
+	return
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// StripedSliceMap.
+func (m *StripedSliceMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var keys []K
+	var values []V
+
+	buf := bytes.NewBuffer(data)
+	dec := gob.NewDecoder(buf)
+	if err = dec.Decode(&keys); err == nil {
+		err = dec.Decode(&values)
+	}
+	if err == nil && len(keys) != len(values) {
+		err = fmt.Errorf("maps: cannot unmarshal %d keys with %d values", len(keys), len(values))
+	}
+	if err == nil {
+		m.Clear()
+		for i, k := range keys {
+			m.Set(k, values[i])
+		}
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *StripedSliceMap[K, V]) MarshalJSON() (data []byte, err error) {
+	// Json objects are unordered
+	items := make(map[K]V)
+	m.Range(func(k K, v V) bool {
+		items[k] = v
+		return true
+	})
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a StripedSliceMap.
+// The JSON must start with an object.
+func (m *StripedSliceMap[K, V]) UnmarshalJSON(data []byte) (err error) {
+	var items map[K]V
+
+	if err = json.Unmarshal(data, &items); err == nil {
+		m.Clear()
+		for k, v := range items {
+			m.Set(k, v)
+		}
+	}
+	return
+}
+
+// CollectStripedSliceMap collects key-value pairs from seq into a new StripedSliceMap
+// and returns it.
+func CollectStripedSliceMap[K comparable, V any](seq iter.Seq2[K, V]) *StripedSliceMap[K, V] {
+	m := new(StripedSliceMap[K, V])
+	m.Insert(seq)
+	return m
+}
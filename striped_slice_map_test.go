@@ -0,0 +1,86 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripedSliceMap_Mapi(t *testing.T) {
+	runMapiTests[StripedSliceMap[string, int]](t, makeMapi[StripedSliceMap[string, int]])
+}
+
+func init() {
+	gob.Register(new(StripedSliceMap[string, int]))
+}
+
+func ExampleStripedSliceMap_String() {
+	m := NewStripedSliceMap[string, int](4)
+	m.Set("b", 2)
+	m.Set("a", 1)
+	m.Set("b", 3)
+	fmt.Print(m)
+	// Output: {"b":3,"a":1}
+}
+
+func TestStripedSliceMap(t *testing.T) {
+	assert.Panics(t, func() {
+		NewStripedSliceMap[string, int](0)
+	})
+
+	m := NewStripedSliceMap(2, map[string]int{"a": 1})
+	m.Set("b", 2)
+	m.Set("c", 3)
+	assert.Equal(t, "b", m.GetKeyAt(1))
+	assert.Equal(t, 3, m.GetAt(2))
+	assert.Equal(t, 0, m.GetAt(3))
+	assert.Equal(t, "", m.GetKeyAt(-1))
+
+	assert.Equal(t, 2, m.Delete("b"))
+	assert.Equal(t, 0, m.Delete("b"))
+	assert.Equal(t, []string{"a", "c"}, m.Keys())
+	assert.Equal(t, []int{1, 3}, m.Values())
+
+	m2 := m.Clone()
+	m2.Set("d", 4)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, []string{"a", "c", "d"}, m2.Keys())
+
+	m3 := CollectStripedSliceMap(m2.All())
+	assert.Equal(t, []string{"a", "c", "d"}, m3.Keys())
+
+	data, err := m3.MarshalBinary()
+	assert.NoError(t, err)
+	var m4 StripedSliceMap[string, int]
+	assert.NoError(t, m4.UnmarshalBinary(data))
+	assert.Equal(t, []string{"a", "c", "d"}, m4.Keys())
+	assert.Equal(t, []int{1, 3, 4}, m4.Values())
+	assert.Error(t, m4.UnmarshalBinary(gobEncode(t, []string{"a", "b"}, []int{1})))
+	assert.Equal(t, []string{"a", "c", "d"}, m4.Keys())
+}
+
+func TestStripedSliceMap_Concurrent(t *testing.T) {
+	m := new(StripedSliceMap[string, int])
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				k := strconv.Itoa(j)
+				m.Set(k, m.Get(k)+1)
+				if j%10 == i {
+					m.Delete(k)
+				}
+				m.Len()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, len(m.Keys()), m.Len())
+	assert.Equal(t, len(m.Values()), m.Len())
+}
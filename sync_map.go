@@ -0,0 +1,315 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+	"sync"
+)
+
+// SyncMap wraps a sync.Map with a standard set of typed functions shared with other MapI-like types.
+//
+// A SyncMap is safe for concurrent use. For read-mostly workloads, or workloads where go routines
+// work on disjoint sets of keys, a SyncMap will outperform a SafeMap. See the sync.Map documentation for details.
+//
+// The recommended way to create a SyncMap is to first declare a concrete type alias, and then call
+// new on it, like this:
+//
+//	type MyMap = SyncMap[string,int]
+//
+//	m := new(MyMap)
+//
+// This will allow you to swap in a different kind of Map just by changing the type.
+//
+// Do not make a copy of a SyncMap using the equality operator (=). Use Clone instead.
+type SyncMap[K comparable, V any] struct {
+	items sync.Map
+}
+
+// NewSyncMap creates a new SyncMap.
+// Pass in zero or more standard maps and the contents of those maps will be copied to the new SyncMap.
+func NewSyncMap[K comparable, V any](sources ...map[K]V) *SyncMap[K, V] {
+	m := new(SyncMap[K, V])
+	for _, i := range sources {
+		m.Copy(Cast(i))
+	}
+	return m
+}
+
+// Clear removes all the items in the map.
+func (m *SyncMap[K, V]) Clear() {
+	m.items.Clear()
+}
+
+// Set sets the key to the given value.
+func (m *SyncMap[K, V]) Set(k K, v V) {
+	m.items.Store(k, v)
+}
+
+// Store sets the key to the given value. This is the same interface as sync.Map.Store().
+func (m *SyncMap[K, V]) Store(k K, v V) {
+	m.items.Store(k, v)
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+// This is the same interface as sync.Map.Load().
+func (m *SyncMap[K, V]) Load(k K) (v V, ok bool) {
+	var i any
+	if i, ok = m.items.Load(k); ok {
+		v, _ = i.(V)
+	}
+	return
+}
+
+// Get returns the value based on its key. If the key does not exist, the zero value will be returned.
+func (m *SyncMap[K, V]) Get(k K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the given key exists in the map.
+func (m *SyncMap[K, V]) Has(k K) (exists bool) {
+	_, exists = m.items.Load(k)
+	return
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *SyncMap[K, V]) Delete(k K) (v V) {
+	v, _ = m.LoadAndDelete(k)
+	return
+}
+
+// LoadAndDelete removes the key from the map and returns the value it had, and a boolean indicating
+// whether the key was in the map. This is the same interface as sync.Map.LoadAndDelete().
+func (m *SyncMap[K, V]) LoadAndDelete(k K) (v V, loaded bool) {
+	var i any
+	if i, loaded = m.items.LoadAndDelete(k); loaded {
+		v, _ = i.(V)
+	}
+	return
+}
+
+// LoadOrStore returns the existing value for the key if present.
+// Otherwise, it stores and returns the given value.
+// The loaded result is true if the value was loaded, false if stored.
+// This is the same interface as sync.Map.LoadOrStore().
+func (m *SyncMap[K, V]) LoadOrStore(k K, v V) (actual V, loaded bool) {
+	var i any
+	i, loaded = m.items.LoadOrStore(k, v)
+	actual, _ = i.(V)
+	return
+}
+
+// GetOrSet returns the value of the key if it exists. Otherwise, it sets the key to v and returns v.
+func (m *SyncMap[K, V]) GetOrSet(k K, v V) V {
+	actual, _ := m.LoadOrStore(k, v)
+	return actual
+}
+
+// Swap sets the key to the given value and returns the previous value, if any.
+// The loaded result reports whether the key was present. This is the same interface as sync.Map.Swap().
+func (m *SyncMap[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	var i any
+	if i, loaded = m.items.Swap(k, v); loaded {
+		previous, _ = i.(V)
+	}
+	return
+}
+
+// Values returns a slice of the values. It will return a nil slice if the map is empty.
+// Multiple calls to Values will result in the same list of values, but may be in a different order.
+func (m *SyncMap[K, V]) Values() (values []V) {
+	m.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+	return
+}
+
+// Keys returns a slice of the keys. It will return a nil slice if the map is empty.
+// Multiple calls to Keys will result in the same list of keys, but may be in a different order.
+func (m *SyncMap[K, V]) Keys() (keys []K) {
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return
+}
+
+// Len returns the number of items in the map.
+//
+// Since a sync.Map does not track its size, this ranges over the whole map, and the result may not
+// be consistent if other go routines are changing the map at the same time.
+func (m *SyncMap[K, V]) Len() (l int) {
+	m.items.Range(func(_, _ any) bool {
+		l++
+		return true
+	})
+	return
+}
+
+// Range will call the given function with every key and value in the map.
+// If f returns false, it stops the iteration. This is the same interface as sync.Map.Range().
+//
+// The map is not locked during the iteration, so f may call other methods of the SyncMap.
+// See sync.Map.Range for the consistency guarantees.
+func (m *SyncMap[K, V]) Range(f func(k K, v V) bool) {
+	if m == nil {
+		return
+	}
+	m.items.Range(func(k, v any) bool {
+		val, _ := v.(V) // v is nil if a nil interface value was stored
+		return f(k.(K), val)
+	})
+}
+
+// Merge copies the items from in to the map, overwriting any conflicting keys.
+// Deprecated: Use Copy instead.
+func (m *SyncMap[K, V]) Merge(in MapI[K, V]) {
+	m.Copy(in)
+}
+
+// Copy copies the keys and values of in into this map, overwriting any duplicates.
+func (m *SyncMap[K, V]) Copy(in MapI[K, V]) {
+	in.Range(func(k K, v V) bool {
+		m.items.Store(k, v)
+		return true
+	})
+}
+
+// Equal returns true if all the keys and values are equal.
+//
+// If the values are not comparable, you should implement the Equaler interface on the values.
+// Otherwise, you will get a runtime panic.
+func (m *SyncMap[K, V]) Equal(m2 MapI[K, V]) bool {
+	if m.Len() != m2.Len() {
+		return false
+	}
+	ret := true
+	m2.Range(func(k K, v V) bool {
+		if v2, ok := m.Load(k); !ok || !equalValues(v, v2) {
+			ret = false
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// snapshot returns the contents of the map as a standard map.
+func (m *SyncMap[K, V]) snapshot() StdMap[K, V] {
+	s := StdMap[K, V]{}
+	m.Range(func(k K, v V) bool {
+		s[k] = v
+		return true
+	})
+	return s
+}
+
+// MarshalBinary implements the BinaryMarshaler interface to convert the map to a byte stream.
+func (m *SyncMap[K, V]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+
+	enc := gob.NewEncoder(&b)
+	err := enc.Encode(map[K]V(m.snapshot()))
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements the BinaryUnmarshaler interface to convert a byte stream to a
+// SyncMap.
+func (m *SyncMap[K, V]) UnmarshalBinary(data []byte) (err error) {
+	var items StdMap[K, V]
+	if err = items.UnmarshalBinary(data); err == nil {
+		m.Clear()
+		m.Copy(items)
+	}
+	return
+}
+
+// MarshalJSON implements the json.Marshaler interface to convert the map into a JSON object.
+func (m *SyncMap[K, V]) MarshalJSON() (out []byte, err error) {
+	return json.Marshal(map[K]V(m.snapshot()))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface to convert a json object to a SyncMap.
+// The JSON must start with an object.
+func (m *SyncMap[K, V]) UnmarshalJSON(in []byte) (err error) {
+	var items StdMap[K, V]
+	if err = items.UnmarshalJSON(in); err == nil {
+		m.Clear()
+		m.Copy(items)
+	}
+	return
+}
+
+// String outputs the map as a string.
+func (m *SyncMap[K, V]) String() string {
+	s := fmt.Sprintf("%#v", map[K]V(m.snapshot()))
+	loc := strings.IndexRune(s, '{')
+	return s[loc:]
+}
+
+// All returns an iterator over all the items in the map.
+// The map is not locked during the iteration.
+func (m *SyncMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// KeysIter returns an iterator over all the keys in the map.
+// The map is not locked during the iteration.
+func (m *SyncMap[K, V]) KeysIter() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.Range(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// ValuesIter returns an iterator over all the values in the map.
+// The map is not locked during the iteration.
+func (m *SyncMap[K, V]) ValuesIter() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Range(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Insert adds the values from seq to the map.
+// Duplicate keys are overridden.
+func (m *SyncMap[K, V]) Insert(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		m.items.Store(k, v)
+	}
+}
+
+// CollectSyncMap collects key-value pairs from seq into a new SyncMap
+// and returns it.
+func CollectSyncMap[K comparable, V any](seq iter.Seq2[K, V]) *SyncMap[K, V] {
+	m := new(SyncMap[K, V])
+	m.Insert(seq)
+	return m
+}
+
+// Clone returns a copy of the SyncMap. This is a shallow clone:
+// the new keys and values are set using ordinary assignment.
+func (m *SyncMap[K, V]) Clone() *SyncMap[K, V] {
+	m1 := new(SyncMap[K, V])
+	m1.Copy(m)
+	return m1
+}
+
+// DeleteFunc deletes any key/value pairs for which del returns true.
+func (m *SyncMap[K, V]) DeleteFunc(del func(K, V) bool) {
+	m.Range(func(k K, v V) bool {
+		if del(k, v) {
+			m.items.Delete(k)
+		}
+		return true
+	})
+}
@@ -0,0 +1,87 @@
+package maps
+
+import (
+	"encoding/gob"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncMap_Mapi(t *testing.T) {
+	runMapiTests[SyncMap[string, int]](t, makeMapi[SyncMap[string, int]])
+}
+
+func init() {
+	gob.Register(new(SyncMap[string, int]))
+}
+
+func ExampleSyncMap_String() {
+	m := new(SyncMap[string, int])
+	m.Set("a", 1)
+	m.Set("b", 2)
+	fmt.Print(m)
+	// Output: {"a":1, "b":2}
+}
+
+func TestSyncMap(t *testing.T) {
+	m := NewSyncMap(map[string]int{"a": 1})
+
+	v, loaded := m.LoadOrStore("a", 2)
+	assert.Equal(t, 1, v)
+	assert.True(t, loaded)
+	v, loaded = m.LoadOrStore("b", 2)
+	assert.Equal(t, 2, v)
+	assert.False(t, loaded)
+
+	v, loaded = m.Swap("b", 3)
+	assert.Equal(t, 2, v)
+	assert.True(t, loaded)
+	v, loaded = m.Swap("c", 4)
+	assert.Equal(t, 0, v)
+	assert.False(t, loaded)
+
+	m.Store("d", 5)
+	v, loaded = m.LoadAndDelete("d")
+	assert.Equal(t, 5, v)
+	assert.True(t, loaded)
+	_, loaded = m.LoadAndDelete("d")
+	assert.False(t, loaded)
+
+	m2 := m.Clone()
+	assert.True(t, m.Equal(m2))
+	m2.Set("e", 6)
+	assert.False(t, m.Has("e"))
+
+	m3 := CollectSyncMap(m.All())
+	assert.True(t, m3.Equal(StdMap[string, int]{"a": 1, "b": 3, "c": 4}))
+
+	// the map can be changed while ranging
+	m3.Range(func(k string, v int) bool {
+		m3.Delete(k)
+		return true
+	})
+	assert.Equal(t, 0, m3.Len())
+}
+
+func TestSyncMap_NilInterface(t *testing.T) {
+	m := new(SyncMap[string, error])
+	m.Set("a", nil)
+	assert.Nil(t, m.Get("a"))
+	v, ok := m.Load("a")
+	assert.Nil(t, v)
+	assert.True(t, ok)
+	assert.Equal(t, 1, m.Len())
+	assert.Equal(t, []string{"a"}, m.Keys())
+	assert.Equal(t, []error{nil}, m.Values())
+
+	v, loaded := m.LoadOrStore("a", fmt.Errorf("b"))
+	assert.Nil(t, v)
+	assert.True(t, loaded)
+	v, loaded = m.Swap("a", nil)
+	assert.Nil(t, v)
+	assert.True(t, loaded)
+	v, loaded = m.LoadAndDelete("a")
+	assert.Nil(t, v)
+	assert.True(t, loaded)
+}
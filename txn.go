@@ -0,0 +1,80 @@
+package maps
+
+// Tx is a transaction on a SafeMap. See SafeMap.Txn.
+//
+// Changes made through the Tx are buffered, and are only applied to the map if the transaction succeeds.
+// Reads made through the Tx see the changes made earlier in the same transaction.
+type Tx[K comparable, V any] struct {
+	items   StdMap[K, V]
+	changes map[K]txChange[V]
+	keyF    func(K) K
+}
+
+// txChange records a buffered change to a key. If deleted is true, the key will be removed.
+type txChange[V any] struct {
+	v       V
+	deleted bool
+}
+
+// Txn runs f as a transaction on the map. The map is locked for the entire call, so no other
+// go routine can see a partial state. If f returns nil, all the changes made through tx are applied to the map.
+// If f returns an error, none of the changes are applied and the error is returned.
+//
+// Since the map is locked while f is called, f must not call methods of the SafeMap itself, and
+// tx must not be used after f returns.
+func (m *SafeMap[K, V]) Txn(f func(tx *Tx[K, V]) error) error {
+	m.mu.Lock()
+	defer m.unlock()
+
+	tx := &Tx[K, V]{items: m.items, changes: make(map[K]txChange[V]), keyF: m.keyF}
+	if err := f(tx); err != nil {
+		return err
+	}
+	if len(tx.changes) > 0 && m.items == nil {
+		m.items = make(map[K]V, len(tx.changes))
+	}
+	for k, c := range tx.changes {
+		if c.deleted {
+			delete(m.items, k)
+		} else {
+			m.items[k] = c.v
+		}
+	}
+	return nil
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the transaction.
+func (tx *Tx[K, V]) Load(k K) (v V, ok bool) {
+	k = applyKeyFunc(tx.keyF, k)
+	if c, found := tx.changes[k]; found {
+		if c.deleted {
+			return
+		}
+		return c.v, true
+	}
+	return tx.items.Load(k)
+}
+
+// Get returns the value for the given key. If the key does not exist, the zero value will be returned.
+func (tx *Tx[K, V]) Get(k K) (v V) {
+	v, _ = tx.Load(k)
+	return
+}
+
+// Has returns true if the key exists.
+func (tx *Tx[K, V]) Has(k K) (exists bool) {
+	_, exists = tx.Load(k)
+	return
+}
+
+// Set sets the key to the given value when the transaction is applied.
+func (tx *Tx[K, V]) Set(k K, v V) {
+	tx.changes[applyKeyFunc(tx.keyF, k)] = txChange[V]{v: v}
+}
+
+// Delete removes the key when the transaction is applied, and returns the value it had in the transaction.
+func (tx *Tx[K, V]) Delete(k K) (v V) {
+	v = tx.Get(k)
+	tx.changes[applyKeyFunc(tx.keyF, k)] = txChange[V]{deleted: true}
+	return
+}
@@ -0,0 +1,61 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSafeMap_Txn() {
+	m := NewSafeMap(map[string]int{"from": 5, "total": 5})
+	_ = m.Txn(func(tx *Tx[string, int]) error {
+		tx.Set("to", tx.Delete("from"))
+		tx.Set("total", tx.Get("total")+1)
+		return nil
+	})
+	fmt.Print(m.Get("from"), m.Get("to"), m.Get("total"))
+	// Output: 0 5 6
+}
+
+func TestSafeMap_Txn(t *testing.T) {
+	m := NewSafeMap(map[string]int{"a": 1, "b": 2})
+
+	errFail := errors.New("fail")
+	err := m.Txn(func(tx *Tx[string, int]) error {
+		tx.Set("c", 3)
+		tx.Delete("a")
+		return errFail
+	})
+	assert.ErrorIs(t, err, errFail)
+	assert.True(t, m.Equal(StdMap[string, int]{"a": 1, "b": 2}))
+
+	err = m.Txn(func(tx *Tx[string, int]) error {
+		tx.Set("c", 3)
+		assert.True(t, tx.Has("c"))
+		assert.Equal(t, 3, tx.Get("c"))
+
+		assert.Equal(t, 1, tx.Delete("a"))
+		assert.False(t, tx.Has("a"))
+		v, ok := tx.Load("a")
+		assert.Equal(t, 0, v)
+		assert.False(t, ok)
+
+		tx.Set("a", 4)
+		assert.Equal(t, 4, tx.Get("a"))
+		tx.Delete("b")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, m.Equal(StdMap[string, int]{"a": 4, "c": 3}))
+
+	var m2 SafeMap[string, int]
+	err = m2.Txn(func(tx *Tx[string, int]) error {
+		assert.False(t, tx.Has("a"))
+		tx.Set("a", 1)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, m2.Get("a"))
+}
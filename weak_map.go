@@ -0,0 +1,375 @@
+package maps
+
+import (
+	"fmt"
+	"iter"
+	"runtime"
+	"strings"
+	"sync"
+	"weak"
+)
+
+// WeakMap is a map of pointers that does not keep its values alive. When a value is no longer reachable
+// from anywhere other than the map, the garbage collector may reclaim it, and its item is then removed from the map.
+//
+// Use a WeakMap for caches of large objects, like decoded images or parsed documents, that should be
+// shared while something is using them, but that should not be kept in memory just because they are in the cache.
+//
+// Items are removed by the runtime some time after the garbage collector reclaims their values, so Len may
+// count items whose values are gone. Get, Load, Has and the ranging methods never return those items.
+//
+// WeakMap is safe for concurrent use, since the runtime removes items from a separate go routine.
+// The zero value is ready to use. Do not make a copy of a WeakMap using the equality operator (=).
+type WeakMap[K comparable, V any] struct {
+	mu    sync.Mutex
+	items map[K]weakEntry[V]
+}
+
+// weakEntry is an item of a WeakMap.
+type weakEntry[V any] struct {
+	p       weak.Pointer[V]
+	cleanup runtime.Cleanup
+}
+
+// weakCleanupArg is the argument given to a WeakMap cleanup function.
+// It must not refer to the value, or the value would never be reclaimed.
+type weakCleanupArg[K comparable, V any] struct {
+	k K
+	p weak.Pointer[V]
+}
+
+// NewWeakMap creates a new, empty WeakMap.
+func NewWeakMap[K comparable, V any]() *WeakMap[K, V] {
+	return new(WeakMap[K, V])
+}
+
+// Set sets the key to the given value. Setting a key to nil deletes it.
+func (m *WeakMap[K, V]) Set(k K, v *V) {
+	if m == nil {
+		panic("cannot set a value on a nil WeakMap")
+	}
+	if v == nil {
+		m.Delete(k)
+		return
+	}
+	p := weak.Make(v)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.items[k]; ok {
+		if e.p == p {
+			return
+		}
+		e.cleanup.Stop()
+	}
+	if m.items == nil {
+		m.items = make(map[K]weakEntry[V])
+	}
+	m.items[k] = weakEntry[V]{
+		p:       p,
+		cleanup: runtime.AddCleanup(v, m.remove, weakCleanupArg[K, V]{k, p}),
+	}
+}
+
+// remove is called by the runtime after the value of an item has been reclaimed.
+func (m *WeakMap[K, V]) remove(arg weakCleanupArg[K, V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// the key may have been set to a new value since
+	if e, ok := m.items[arg.k]; ok && e.p == arg.p {
+		delete(m.items, arg.k)
+	}
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+func (m *WeakMap[K, V]) Load(k K) (v *V, ok bool) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	e, found := m.items[k]
+	m.mu.Unlock()
+	if !found {
+		return
+	}
+	v = e.p.Value()
+	return v, v != nil
+}
+
+// Get returns the value based on its key. If the key does not exist, or its value has been reclaimed, nil is returned.
+func (m *WeakMap[K, V]) Get(k K) (v *V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the given key exists in the map and its value has not been reclaimed.
+func (m *WeakMap[K, V]) Has(k K) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// Delete removes the key from the map and returns the value.
+// If the key does not exist, or its value has been reclaimed, nil is returned.
+func (m *WeakMap[K, V]) Delete(k K) (v *V) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.items[k]; ok {
+		e.cleanup.Stop()
+		delete(m.items, k)
+		v = e.p.Value()
+	}
+	return
+}
+
+// Clear removes all the items in the map.
+func (m *WeakMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.items {
+		e.cleanup.Stop()
+	}
+	m.items = nil
+}
+
+// Len returns the number of items in the map. This may include items whose values have been
+// reclaimed, but not yet removed.
+func (m *WeakMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.items)
+}
+
+// live returns the keys and values of the items whose values have not been reclaimed.
+// The values are strong pointers, so they will not be reclaimed while they are being used.
+func (m *WeakMap[K, V]) live() (pairs []Pair[K, *V]) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, e := range m.items {
+		if v := e.p.Value(); v != nil {
+			pairs = append(pairs, Pair[K, *V]{k, v})
+		}
+	}
+	return
+}
+
+// Range calls the given function with every key and value in the map whose value has not been reclaimed.
+// If f returns false, it stops the iteration.
+// The items are gathered under a brief lock, and the map is not locked while f is called,
+// so f may call other methods of the WeakMap.
+func (m *WeakMap[K, V]) Range(f func(k K, v *V) bool) {
+	for _, p := range m.live() {
+		if !f(p.Key, p.Value) {
+			break
+		}
+	}
+}
+
+// All returns an iterator over the items in the map whose values have not been reclaimed.
+func (m *WeakMap[K, V]) All() iter.Seq2[K, *V] {
+	return func(yield func(K, *V) bool) {
+		m.Range(yield)
+	}
+}
+
+// Keys returns a slice of the keys whose values have not been reclaimed.
+func (m *WeakMap[K, V]) Keys() (keys []K) {
+	for _, p := range m.live() {
+		keys = append(keys, p.Key)
+	}
+	return
+}
+
+// Values returns a slice of the values that have not been reclaimed.
+func (m *WeakMap[K, V]) Values() (values []*V) {
+	for _, p := range m.live() {
+		values = append(values, p.Value)
+	}
+	return
+}
+
+// String outputs the map as a string.
+func (m *WeakMap[K, V]) String() string {
+	if m == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("{")
+	for i, p := range m.live() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%#v:%#v", p.Key, *p.Value)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// WeakKeyMap is a map keyed by pointers that does not keep its keys alive. When a key is no longer reachable
+// from anywhere other than the map, the garbage collector may reclaim it, and its item is then removed from the map.
+//
+// Use a WeakKeyMap to attach extra data to objects you do not control, without having to remove the data
+// when the objects are no longer used. Keys are compared by identity, like an IdentityMap.
+//
+// The value of an item must not refer to its key, or the key will never be reclaimed.
+//
+// Items are removed by the runtime some time after the garbage collector reclaims their keys, so Len may
+// count items whose keys are gone.
+//
+// WeakKeyMap is safe for concurrent use, since the runtime removes items from a separate go routine.
+// The zero value is ready to use. Do not make a copy of a WeakKeyMap using the equality operator (=).
+type WeakKeyMap[K any, V any] struct {
+	mu    sync.Mutex
+	items map[weak.Pointer[K]]weakKeyEntry[V]
+}
+
+// weakKeyEntry is an item of a WeakKeyMap.
+type weakKeyEntry[V any] struct {
+	v       V
+	cleanup runtime.Cleanup
+}
+
+// NewWeakKeyMap creates a new, empty WeakKeyMap.
+func NewWeakKeyMap[K any, V any]() *WeakKeyMap[K, V] {
+	return new(WeakKeyMap[K, V])
+}
+
+// Set sets the key to the given value. The key must not be nil.
+func (m *WeakKeyMap[K, V]) Set(k *K, v V) {
+	if m == nil {
+		panic("cannot set a value on a nil WeakKeyMap")
+	}
+	if k == nil {
+		panic("cannot use a nil key in a WeakKeyMap")
+	}
+	p := weak.Make(k)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.items[p]; ok {
+		e.v = v
+		m.items[p] = e
+		return
+	}
+	if m.items == nil {
+		m.items = make(map[weak.Pointer[K]]weakKeyEntry[V])
+	}
+	m.items[p] = weakKeyEntry[V]{
+		v:       v,
+		cleanup: runtime.AddCleanup(k, m.remove, p),
+	}
+}
+
+// remove is called by the runtime after the key of an item has been reclaimed.
+func (m *WeakKeyMap[K, V]) remove(p weak.Pointer[K]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.items, p)
+}
+
+// Load returns the value based on its key, and a boolean indicating whether it exists in the map.
+func (m *WeakKeyMap[K, V]) Load(k *K) (v V, ok bool) {
+	if m == nil || k == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.items[weak.Make(k)]
+	return e.v, ok
+}
+
+// Get returns the value based on its key. If the key does not exist, the zero value will be returned.
+func (m *WeakKeyMap[K, V]) Get(k *K) (v V) {
+	v, _ = m.Load(k)
+	return
+}
+
+// Has returns true if the given key exists in the map.
+func (m *WeakKeyMap[K, V]) Has(k *K) (exists bool) {
+	_, exists = m.Load(k)
+	return
+}
+
+// Delete removes the key from the map and returns the value. If the key does not exist, the zero value will be returned.
+func (m *WeakKeyMap[K, V]) Delete(k *K) (v V) {
+	if m == nil || k == nil {
+		return
+	}
+	p := weak.Make(k)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.items[p]; ok {
+		e.cleanup.Stop()
+		delete(m.items, p)
+		v = e.v
+	}
+	return
+}
+
+// Clear removes all the items in the map.
+func (m *WeakKeyMap[K, V]) Clear() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.items {
+		e.cleanup.Stop()
+	}
+	m.items = nil
+}
+
+// Len returns the number of items in the map. This may include items whose keys have been
+// reclaimed, but not yet removed.
+func (m *WeakKeyMap[K, V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.items)
+}
+
+// Range calls the given function with every key and value in the map whose key has not been reclaimed.
+// If f returns false, it stops the iteration.
+// The items are gathered under a brief lock, and the map is not locked while f is called,
+// so f may call other methods of the WeakKeyMap.
+func (m *WeakKeyMap[K, V]) Range(f func(k *K, v V) bool) {
+	if m == nil {
+		return
+	}
+	type item struct {
+		k *K
+		v V
+	}
+	var items []item
+	m.mu.Lock()
+	for p, e := range m.items {
+		if k := p.Value(); k != nil {
+			items = append(items, item{k, e.v})
+		}
+	}
+	m.mu.Unlock()
+
+	for _, i := range items {
+		if !f(i.k, i.v) {
+			break
+		}
+	}
+}
+
+// All returns an iterator over the items in the map whose keys have not been reclaimed.
+func (m *WeakKeyMap[K, V]) All() iter.Seq2[*K, V] {
+	return func(yield func(*K, V) bool) {
+		m.Range(yield)
+	}
+}
@@ -0,0 +1,113 @@
+package maps
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type weakTestValue struct {
+	name string
+	data [64]byte
+}
+
+// waitForLen runs the garbage collector until f returns want, or gives up after a while.
+func waitForLen(f func() int, want int) int {
+	for range 100 {
+		runtime.GC()
+		if n := f(); n == want {
+			return n
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return f()
+}
+
+func TestWeakMap(t *testing.T) {
+	m := NewWeakMap[string, weakTestValue]()
+	a := &weakTestValue{name: "a"}
+	b := &weakTestValue{name: "b"}
+	m.Set("a", a)
+	m.Set("b", b)
+	m.Set("c", &weakTestValue{name: "c"})
+	m.Set("d", &weakTestValue{name: "d"})
+	m.Set("d", nil)
+	assert.False(t, m.Has("d"))
+
+	assert.Same(t, a, m.Get("a"))
+	assert.Equal(t, 2, waitForLen(m.Len, 2), "unreachable values are removed")
+	assert.Nil(t, m.Get("c"))
+	assert.ElementsMatch(t, []string{"a", "b"}, m.Keys())
+	assert.ElementsMatch(t, []*weakTestValue{a, b}, m.Values())
+
+	// setting a new value keeps the item when the old value is reclaimed
+	m.Set("b", &weakTestValue{name: "b2"})
+	b = nil
+	m.Set("b", a)
+	runtime.GC()
+	assert.Same(t, a, m.Get("b"))
+
+	assert.Same(t, a, m.Delete("b"))
+	n := 0
+	for k, v := range m.All() {
+		assert.Equal(t, "a", k)
+		assert.Same(t, a, v)
+		n++
+	}
+	assert.Equal(t, 1, n)
+	assert.Contains(t, m.String(), `"a":maps.weakTestValue{name:"a"`)
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	runtime.KeepAlive(a)
+
+	var m2 *WeakMap[string, weakTestValue]
+	assert.Equal(t, 0, m2.Len())
+	assert.Nil(t, m2.Get("a"))
+	assert.Nil(t, m2.Delete("a"))
+	assert.Nil(t, m2.Keys())
+	assert.Equal(t, "", m2.String())
+	m2.Clear()
+	assert.Panics(t, func() {
+		m2.Set("a", a)
+	})
+}
+
+func TestWeakKeyMap(t *testing.T) {
+	m := NewWeakKeyMap[weakTestValue, string]()
+	a := &weakTestValue{name: "a"}
+	a2 := &weakTestValue{name: "a"}
+	m.Set(a, "first")
+	m.Set(a2, "second")
+	m.Set(&weakTestValue{name: "b"}, "third")
+	assert.Equal(t, "first", m.Get(a), "keys are compared by identity")
+	m.Set(a, "fourth")
+	assert.Equal(t, "fourth", m.Get(a))
+	assert.Equal(t, 2, waitForLen(m.Len, 2), "unreachable keys are removed")
+
+	assert.Equal(t, "second", m.Delete(a2))
+	assert.False(t, m.Has(a2))
+	n := 0
+	for k, v := range m.All() {
+		assert.Same(t, a, k)
+		assert.Equal(t, "fourth", v)
+		n++
+	}
+	assert.Equal(t, 1, n)
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(a2)
+
+	var m2 *WeakKeyMap[weakTestValue, string]
+	assert.Equal(t, 0, m2.Len())
+	assert.Equal(t, "", m2.Get(a))
+	assert.Equal(t, "", m2.Delete(a))
+	assert.Panics(t, func() {
+		m2.Set(a, "a")
+	})
+	assert.Panics(t, func() {
+		m.Set(nil, "a")
+	})
+}